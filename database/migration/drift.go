@@ -0,0 +1,276 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	"gorm.io/gorm/schema"
+)
+
+// Models are the entities stored in the tables the migrations create.
+// Many to many join tables are checked through the entities referring to them.
+var Models = []interface{}{
+	&entities.Category{},
+	&entities.IdempotencyKey{},
+	&entities.Item{},
+	&entities.ItemImage{},
+	&entities.ItemPrice{},
+	&entities.ItemRevision{},
+	&entities.ItemVariant{},
+	&entities.Job{},
+	&entities.StockMovement{},
+	&entities.StockReservation{},
+	&entities.Tag{},
+}
+
+// namingStrategy is the one of database.NamingStrategy, declared here so
+// the migrations don't depend on the packages pkg/database connects with.
+var namingStrategy = schema.NamingStrategy{SingularTable: true}
+
+// columnTypes are the MySQL data types a GORM data type may be stored in.
+var columnTypes = map[schema.DataType][]string{
+	schema.Bool:   {"tinyint", "bit"},
+	schema.Int:    {"tinyint", "smallint", "mediumint", "int", "bigint"},
+	schema.Uint:   {"tinyint", "smallint", "mediumint", "int", "bigint"},
+	schema.Float:  {"float", "double", "decimal"},
+	schema.String: {"char", "varchar", "tinytext", "text", "mediumtext", "longtext", "enum", "json"},
+	schema.Time:   {"date", "datetime", "timestamp"},
+	schema.Bytes:  {"binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob"},
+}
+
+type (
+	// Drift is a difference between the entities and the live schema.
+	Drift struct {
+		Table string
+		// Column or Index is empty when the drift concerns the table.
+		Column  string
+		Index   string
+		Problem string
+	}
+
+	// table is either what the entities expect or what the database has.
+	table struct {
+		name    string
+		columns map[string]column
+		indexes []index
+	}
+
+	column struct {
+		// dataType is a schema.DataType when expected, a MySQL DATA_TYPE when live.
+		dataType string
+		unsigned bool
+	}
+
+	index struct {
+		name    string
+		columns []string
+		unique  bool
+	}
+)
+
+func (d Drift) String() string {
+	switch {
+	case d.Column != "":
+		return fmt.Sprintf("%s.%s: %s", d.Table, d.Column, d.Problem)
+	case d.Index != "":
+		return fmt.Sprintf("%s %s: %s", d.Table, d.Index, d.Problem)
+	}
+	return fmt.Sprintf("%s: %s", d.Table, d.Problem)
+}
+
+// Check compares the GORM metadata of models with the information_schema
+// of the database db is connected to: missing tables, columns and indexes,
+// columns of a type the field cannot be stored in, and columns no field maps.
+func Check(ctx context.Context, db *sql.DB, models ...interface{}) ([]Drift, error) {
+	expected, err := expectedTables(models)
+	if err != nil {
+		return nil, err
+	}
+	live, err := liveTables(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	return compare(expected, live), nil
+}
+
+// expectedTables reads the tables models map to, including their join
+// tables, the indexes declared with index tags and an index for every
+// foreign key of a relationship.
+func expectedTables(models []interface{}) (map[string]*table, error) {
+	cache := &sync.Map{}
+	tables := map[string]*table{}
+	get := func(s *schema.Schema) *table {
+		t, ok := tables[s.Table]
+		if !ok {
+			t = &table{name: s.Table, columns: map[string]column{}}
+			tables[s.Table] = t
+		}
+		for _, f := range s.Fields {
+			if f.DBName != "" && !f.IgnoreMigration {
+				t.columns[f.DBName] = column{dataType: string(f.DataType), unsigned: f.DataType == schema.Uint}
+			}
+		}
+		return t
+	}
+
+	for _, model := range models {
+		s, err := schema.Parse(model, cache, namingStrategy)
+		if err != nil {
+			return nil, err
+		}
+		t := get(s)
+		for _, idx := range s.ParseIndexes() {
+			i := index{name: idx.Name, unique: idx.Class == "UNIQUE"}
+			for _, f := range idx.Fields {
+				i.columns = append(i.columns, f.DBName)
+			}
+			t.expect(i)
+		}
+		for _, rel := range s.Relationships.Relations {
+			for _, ref := range rel.References {
+				// the key is in the join table, the related table or s itself
+				fk := ref.ForeignKey
+				get(fk.Schema).expect(index{name: "foreign key " + fk.DBName, columns: []string{fk.DBName}})
+			}
+		}
+	}
+	return tables, nil
+}
+
+// expect adds i unless t expects it already, e.g. through two relationships.
+func (t *table) expect(i index) {
+	for _, e := range t.indexes {
+		if e.name == i.name {
+			return
+		}
+	}
+	t.indexes = append(t.indexes, i)
+}
+
+func liveTables(ctx context.Context, db *sql.DB) (map[string]*table, error) {
+	tables := map[string]*table{}
+	rows, err := db.QueryContext(ctx, "SELECT TABLE_NAME, COLUMN_NAME, DATA_TYPE, COLUMN_TYPE FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE()")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var tableName, columnName, dataType, columnType string
+		if err := rows.Scan(&tableName, &columnName, &dataType, &columnType); err != nil {
+			return nil, err
+		}
+		t, ok := tables[tableName]
+		if !ok {
+			t = &table{name: tableName, columns: map[string]column{}}
+			tables[tableName] = t
+		}
+		t.columns[columnName] = column{dataType: strings.ToLower(dataType), unsigned: strings.Contains(strings.ToLower(columnType), "unsigned")}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = db.QueryContext(ctx, "SELECT TABLE_NAME, INDEX_NAME, NON_UNIQUE, COLUMN_NAME FROM information_schema.STATISTICS WHERE TABLE_SCHEMA = DATABASE() ORDER BY TABLE_NAME, INDEX_NAME, SEQ_IN_INDEX")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var tableName, indexName, columnName string
+		var nonUnique int
+		if err := rows.Scan(&tableName, &indexName, &nonUnique, &columnName); err != nil {
+			return nil, err
+		}
+		t, ok := tables[tableName]
+		if !ok {
+			continue
+		}
+		if n := len(t.indexes); n == 0 || t.indexes[n-1].name != indexName {
+			t.indexes = append(t.indexes, index{name: indexName, unique: nonUnique == 0})
+		}
+		i := &t.indexes[len(t.indexes)-1]
+		i.columns = append(i.columns, columnName)
+	}
+	return tables, rows.Err()
+}
+
+func compare(expected map[string]*table, live map[string]*table) (drifts []Drift) {
+	for name, e := range expected {
+		l, ok := live[name]
+		if !ok {
+			drifts = append(drifts, Drift{Table: name, Problem: "missing table"})
+			continue
+		}
+		for columnName, ec := range e.columns {
+			lc, ok := l.columns[columnName]
+			if !ok {
+				drifts = append(drifts, Drift{Table: name, Column: columnName, Problem: "missing column"})
+				continue
+			}
+			if problem := compareColumn(ec, lc); problem != "" {
+				drifts = append(drifts, Drift{Table: name, Column: columnName, Problem: problem})
+			}
+		}
+		for columnName := range l.columns {
+			if _, ok := e.columns[columnName]; !ok {
+				drifts = append(drifts, Drift{Table: name, Column: columnName, Problem: "column is not mapped by the entity"})
+			}
+		}
+		for _, ei := range e.indexes {
+			if !covered(ei, l.indexes) {
+				drifts = append(drifts, Drift{Table: name, Index: ei.name, Problem: fmt.Sprintf("missing index on (%s)", strings.Join(ei.columns, ", "))})
+			}
+		}
+	}
+	sort.Slice(drifts, func(i, j int) bool {
+		return drifts[i].String() < drifts[j].String()
+	})
+	return drifts
+}
+
+func compareColumn(expected column, live column) string {
+	types, ok := columnTypes[schema.DataType(expected.dataType)]
+	if !ok {
+		// custom types, e.g. entities.VariantOptions, store as their Value does
+		return ""
+	}
+	for _, t := range types {
+		if t == live.dataType {
+			if expected.unsigned && !live.unsigned {
+				return fmt.Sprintf("type mismatch: %s needs an unsigned column", expected.dataType)
+			}
+			return ""
+		}
+	}
+	return fmt.Sprintf("type mismatch: %s cannot be stored in %s", expected.dataType, live.dataType)
+}
+
+// covered reports whether an index of live serves expected regardless of
+// its name: a unique index needs the same columns, any other index leading
+// with the expected columns will do.
+func covered(expected index, live []index) bool {
+	for _, l := range live {
+		if expected.unique && (!l.unique || len(l.columns) != len(expected.columns)) {
+			continue
+		}
+		if len(l.columns) < len(expected.columns) {
+			continue
+		}
+		match := true
+		for i, c := range expected.columns {
+			if l.columns[i] != c {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	_ "github.com/go-sql-driver/mysql"
+	. "github.com/smartystreets/goconvey/convey"
+	"gorm.io/gorm"
+)
+
+type driftItem struct {
+	gorm.Model
+	Code  string `gorm:"uniqueIndex:code_UNIQUE"`
+	Name  string
+	Price int
+}
+
+func Test_Check(t *testing.T) {
+	Convey("エンティティとスキーマの差分を報告する", t, func() {
+		db, mock, err := sqlmock.New()
+		So(err, ShouldBeNil)
+		defer db.Close()
+		mock.ExpectQuery("FROM information_schema.COLUMNS").WillReturnRows(
+			sqlmock.NewRows([]string{"TABLE_NAME", "COLUMN_NAME", "DATA_TYPE", "COLUMN_TYPE"}).
+				AddRow("drift_item", "id", "bigint", "bigint").
+				AddRow("drift_item", "code", "varchar", "varchar(64)").
+				AddRow("drift_item", "price", "varchar", "varchar(16)").
+				AddRow("drift_item", "user_id", "bigint", "bigint unsigned").
+				AddRow("drift_item", "created_at", "datetime", "datetime").
+				AddRow("drift_item", "updated_at", "datetime", "datetime").
+				AddRow("drift_item", "deleted_at", "datetime", "datetime"))
+		mock.ExpectQuery("FROM information_schema.STATISTICS").WillReturnRows(
+			sqlmock.NewRows([]string{"TABLE_NAME", "INDEX_NAME", "NON_UNIQUE", "COLUMN_NAME"}).
+				AddRow("drift_item", "PRIMARY", 0, "id").
+				AddRow("drift_item", "code_idx", 1, "code").
+				AddRow("drift_item", "deleted_at_created_at_idx", 1, "deleted_at").
+				AddRow("drift_item", "deleted_at_created_at_idx", 1, "created_at"))
+
+		drifts, err := Check(context.Background(), db, &driftItem{}, &missingTable{})
+		So(err, ShouldBeNil)
+		actual := []string{}
+		for _, d := range drifts {
+			actual = append(actual, d.String())
+		}
+		So(actual, ShouldResemble, []string{
+			"drift_item code_UNIQUE: missing index on (code)",
+			"drift_item.id: type mismatch: uint needs an unsigned column",
+			"drift_item.name: missing column",
+			"drift_item.price: type mismatch: int cannot be stored in varchar",
+			"drift_item.user_id: column is not mapped by the entity",
+			"missing_table: missing table",
+		})
+		So(mock.ExpectationsWereMet(), ShouldBeNil)
+	})
+}
+
+// missingTable is a model without a table.
+type missingTable struct {
+	ID uint
+}
+
+// Test_CheckLive applies the migrations to the MySQL database of
+// MIGRATION_TEST_DSN, e.g. "root:password@tcp(localhost:3306)/go_app_test?parseTime=true",
+// and expects no drift. It is skipped without one.
+func Test_CheckLive(t *testing.T) {
+	dsn := os.Getenv("MIGRATION_TEST_DSN")
+	if dsn == "" {
+		t.Skip("MIGRATION_TEST_DSN is not set")
+	}
+	Convey("マイグレーション後のスキーマがエンティティと一致する", t, func() {
+		db, err := sql.Open("mysql", dsn)
+		So(err, ShouldBeNil)
+		defer db.Close()
+		ctx := context.Background()
+
+		_, err = NewMigrator(db, time.Minute).Up(ctx)
+		So(err, ShouldBeNil)
+		drifts, err := Check(ctx, db, Models...)
+		So(err, ShouldBeNil)
+		So(drifts, ShouldBeEmpty)
+	})
+}
@@ -0,0 +1,171 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+const (
+	// TableName records the applied migrations.
+	TableName = "schema_migrations"
+	// lockName is the MySQL named lock held while migrating, so instances
+	// starting together do not apply the same migration twice.
+	lockName = "schema_migrations"
+	dialect  = "mysql"
+)
+
+//go:embed sqls/*.sql
+var sqls embed.FS
+
+var (
+	ErrLocked = errors.New("migration: another run holds the lock")
+
+	createName = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+)
+
+type (
+	// Migrator applies the migrations in sqls, which are built into the binary.
+	Migrator struct {
+		db          *sql.DB
+		set         migrate.MigrationSet
+		source      migrate.MigrationSource
+		lockTimeout time.Duration
+	}
+
+	Status struct {
+		ID string
+		// AppliedAt is nil while the migration is pending.
+		AppliedAt *time.Time
+		// Unknown is set for applied migrations missing from the binary,
+		// e.g. after rolling back to an older release.
+		Unknown bool
+	}
+)
+
+func NewMigrator(db *sql.DB, lockTimeout time.Duration) *Migrator {
+	files, _ := fs.Sub(sqls, "sqls")
+	return &Migrator{
+		db:          db,
+		set:         migrate.MigrationSet{TableName: TableName},
+		source:      migrate.HttpFileSystemMigrationSource{FileSystem: http.FS(files)},
+		lockTimeout: lockTimeout,
+	}
+}
+
+// Up applies every pending migration and returns how many were applied.
+func (m *Migrator) Up(ctx context.Context) (n int, err error) {
+	err = m.withLock(ctx, func() error {
+		n, err = m.set.Exec(m.db, dialect, m.source, migrate.Up)
+		return err
+	})
+	return n, err
+}
+
+// Down rolls back the last max migrations.
+func (m *Migrator) Down(ctx context.Context, max int) (n int, err error) {
+	if max <= 0 {
+		return 0, fmt.Errorf("migration: down needs a positive count, got %d", max)
+	}
+	err = m.withLock(ctx, func() error {
+		n, err = m.set.ExecMax(m.db, dialect, m.source, migrate.Down, max)
+		return err
+	})
+	return n, err
+}
+
+// Redo rolls back the last migration and applies it again.
+func (m *Migrator) Redo(ctx context.Context) (id string, err error) {
+	err = m.withLock(ctx, func() error {
+		planned, _, err := m.set.PlanMigration(m.db, dialect, m.source, migrate.Down, 1)
+		if err != nil {
+			return err
+		}
+		if len(planned) == 0 {
+			return errors.New("migration: nothing to redo")
+		}
+		id = planned[0].Id
+		if _, err := m.set.ExecMax(m.db, dialect, m.source, migrate.Down, 1); err != nil {
+			return err
+		}
+		_, err = m.set.ExecMax(m.db, dialect, m.source, migrate.Up, 1)
+		return err
+	})
+	return id, err
+}
+
+// Status lists the migrations in the binary, then applied ones it lacks.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	migrations, err := m.source.FindMigrations()
+	if err != nil {
+		return nil, err
+	}
+	records, err := m.set.GetMigrationRecords(m.db, dialect)
+	if err != nil {
+		return nil, err
+	}
+	applied := map[string]time.Time{}
+	for _, r := range records {
+		applied[r.Id] = r.AppliedAt
+	}
+
+	statuses := make([]Status, 0, len(migrations))
+	for _, migration := range migrations {
+		s := Status{ID: migration.Id}
+		if at, ok := applied[migration.Id]; ok {
+			s.AppliedAt = &at
+			delete(applied, migration.Id)
+		}
+		statuses = append(statuses, s)
+	}
+	for _, r := range records {
+		if at, ok := applied[r.Id]; ok {
+			statuses = append(statuses, Status{ID: r.Id, AppliedAt: &at, Unknown: true})
+		}
+	}
+	return statuses, nil
+}
+
+// withLock runs fn holding the named lock on a connection of its own, as a
+// MySQL named lock belongs to the session that took it.
+func (m *Migrator) withLock(ctx context.Context, fn func() error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var locked sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, int(m.lockTimeout.Seconds())).Scan(&locked); err != nil {
+		return err
+	}
+	if !locked.Valid || locked.Int64 != 1 {
+		return ErrLocked
+	}
+	defer conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", lockName)
+	return fn()
+}
+
+// Create writes an empty migration named like "202101291548-<name>.sql" to
+// dir and returns its path. name is lower case words joined by "-".
+func Create(dir string, name string, now time.Time) (string, error) {
+	if !createName.MatchString(name) {
+		return "", fmt.Errorf("migration: name %q is not lower case words joined by \"-\"", name)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.sql", now.Format("200601021504"), name))
+	if _, err := os.Stat(path); err == nil {
+		return "", fmt.Errorf("migration: %s exists", path)
+	}
+	return path, ioutil.WriteFile(path, []byte("-- +migrate Up\n\n\n-- +migrate Down\n"), 0o644)
+}
@@ -0,0 +1,66 @@
+package migration
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_Source(t *testing.T) {
+	Convey("組み込んだマイグレーションを読み込める", t, func() {
+		m := NewMigrator(nil, time.Second)
+		migrations, err := m.source.FindMigrations()
+		So(err, ShouldBeNil)
+		So(migrations, ShouldNotBeEmpty)
+		for _, migration := range migrations {
+			So(migration.Up, ShouldNotBeEmpty)
+			So(migration.Down, ShouldNotBeEmpty)
+		}
+	})
+}
+
+func Test_Lock(t *testing.T) {
+	Convey("ロックを取れなければ実行しない", t, func() {
+		db, mock, err := sqlmock.New()
+		So(err, ShouldBeNil)
+		defer db.Close()
+		mock.ExpectQuery("SELECT GET_LOCK").WithArgs(lockName, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(0))
+
+		n, err := NewMigrator(db, time.Second).Up(context.Background())
+		So(err, ShouldEqual, ErrLocked)
+		So(n, ShouldEqual, 0)
+		So(mock.ExpectationsWereMet(), ShouldBeNil)
+	})
+	Convey("down は正の件数が必要", t, func() {
+		_, err := NewMigrator(nil, time.Second).Down(context.Background(), 0)
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func Test_Create(t *testing.T) {
+	Convey("空のマイグレーションを作成する", t, func() {
+		dir := t.TempDir()
+		now := time.Date(2026, 10, 20, 3, 4, 0, 0, time.UTC)
+
+		path, err := Create(dir, "item-sku", now)
+		So(err, ShouldBeNil)
+		So(path, ShouldEqual, filepath.Join(dir, "202610200304-item-sku.sql"))
+		b, _ := ioutil.ReadFile(path)
+		So(string(b), ShouldStartWith, "-- +migrate Up")
+
+		Convey("同じ名前は作成しない", func() {
+			_, err := Create(dir, "item-sku", now)
+			So(err, ShouldNotBeNil)
+		})
+		Convey("名前は小文字をハイフンでつなぐ", func() {
+			_, err := Create(dir, "Item SKU", now)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
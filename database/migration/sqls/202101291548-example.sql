@@ -1,4 +1,12 @@
 -- +migrate Up
+CREATE TABLE `user` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `created_at` DATETIME NOT NULL,
+    `updated_at` DATETIME NULL,
+    `deleted_at` DATETIME NULL,
+    PRIMARY KEY (`id`))
+ENGINE = InnoDB;
+
 CREATE TABLE `item` (
     `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
     `user_id` BIGINT UNSIGNED NOT NULL,
@@ -17,3 +25,4 @@ ENGINE = InnoDB;
 
 -- +migrate Down
 DROP TABLE `item`;
+DROP TABLE `user`;
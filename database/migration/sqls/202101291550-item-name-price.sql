@@ -0,0 +1,21 @@
+-- +migrate Up
+-- entities.Item has no owner; code is added by 202610191800-item-code
+ALTER TABLE `item`
+    DROP FOREIGN KEY `fk_item_user1`,
+    DROP INDEX `fk_item_user1_idx`,
+    DROP COLUMN `user_id`,
+    ADD COLUMN `name` VARCHAR(255) NOT NULL DEFAULT '' AFTER `id`,
+    ADD COLUMN `price` INT NOT NULL DEFAULT 0 AFTER `name`;
+
+
+-- +migrate Down
+ALTER TABLE `item`
+    DROP COLUMN `price`,
+    DROP COLUMN `name`,
+    ADD COLUMN `user_id` BIGINT UNSIGNED NOT NULL AFTER `id`,
+    ADD INDEX `fk_item_user1_idx` (`user_id` ASC),
+    ADD CONSTRAINT `fk_item_user1`
+    FOREIGN KEY (`user_id`)
+     REFERENCES `user` (`id`)
+     ON DELETE NO ACTION
+     ON UPDATE NO ACTION;
@@ -0,0 +1,23 @@
+-- +migrate Up
+CREATE TABLE `item_image` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `item_id` BIGINT UNSIGNED NOT NULL,
+    `object_key` VARCHAR(255) NOT NULL,
+    `content_type` VARCHAR(64) NOT NULL,
+    `size` BIGINT NOT NULL,
+    `created_at` DATETIME NOT NULL,
+    `updated_at` DATETIME NULL,
+    `deleted_at` DATETIME NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `object_key_UNIQUE` (`object_key` ASC),
+    INDEX `fk_item_image_item1_idx` (`item_id` ASC),
+    CONSTRAINT `fk_item_image_item1`
+    FOREIGN KEY (`item_id`)
+     REFERENCES `item` (`id`)
+     ON DELETE NO ACTION
+     ON UPDATE NO ACTION)
+ENGINE = InnoDB;
+
+
+-- +migrate Down
+DROP TABLE `item_image`;
@@ -0,0 +1,25 @@
+-- +migrate Up
+CREATE TABLE `job` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `type` VARCHAR(64) NOT NULL,
+    `payload` TEXT NOT NULL,
+    `status` VARCHAR(16) NOT NULL,
+    `attempts` INT NOT NULL DEFAULT 0,
+    `max_attempts` INT NOT NULL,
+    `run_at` DATETIME NOT NULL,
+    `locked_by` VARCHAR(128) NOT NULL DEFAULT '',
+    `locked_until` DATETIME NULL,
+    `last_error` TEXT NULL,
+    `result` TEXT NULL,
+    `finished_at` DATETIME NULL,
+    `created_at` DATETIME NOT NULL,
+    `updated_at` DATETIME NULL,
+    `deleted_at` DATETIME NULL,
+    PRIMARY KEY (`id`),
+    INDEX `job_status_run_at_idx` (`status` ASC, `run_at` ASC),
+    INDEX `job_status_locked_until_idx` (`status` ASC, `locked_until` ASC))
+ENGINE = InnoDB;
+
+
+-- +migrate Down
+DROP TABLE `job`;
@@ -0,0 +1,10 @@
+-- +migrate Up
+ALTER TABLE `item`
+    ADD COLUMN `code` VARCHAR(64) NULL AFTER `id`,
+    ADD UNIQUE INDEX `code_UNIQUE` (`code` ASC);
+
+
+-- +migrate Down
+ALTER TABLE `item`
+    DROP INDEX `code_UNIQUE`,
+    DROP COLUMN `code`;
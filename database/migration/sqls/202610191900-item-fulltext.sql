@@ -0,0 +1,8 @@
+-- +migrate Up
+ALTER TABLE `item`
+    ADD FULLTEXT INDEX `ft_item_name` (`name`) WITH PARSER ngram;
+
+
+-- +migrate Down
+ALTER TABLE `item`
+    DROP INDEX `ft_item_name`;
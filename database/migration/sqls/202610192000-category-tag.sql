@@ -0,0 +1,67 @@
+-- +migrate Up
+CREATE TABLE `category` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `parent_id` BIGINT UNSIGNED NULL,
+    `name` VARCHAR(255) NOT NULL,
+    `created_at` DATETIME NOT NULL,
+    `updated_at` DATETIME NULL,
+    `deleted_at` DATETIME NULL,
+    PRIMARY KEY (`id`),
+    INDEX `fk_category_category1_idx` (`parent_id` ASC),
+    CONSTRAINT `fk_category_category1`
+    FOREIGN KEY (`parent_id`)
+     REFERENCES `category` (`id`)
+     ON DELETE NO ACTION
+     ON UPDATE NO ACTION)
+ENGINE = InnoDB;
+
+CREATE TABLE `tag` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `name` VARCHAR(64) NOT NULL,
+    `created_at` DATETIME NOT NULL,
+    `updated_at` DATETIME NULL,
+    `deleted_at` DATETIME NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `name_UNIQUE` (`name` ASC))
+ENGINE = InnoDB;
+
+CREATE TABLE `item_category` (
+    `item_id` BIGINT UNSIGNED NOT NULL,
+    `category_id` BIGINT UNSIGNED NOT NULL,
+    PRIMARY KEY (`item_id`, `category_id`),
+    INDEX `fk_item_category_category1_idx` (`category_id` ASC),
+    CONSTRAINT `fk_item_category_item1`
+    FOREIGN KEY (`item_id`)
+     REFERENCES `item` (`id`)
+     ON DELETE CASCADE
+     ON UPDATE NO ACTION,
+    CONSTRAINT `fk_item_category_category1`
+    FOREIGN KEY (`category_id`)
+     REFERENCES `category` (`id`)
+     ON DELETE CASCADE
+     ON UPDATE NO ACTION)
+ENGINE = InnoDB;
+
+CREATE TABLE `item_tag` (
+    `item_id` BIGINT UNSIGNED NOT NULL,
+    `tag_id` BIGINT UNSIGNED NOT NULL,
+    PRIMARY KEY (`item_id`, `tag_id`),
+    INDEX `fk_item_tag_tag1_idx` (`tag_id` ASC),
+    CONSTRAINT `fk_item_tag_item1`
+    FOREIGN KEY (`item_id`)
+     REFERENCES `item` (`id`)
+     ON DELETE CASCADE
+     ON UPDATE NO ACTION,
+    CONSTRAINT `fk_item_tag_tag1`
+    FOREIGN KEY (`tag_id`)
+     REFERENCES `tag` (`id`)
+     ON DELETE CASCADE
+     ON UPDATE NO ACTION)
+ENGINE = InnoDB;
+
+
+-- +migrate Down
+DROP TABLE `item_tag`;
+DROP TABLE `item_category`;
+DROP TABLE `tag`;
+DROP TABLE `category`;
@@ -0,0 +1,24 @@
+-- +migrate Up
+CREATE TABLE `item_variant` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `item_id` BIGINT UNSIGNED NOT NULL,
+    `sku` VARCHAR(64) NOT NULL,
+    `options` JSON NOT NULL,
+    `price` INT NULL,
+    `barcode` VARCHAR(64) NOT NULL DEFAULT '',
+    `created_at` DATETIME NOT NULL,
+    `updated_at` DATETIME NULL,
+    `deleted_at` DATETIME NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `sku_UNIQUE` (`sku` ASC),
+    INDEX `fk_item_variant_item1_idx` (`item_id` ASC),
+    CONSTRAINT `fk_item_variant_item1`
+    FOREIGN KEY (`item_id`)
+     REFERENCES `item` (`id`)
+     ON DELETE CASCADE
+     ON UPDATE NO ACTION)
+ENGINE = InnoDB;
+
+
+-- +migrate Down
+DROP TABLE `item_variant`;
@@ -0,0 +1,57 @@
+-- +migrate Up
+CREATE TABLE `stock_reservation` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `item_id` BIGINT UNSIGNED NOT NULL,
+    `item_variant_id` BIGINT UNSIGNED NULL,
+    `quantity` INT NOT NULL,
+    `status` VARCHAR(16) NOT NULL,
+    `expires_at` DATETIME NOT NULL,
+    `created_at` DATETIME NOT NULL,
+    `updated_at` DATETIME NULL,
+    `deleted_at` DATETIME NULL,
+    PRIMARY KEY (`id`),
+    INDEX `stock_reservation_item_status_idx` (`item_id` ASC, `item_variant_id` ASC, `status` ASC, `expires_at` ASC),
+    CONSTRAINT `fk_stock_reservation_item1`
+    FOREIGN KEY (`item_id`)
+     REFERENCES `item` (`id`)
+     ON DELETE CASCADE
+     ON UPDATE NO ACTION,
+    CONSTRAINT `fk_stock_reservation_item_variant1`
+    FOREIGN KEY (`item_variant_id`)
+     REFERENCES `item_variant` (`id`)
+     ON DELETE CASCADE
+     ON UPDATE NO ACTION)
+ENGINE = InnoDB;
+
+CREATE TABLE `stock_movement` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `item_id` BIGINT UNSIGNED NOT NULL,
+    `item_variant_id` BIGINT UNSIGNED NULL,
+    `type` VARCHAR(16) NOT NULL,
+    `quantity` INT NOT NULL,
+    `stock_reservation_id` BIGINT UNSIGNED NULL,
+    `note` VARCHAR(255) NOT NULL DEFAULT '',
+    `created_at` DATETIME NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `stock_movement_item_idx` (`item_id` ASC, `item_variant_id` ASC),
+    CONSTRAINT `fk_stock_movement_item1`
+    FOREIGN KEY (`item_id`)
+     REFERENCES `item` (`id`)
+     ON DELETE CASCADE
+     ON UPDATE NO ACTION,
+    CONSTRAINT `fk_stock_movement_item_variant1`
+    FOREIGN KEY (`item_variant_id`)
+     REFERENCES `item_variant` (`id`)
+     ON DELETE CASCADE
+     ON UPDATE NO ACTION,
+    CONSTRAINT `fk_stock_movement_stock_reservation1`
+    FOREIGN KEY (`stock_reservation_id`)
+     REFERENCES `stock_reservation` (`id`)
+     ON DELETE NO ACTION
+     ON UPDATE NO ACTION)
+ENGINE = InnoDB;
+
+
+-- +migrate Down
+DROP TABLE `stock_movement`;
+DROP TABLE `stock_reservation`;
@@ -0,0 +1,26 @@
+-- +migrate Up
+CREATE TABLE `item_price` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `item_id` BIGINT UNSIGNED NOT NULL,
+    `price` INT NOT NULL,
+    `effective_from` DATETIME NOT NULL,
+    `effective_to` DATETIME NULL,
+    `created_at` DATETIME NOT NULL,
+    `updated_at` DATETIME NULL,
+    `deleted_at` DATETIME NULL,
+    PRIMARY KEY (`id`),
+    INDEX `item_price_item_effective_idx` (`item_id` ASC, `effective_from` ASC),
+    CONSTRAINT `fk_item_price_item1`
+    FOREIGN KEY (`item_id`)
+     REFERENCES `item` (`id`)
+     ON DELETE CASCADE
+     ON UPDATE NO ACTION)
+ENGINE = InnoDB;
+
+-- the current price of every existing item becomes its first range
+INSERT INTO `item_price` (`item_id`, `price`, `effective_from`, `created_at`)
+SELECT `id`, `price`, `created_at`, NOW() FROM `item` WHERE `deleted_at` IS NULL;
+
+
+-- +migrate Down
+DROP TABLE `item_price`;
@@ -0,0 +1,27 @@
+-- +migrate Up
+CREATE TABLE `item_revision` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `item_id` BIGINT UNSIGNED NOT NULL,
+    `revision` INT NOT NULL,
+    `action` VARCHAR(16) NOT NULL,
+    `code` VARCHAR(64) NULL,
+    `name` VARCHAR(255) NOT NULL,
+    `deleted` TINYINT(1) NOT NULL DEFAULT 0,
+    `created_at` DATETIME NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `item_revision_UNIQUE` (`item_id` ASC, `revision` ASC),
+    INDEX `item_revision_item_created_idx` (`item_id` ASC, `created_at` ASC),
+    CONSTRAINT `fk_item_revision_item1`
+    FOREIGN KEY (`item_id`)
+     REFERENCES `item` (`id`)
+     ON DELETE CASCADE
+     ON UPDATE NO ACTION)
+ENGINE = InnoDB;
+
+-- existing items start with their current state as first revision
+INSERT INTO `item_revision` (`item_id`, `revision`, `action`, `code`, `name`, `deleted`, `created_at`)
+SELECT `id`, 1, 'create', `code`, `name`, `deleted_at` IS NOT NULL, `created_at` FROM `item`;
+
+
+-- +migrate Down
+DROP TABLE `item_revision`;
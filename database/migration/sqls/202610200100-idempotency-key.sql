@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TABLE `idempotency_key` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `principal` VARCHAR(128) NOT NULL,
+    `key` VARCHAR(255) NOT NULL,
+    `fingerprint` CHAR(64) NOT NULL,
+    `status` VARCHAR(16) NOT NULL,
+    `response_status` INT NOT NULL DEFAULT 0,
+    `content_type` VARCHAR(255) NOT NULL DEFAULT '',
+    `response_body` MEDIUMBLOB NULL,
+    `locked_until` DATETIME NOT NULL,
+    `expires_at` DATETIME NOT NULL,
+    `created_at` DATETIME NOT NULL,
+    `updated_at` DATETIME NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `principal_key_UNIQUE` (`principal` ASC, `key` ASC),
+    INDEX `idempotency_key_expires_at_idx` (`expires_at` ASC))
+ENGINE = InnoDB;
+
+
+-- +migrate Down
+DROP TABLE `idempotency_key`;
@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE `rate_limit` (
+    `bucket` VARCHAR(255) NOT NULL,
+    `a` DOUBLE NOT NULL DEFAULT 0,
+    `b` DOUBLE NOT NULL DEFAULT 0,
+    `stamp` DATETIME(6) NOT NULL,
+    `expires_at` DATETIME(6) NOT NULL,
+    PRIMARY KEY (`bucket`),
+    INDEX `rate_limit_expires_at_idx` (`expires_at` ASC))
+ENGINE = InnoDB;
+
+
+-- +migrate Down
+DROP TABLE `rate_limit`;
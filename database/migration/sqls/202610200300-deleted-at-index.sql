@@ -0,0 +1,21 @@
+-- +migrate Up
+-- gorm.Model declares an index on deleted_at
+ALTER TABLE `item` ADD INDEX `item_deleted_at_idx` (`deleted_at` ASC);
+ALTER TABLE `item_image` ADD INDEX `item_image_deleted_at_idx` (`deleted_at` ASC);
+ALTER TABLE `item_price` ADD INDEX `item_price_deleted_at_idx` (`deleted_at` ASC);
+ALTER TABLE `item_variant` ADD INDEX `item_variant_deleted_at_idx` (`deleted_at` ASC);
+ALTER TABLE `category` ADD INDEX `category_deleted_at_idx` (`deleted_at` ASC);
+ALTER TABLE `tag` ADD INDEX `tag_deleted_at_idx` (`deleted_at` ASC);
+ALTER TABLE `job` ADD INDEX `job_deleted_at_idx` (`deleted_at` ASC);
+ALTER TABLE `stock_reservation` ADD INDEX `stock_reservation_deleted_at_idx` (`deleted_at` ASC);
+
+
+-- +migrate Down
+ALTER TABLE `stock_reservation` DROP INDEX `stock_reservation_deleted_at_idx`;
+ALTER TABLE `job` DROP INDEX `job_deleted_at_idx`;
+ALTER TABLE `tag` DROP INDEX `tag_deleted_at_idx`;
+ALTER TABLE `category` DROP INDEX `category_deleted_at_idx`;
+ALTER TABLE `item_variant` DROP INDEX `item_variant_deleted_at_idx`;
+ALTER TABLE `item_price` DROP INDEX `item_price_deleted_at_idx`;
+ALTER TABLE `item_image` DROP INDEX `item_image_deleted_at_idx`;
+ALTER TABLE `item` DROP INDEX `item_deleted_at_idx`;
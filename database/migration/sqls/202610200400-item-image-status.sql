@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE `item_image`
+    ADD COLUMN `status` VARCHAR(16) NOT NULL DEFAULT 'attached' AFTER `size`;
+
+
+-- +migrate Down
+ALTER TABLE `item_image` DROP COLUMN `status`;
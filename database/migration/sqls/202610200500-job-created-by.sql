@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE `job`
+    ADD COLUMN `created_by` VARCHAR(128) NOT NULL DEFAULT '' AFTER `payload`;
+
+
+-- +migrate Down
+ALTER TABLE `job` DROP COLUMN `created_by`;
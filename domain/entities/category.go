@@ -0,0 +1,12 @@
+package gormmodel
+
+import (
+	"gorm.io/gorm"
+)
+
+// Category is a node of the category tree, stored as an adjacency list.
+type Category struct {
+	gorm.Model
+	ParentID *uint
+	Name     string
+}
@@ -0,0 +1,24 @@
+package gormmodel
+
+import (
+	"time"
+
+	"github.com/genpsp/go-app/domain/enum"
+)
+
+// IdempotencyKey remembers the first response to a request sent with an
+// Idempotency-Key header so retries of the same request can be replayed.
+type IdempotencyKey struct {
+	ID             uint   `gorm:"primarykey"`
+	Principal      string `gorm:"uniqueIndex:principal_key_UNIQUE,priority:1"`
+	Key            string `gorm:"uniqueIndex:principal_key_UNIQUE,priority:2"`
+	Fingerprint    string
+	Status         enum.IdempotencyKeyStatus
+	ResponseStatus int
+	ContentType    string
+	ResponseBody   []byte
+	LockedUntil    time.Time
+	ExpiresAt      time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
@@ -6,6 +6,13 @@ import (
 
 type Item struct {
 	gorm.Model
-	Name  string
-	Price int
+	// Code is nil for items created without one, stored as NULL so they
+	// do not collide on code_UNIQUE.
+	Code       *string `gorm:"uniqueIndex:code_UNIQUE"`
+	Name       string
+	Price      int
+	Images     []ItemImage   `gorm:"foreignKey:ItemID"`
+	Categories []Category    `gorm:"many2many:item_category"`
+	Tags       []Tag         `gorm:"many2many:item_tag"`
+	Variants   []ItemVariant `gorm:"foreignKey:ItemID"`
 }
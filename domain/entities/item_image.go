@@ -0,0 +1,16 @@
+package gormmodel
+
+import (
+	"github.com/genpsp/go-app/domain/enum"
+	"gorm.io/gorm"
+)
+
+type ItemImage struct {
+	gorm.Model
+	ItemID      uint
+	ObjectKey   string `gorm:"uniqueIndex:object_key_UNIQUE"`
+	ContentType string
+	Size        int64
+	Status      enum.ItemImageStatus
+	URL         string `gorm:"-"`
+}
@@ -0,0 +1,22 @@
+package gormmodel
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ItemPrice is the price of an item during [EffectiveFrom, EffectiveTo).
+// A nil EffectiveTo leaves the range open ended.
+type ItemPrice struct {
+	gorm.Model
+	ItemID        uint
+	Price         int
+	EffectiveFrom time.Time
+	EffectiveTo   *time.Time
+}
+
+// EffectiveAt reports whether the price applies at t.
+func (p ItemPrice) EffectiveAt(t time.Time) bool {
+	return !t.Before(p.EffectiveFrom) && (p.EffectiveTo == nil || t.Before(*p.EffectiveTo))
+}
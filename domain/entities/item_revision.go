@@ -0,0 +1,20 @@
+package gormmodel
+
+import (
+	"time"
+
+	"github.com/genpsp/go-app/domain/enum"
+)
+
+// ItemRevision is an immutable snapshot of the versioned columns of an item,
+// taken after every change. Prices are versioned separately by ItemPrice.
+type ItemRevision struct {
+	ID        uint `gorm:"primarykey"`
+	ItemID    uint `gorm:"uniqueIndex:item_revision_UNIQUE,priority:1"`
+	Revision  int  `gorm:"uniqueIndex:item_revision_UNIQUE,priority:2"`
+	Action    enum.ItemRevisionAction
+	Code      string
+	Name      string
+	Deleted   bool
+	CreatedAt time.Time
+}
@@ -0,0 +1,49 @@
+package gormmodel
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type ItemVariant struct {
+	gorm.Model
+	ItemID  uint
+	SKU     string `gorm:"column:sku;uniqueIndex:sku_UNIQUE"`
+	Options VariantOptions
+	// Price overrides the price of the item when set.
+	Price   *int
+	Barcode string
+}
+
+// VariantOptions holds the option attributes of a variant, e.g. {"size": "M", "color": "red"}, stored as JSON.
+type VariantOptions map[string]string
+
+func (o VariantOptions) Value() (driver.Value, error) {
+	if o == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (o *VariantOptions) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		*o = VariantOptions{}
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for VariantOptions: %T", value)
+	}
+	return json.Unmarshal(b, o)
+}
@@ -0,0 +1,24 @@
+package gormmodel
+
+import (
+	"time"
+
+	"github.com/genpsp/go-app/domain/enum"
+	"gorm.io/gorm"
+)
+
+type Job struct {
+	gorm.Model
+	Type        string
+	Payload     string
+	CreatedBy   string
+	Status      enum.JobStatus `gorm:"index:job_status_run_at_idx,priority:1"`
+	Attempts    int
+	MaxAttempts int
+	RunAt       time.Time `gorm:"index:job_status_run_at_idx,priority:2"`
+	LockedBy    string
+	LockedUntil *time.Time
+	LastError   string
+	Result      string
+	FinishedAt  *time.Time
+}
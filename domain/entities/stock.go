@@ -0,0 +1,32 @@
+package gormmodel
+
+import (
+	"time"
+
+	"github.com/genpsp/go-app/domain/enum"
+	"gorm.io/gorm"
+)
+
+// StockMovement is an immutable ledger entry. Quantity is signed: receipts
+// add to the on-hand quantity and sales subtract from it.
+type StockMovement struct {
+	ID                 uint `gorm:"primarykey"`
+	ItemID             uint
+	ItemVariantID      *uint
+	Type               enum.StockMovementType
+	Quantity           int
+	StockReservationID *uint
+	Note               string
+	CreatedAt          time.Time
+}
+
+// StockReservation holds quantity aside until it is committed as a sale,
+// released, or ExpiresAt passes.
+type StockReservation struct {
+	gorm.Model
+	ItemID        uint
+	ItemVariantID *uint
+	Quantity      int
+	Status        enum.StockReservationStatus
+	ExpiresAt     time.Time
+}
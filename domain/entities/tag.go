@@ -0,0 +1,10 @@
+package gormmodel
+
+import (
+	"gorm.io/gorm"
+)
+
+type Tag struct {
+	gorm.Model
+	Name string `gorm:"uniqueIndex:name_UNIQUE"`
+}
@@ -0,0 +1,8 @@
+package enum
+
+type IdempotencyKeyStatus string
+
+const (
+	IdempotencyKeyStatusInFlight  IdempotencyKeyStatus = "in_flight"
+	IdempotencyKeyStatusCompleted IdempotencyKeyStatus = "completed"
+)
@@ -0,0 +1,10 @@
+package enum
+
+type ItemImageStatus string
+
+const (
+	// ItemImageStatusPending images have a signed upload URL but the client
+	// has not confirmed the upload yet.
+	ItemImageStatusPending  ItemImageStatus = "pending"
+	ItemImageStatusAttached ItemImageStatus = "attached"
+)
@@ -0,0 +1,11 @@
+package enum
+
+type ItemRevisionAction string
+
+const (
+	ItemRevisionActionCreate ItemRevisionAction = "create"
+	ItemRevisionActionUpdate ItemRevisionAction = "update"
+	ItemRevisionActionDelete ItemRevisionAction = "delete"
+	ItemRevisionActionImport ItemRevisionAction = "import"
+	ItemRevisionActionRevert ItemRevisionAction = "revert"
+)
@@ -0,0 +1,18 @@
+package enum
+
+type StockMovementType string
+
+const (
+	StockMovementTypeReceipt    StockMovementType = "receipt"
+	StockMovementTypeAdjustment StockMovementType = "adjustment"
+	StockMovementTypeSale       StockMovementType = "sale"
+)
+
+type StockReservationStatus string
+
+const (
+	StockReservationStatusActive    StockReservationStatus = "active"
+	StockReservationStatusCommitted StockReservationStatus = "committed"
+	StockReservationStatusReleased  StockReservationStatus = "released"
+	StockReservationStatusExpired   StockReservationStatus = "expired"
+)
@@ -0,0 +1,169 @@
+package repositories
+
+import (
+	"errors"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// categorySubtreeSQL selects the id of a category and of all its descendants.
+const categorySubtreeSQL = `WITH RECURSIVE subtree (id) AS (
+	SELECT id FROM category WHERE id = ? AND deleted_at IS NULL
+	UNION ALL
+	SELECT c.id FROM category c JOIN subtree s ON c.parent_id = s.id WHERE c.deleted_at IS NULL
+) SELECT id FROM subtree`
+
+type (
+	CategoryRepository interface {
+		FindAll(db *gorm.DB) (categories *[]entities.Category, err error)
+		FindByID(db *gorm.DB, categoryID int) (categoryEntity *entities.Category, err error)
+		FindByIDs(db *gorm.DB, categoryIDs []uint) (categories *[]entities.Category, err error)
+		FindSubtreeIDs(db *gorm.DB, categoryID int) (categoryIDs []uint, err error)
+		CountItems(db *gorm.DB) (counts *[]CategoryItemCount, err error)
+		Create(db *gorm.DB, categoryEntity *entities.Category) (err error)
+		Update(db *gorm.DB, categoryID int, categoryEntity *entities.Category) (err error)
+		Delete(db *gorm.DB, categoryID int) (err error)
+	}
+	CategoryRepositoryImpl struct{}
+
+	// CategoryItemCount counts the items linked to a category directly
+	// and to the category or any of its descendants.
+	CategoryItemCount struct {
+		CategoryID uint
+		ItemCount  int64
+		TotalCount int64
+	}
+)
+
+func NewCategoryRepository() CategoryRepository {
+	return &CategoryRepositoryImpl{}
+}
+
+func (r *CategoryRepositoryImpl) FindAll(db *gorm.DB) (categories *[]entities.Category, err error) {
+	err = db.Model(&entities.Category{}).
+		Order("id").
+		Find(&categories).Error
+
+	if err != nil {
+		log.Error("Category FindAll error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *CategoryRepositoryImpl) FindByID(db *gorm.DB, categoryID int) (categoryEntity *entities.Category, err error) {
+	err = db.Model(&entities.Category{}).
+		Where("id = ?", categoryID).
+		First(&categoryEntity).
+		Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Info("Category. record not found.")
+		return nil, nil
+	}
+
+	if err != nil {
+		log.Error("Category FindByID error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *CategoryRepositoryImpl) FindByIDs(db *gorm.DB, categoryIDs []uint) (categories *[]entities.Category, err error) {
+	err = db.Model(&entities.Category{}).
+		Where("id IN ?", categoryIDs).
+		Find(&categories).Error
+
+	if err != nil {
+		log.Error("Category FindByIDs error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *CategoryRepositoryImpl) FindSubtreeIDs(db *gorm.DB, categoryID int) (categoryIDs []uint, err error) {
+	err = db.Raw(categorySubtreeSQL, categoryID).
+		Scan(&categoryIDs).Error
+
+	if err != nil {
+		log.Error("Category FindSubtreeIDs error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *CategoryRepositoryImpl) CountItems(db *gorm.DB) (counts *[]CategoryItemCount, err error) {
+	err = db.Raw(`WITH RECURSIVE closure (ancestor_id, id) AS (
+	SELECT id, id FROM category WHERE deleted_at IS NULL
+	UNION ALL
+	SELECT cl.ancestor_id, c.id FROM category c JOIN closure cl ON c.parent_id = cl.id WHERE c.deleted_at IS NULL
+)
+SELECT cl.ancestor_id AS category_id,
+	COUNT(DISTINCT CASE WHEN cl.id = cl.ancestor_id THEN ic.item_id END) AS item_count,
+	COUNT(DISTINCT ic.item_id) AS total_count
+FROM closure cl
+JOIN item_category ic ON ic.category_id = cl.id
+JOIN item i ON i.id = ic.item_id AND i.deleted_at IS NULL
+GROUP BY cl.ancestor_id`).
+		Scan(&counts).Error
+
+	if err != nil {
+		log.Error("Category CountItems error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *CategoryRepositoryImpl) Create(db *gorm.DB, categoryEntity *entities.Category) (err error) {
+	err = db.Create(&categoryEntity).Error
+
+	if err != nil {
+		log.Error("Category Create error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *CategoryRepositoryImpl) Update(db *gorm.DB, categoryID int, categoryEntity *entities.Category) (err error) {
+	err = db.Model(&entities.Category{}).
+		Where("id = ?", categoryID).
+		Updates(map[string]interface{}{
+			"parent_id": categoryEntity.ParentID,
+			"name":      categoryEntity.Name,
+		}).
+		Error
+
+	if err != nil {
+		log.Error("Category Update error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *CategoryRepositoryImpl) Delete(db *gorm.DB, categoryID int) (err error) {
+	categoryEntity := entities.Category{}
+	err = db.Model(&categoryEntity).Where("id = ?", categoryID).Delete(&categoryEntity).Error
+	if err != nil {
+		log.Error("Category Delete error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+	return
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// mysqlDuplicateEntry is the MySQL error number of a unique index violation.
+const mysqlDuplicateEntry = 1062
+
+// ErrDuplicateKey is returned instead of DBClientError when a write violates
+// a unique index, e.g. when a concurrent request took the same SKU.
+var ErrDuplicateKey = errors.New("duplicate key")
+
+func isDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry
+}
@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/domain/enum"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	IdempotencyKeyRepository interface {
+		FindForUpdate(db *gorm.DB, principal string, key string) (keyEntity *entities.IdempotencyKey, err error)
+		Create(db *gorm.DB, keyEntity *entities.IdempotencyKey) (created bool, err error)
+		Restart(db *gorm.DB, keyEntity *entities.IdempotencyKey) (err error)
+		Complete(db *gorm.DB, id uint, status int, contentType string, body []byte) (err error)
+		Delete(db *gorm.DB, id uint) (err error)
+		DeleteExpired(db *gorm.DB, now time.Time) (err error)
+	}
+	IdempotencyKeyRepositoryImpl struct{}
+)
+
+func NewIdempotencyKeyRepository() IdempotencyKeyRepository {
+	return &IdempotencyKeyRepositoryImpl{}
+}
+
+func (r *IdempotencyKeyRepositoryImpl) FindForUpdate(db *gorm.DB, principal string, key string) (keyEntity *entities.IdempotencyKey, err error) {
+	err = db.Model(&entities.IdempotencyKey{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("principal = ? AND `key` = ?", principal, key).
+		First(&keyEntity).
+		Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		log.Error("IdempotencyKey FindForUpdate error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+// Create inserts the key unless another request inserted the same one first,
+// in which case created is false.
+func (r *IdempotencyKeyRepositoryImpl) Create(db *gorm.DB, keyEntity *entities.IdempotencyKey) (created bool, err error) {
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&keyEntity)
+
+	if result.Error != nil {
+		log.Error("IdempotencyKey Create error", zap.Error(result.Error))
+		err = appErr.DBClientError
+		return
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// Restart hands an expired or abandoned key over to a new request.
+func (r *IdempotencyKeyRepositoryImpl) Restart(db *gorm.DB, keyEntity *entities.IdempotencyKey) (err error) {
+	err = db.Model(&entities.IdempotencyKey{}).
+		Where("id = ?", keyEntity.ID).
+		Updates(map[string]interface{}{
+			"fingerprint":     keyEntity.Fingerprint,
+			"status":          enum.IdempotencyKeyStatusInFlight,
+			"response_status": 0,
+			"content_type":    "",
+			"response_body":   nil,
+			"locked_until":    keyEntity.LockedUntil,
+			"expires_at":      keyEntity.ExpiresAt,
+		}).
+		Error
+
+	if err != nil {
+		log.Error("IdempotencyKey Restart error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *IdempotencyKeyRepositoryImpl) Complete(db *gorm.DB, id uint, status int, contentType string, body []byte) (err error) {
+	err = db.Model(&entities.IdempotencyKey{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":          enum.IdempotencyKeyStatusCompleted,
+			"response_status": status,
+			"content_type":    contentType,
+			"response_body":   body,
+		}).
+		Error
+
+	if err != nil {
+		log.Error("IdempotencyKey Complete error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *IdempotencyKeyRepositoryImpl) Delete(db *gorm.DB, id uint) (err error) {
+	err = db.Where("id = ?", id).Delete(&entities.IdempotencyKey{}).Error
+	if err != nil {
+		log.Error("IdempotencyKey Delete error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+	return
+}
+
+// DeleteExpired removes the keys that can no longer be replayed.
+func (r *IdempotencyKeyRepositoryImpl) DeleteExpired(db *gorm.DB, now time.Time) (err error) {
+	err = db.Where("expires_at <= ?", now).Delete(&entities.IdempotencyKey{}).Error
+	if err != nil {
+		log.Error("IdempotencyKey DeleteExpired error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+	return
+}
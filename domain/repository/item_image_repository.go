@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"errors"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/domain/enum"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type (
+	ItemImageRepository interface {
+		FindByID(db *gorm.DB, itemID int, imageID int) (imageEntity *entities.ItemImage, err error)
+		Create(db *gorm.DB, imageEntity *entities.ItemImage) (err error)
+		Attach(db *gorm.DB, imageID int, size int64) (err error)
+	}
+	ItemImageRepositoryImpl struct{}
+)
+
+func NewItemImageRepository() ItemImageRepository {
+	return &ItemImageRepositoryImpl{}
+}
+
+func (r *ItemImageRepositoryImpl) FindByID(db *gorm.DB, itemID int, imageID int) (imageEntity *entities.ItemImage, err error) {
+	err = db.Model(&entities.ItemImage{}).
+		Where("id = ? AND item_id = ?", imageID, itemID).
+		First(&imageEntity).
+		Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Info("ItemImage. record not found.")
+		return nil, nil
+	}
+
+	if err != nil {
+		log.Error("ItemImage FindByID error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *ItemImageRepositoryImpl) Create(db *gorm.DB, imageEntity *entities.ItemImage) (err error) {
+	err = db.Create(&imageEntity).Error
+
+	if err != nil {
+		log.Error("ItemImage Create error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+// Attach marks a pending image attached with the size of the object actually stored.
+func (r *ItemImageRepositoryImpl) Attach(db *gorm.DB, imageID int, size int64) (err error) {
+	err = db.Model(&entities.ItemImage{}).
+		Where("id = ?", imageID).
+		Updates(map[string]interface{}{
+			"status": enum.ItemImageStatusAttached,
+			"size":   size,
+		}).
+		Error
+
+	if err != nil {
+		log.Error("ItemImage Attach error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// itemEffectivePriceSQL selects the price of the item table row effective at
+// the given time: the one of its item_price range, else its own price.
+const itemEffectivePriceSQL = `COALESCE((SELECT ip.price FROM item_price ip
+WHERE ip.item_id = item.id AND ip.deleted_at IS NULL AND ip.effective_from <= ? AND (ip.effective_to IS NULL OR ip.effective_to > ?)
+LIMIT 1), item.price)`
+
+type (
+	ItemPriceRepository interface {
+		FindByItemID(db *gorm.DB, itemID int) (prices *[]entities.ItemPrice, err error)
+		FindByItemIDsForUpdate(db *gorm.DB, itemIDs []uint) (prices *[]entities.ItemPrice, err error)
+		FindEffective(db *gorm.DB, itemIDs []uint, at time.Time) (prices *[]entities.ItemPrice, err error)
+		Create(db *gorm.DB, priceEntity *entities.ItemPrice) (err error)
+		UpdateRange(db *gorm.DB, priceID uint, effectiveFrom time.Time, effectiveTo *time.Time) (err error)
+		Delete(db *gorm.DB, priceID uint) (err error)
+	}
+	ItemPriceRepositoryImpl struct{}
+)
+
+func NewItemPriceRepository() ItemPriceRepository {
+	return &ItemPriceRepositoryImpl{}
+}
+
+func (r *ItemPriceRepositoryImpl) FindByItemID(db *gorm.DB, itemID int) (prices *[]entities.ItemPrice, err error) {
+	err = db.Model(&entities.ItemPrice{}).
+		Where("item_id = ?", itemID).
+		Order("effective_from").
+		Find(&prices).Error
+
+	if err != nil {
+		log.Error("ItemPrice FindByItemID error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+// FindByItemIDsForUpdate locks the price rows of the items, and the index gap
+// after them, so concurrent price writes of the same item run one at a time.
+func (r *ItemPriceRepositoryImpl) FindByItemIDsForUpdate(db *gorm.DB, itemIDs []uint) (prices *[]entities.ItemPrice, err error) {
+	err = db.Model(&entities.ItemPrice{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("item_id IN ?", itemIDs).
+		Order("item_id, effective_from").
+		Find(&prices).Error
+
+	if err != nil {
+		log.Error("ItemPrice FindByItemIDsForUpdate error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+// FindEffective returns the price effective at t of each item that has one.
+func (r *ItemPriceRepositoryImpl) FindEffective(db *gorm.DB, itemIDs []uint, at time.Time) (prices *[]entities.ItemPrice, err error) {
+	err = db.Model(&entities.ItemPrice{}).
+		Where("item_id IN ?", itemIDs).
+		Where("effective_from <= ? AND (effective_to IS NULL OR effective_to > ?)", at, at).
+		Find(&prices).Error
+
+	if err != nil {
+		log.Error("ItemPrice FindEffective error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *ItemPriceRepositoryImpl) Create(db *gorm.DB, priceEntity *entities.ItemPrice) (err error) {
+	err = db.Create(&priceEntity).Error
+
+	if err != nil {
+		log.Error("ItemPrice Create error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *ItemPriceRepositoryImpl) UpdateRange(db *gorm.DB, priceID uint, effectiveFrom time.Time, effectiveTo *time.Time) (err error) {
+	err = db.Model(&entities.ItemPrice{}).
+		Where("id = ?", priceID).
+		Updates(map[string]interface{}{
+			"effective_from": effectiveFrom,
+			"effective_to":   effectiveTo,
+		}).
+		Error
+
+	if err != nil {
+		log.Error("ItemPrice UpdateRange error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *ItemPriceRepositoryImpl) Delete(db *gorm.DB, priceID uint) (err error) {
+	priceEntity := entities.ItemPrice{}
+	err = db.Model(&priceEntity).Where("id = ?", priceID).Delete(&priceEntity).Error
+	if err != nil {
+		log.Error("ItemPrice Delete error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+	return
+}
@@ -2,14 +2,19 @@ package repositories
 
 import (
 	"errors"
-	"fmt"
+	"time"
 
 	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/domain/enum"
 	"github.com/genpsp/go-app/pkg/logger"
 	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+var log = logger.New("repositories")
+
 type (
 	ItemRepository interface {
 		FindAll(db *gorm.DB) (items *[]entities.Item, err error)
@@ -17,8 +22,25 @@ type (
 		Create(db *gorm.DB, itemEntity *entities.Item) (err error)
 		Update(db *gorm.DB, itemID int, itemEntity *entities.Item) (err error)
 		Delete(db *gorm.DB, itemID int) (err error)
+		FindInBatches(db *gorm.DB, filter ItemFilter, batchSize int, fn func(items []entities.Item) error) (err error)
+		FindByCodes(db *gorm.DB, codes []string) (items *[]entities.Item, err error)
+		UpsertByCode(db *gorm.DB, items *[]entities.Item) (err error)
+		FindByFilter(db *gorm.DB, filter ItemFilter) (items *[]entities.Item, err error)
+		ReplaceCategories(db *gorm.DB, itemEntity *entities.Item, categories []entities.Category) (err error)
+		ReplaceTags(db *gorm.DB, itemEntity *entities.Item, tags []entities.Tag) (err error)
+		Restore(db *gorm.DB, itemID int, revisionEntity *entities.ItemRevision) (err error)
 	}
 	ItemRepositoryImpl struct{}
+
+	ItemFilter struct {
+		Name string
+		// Price matches the price effective now, as ItemPriceService.ApplyEffective sets it.
+		Price int
+		// CategoryID matches items in the category or any of its descendants.
+		CategoryID int
+		// Tags matches items carrying every one of the tags.
+		Tags []string
+	}
 )
 
 func NewItemRepository() ItemRepository {
@@ -27,15 +49,18 @@ func NewItemRepository() ItemRepository {
 
 func (r *ItemRepositoryImpl) FindAll(db *gorm.DB) (items *[]entities.Item, err error) {
 	err = db.Model(&entities.Item{}).
+		Preload("Images", "status = ?", enum.ItemImageStatusAttached).
+		Preload("Categories").
+		Preload("Tags").
 		Find(&items).Error
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
-		logger.Logging.Info(fmt.Sprintf("Item. record not found."))
+		log.Info("Item. record not found.")
 		return nil, nil
 	}
 
 	if err != nil {
-		logger.Logging.Error(fmt.Sprintf("Item FindAll error: %s", err.Error()))
+		log.Error("Item FindAll error", zap.Error(err))
 		err = appErr.DBClientError
 		return
 	}
@@ -45,17 +70,20 @@ func (r *ItemRepositoryImpl) FindAll(db *gorm.DB) (items *[]entities.Item, err e
 
 func (r *ItemRepositoryImpl) FindByID(db *gorm.DB, itemID int) (itemEntity *entities.Item, err error) {
 	err = db.Model(&entities.Item{}).
+		Preload("Images", "status = ?", enum.ItemImageStatusAttached).
+		Preload("Categories").
+		Preload("Tags").
 		Where("id = ?", itemID).
 		First(&itemEntity).
 		Error
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
-		logger.Logging.Info(fmt.Sprintf("Item. record not found."))
+		log.Info("Item. record not found.")
 		return nil, nil
 	}
 
 	if err != nil {
-		logger.Logging.Error(fmt.Sprintf("Item FindByID error: %s", err.Error()))
+		log.Error("Item FindByID error", zap.Error(err))
 		err = appErr.DBClientError
 		return
 	}
@@ -67,7 +95,7 @@ func (r *ItemRepositoryImpl) Create(db *gorm.DB, itemEntity *entities.Item) (err
 	err = db.Create(&itemEntity).Error
 
 	if err != nil {
-		logger.Logging.Error(fmt.Sprintf("Item Create error: %s", err.Error()))
+		log.Error("Item Create error", zap.Error(err))
 		err = appErr.DBClientError
 		return
 	}
@@ -84,7 +112,7 @@ func (r *ItemRepositoryImpl) Update(db *gorm.DB, itemID int, itemEntity *entitie
 		Error
 
 	if err != nil {
-		logger.Logging.Error(fmt.Sprintf("Item Update error: %s", err.Error()))
+		log.Error("Item Update error", zap.Error(err))
 		err = appErr.DBClientError
 		return
 	}
@@ -96,9 +124,160 @@ func (r *ItemRepositoryImpl) Delete(db *gorm.DB, itemID int) (err error) {
 	itemEntity := entities.Item{}
 	err = db.Model(&itemEntity).Where("id = ?", itemID).Delete(&itemEntity).Error
 	if err != nil {
-		logger.Logging.Error(fmt.Sprintf("Item Update error: %s", err.Error()))
+		log.Error("Item Update error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+	return
+}
+
+// IsZero reports whether f matches every item.
+func (f ItemFilter) IsZero() bool {
+	return f.Name == "" && f.Price == 0 && f.CategoryID == 0 && len(f.Tags) == 0
+}
+
+func (f ItemFilter) apply(db *gorm.DB) *gorm.DB {
+	if f.Name != "" {
+		db = db.Where("name LIKE ?", "%"+f.Name+"%")
+	}
+	if f.Price > 0 {
+		now := time.Now()
+		db = db.Where(itemEffectivePriceSQL+" = ?", now, now, f.Price)
+	}
+	if f.CategoryID > 0 {
+		db = db.Where("id IN (SELECT ic.item_id FROM item_category ic WHERE ic.category_id IN ("+categorySubtreeSQL+"))", f.CategoryID)
+	}
+	if len(f.Tags) > 0 {
+		db = db.Where(`id IN (SELECT it.item_id FROM item_tag it JOIN tag t ON t.id = it.tag_id
+WHERE t.name IN ? GROUP BY it.item_id HAVING COUNT(DISTINCT t.id) = ?)`, f.Tags, len(f.Tags))
+	}
+	return db
+}
+
+func (r *ItemRepositoryImpl) FindByFilter(db *gorm.DB, filter ItemFilter) (items *[]entities.Item, err error) {
+	err = filter.apply(db.Model(&entities.Item{})).
+		Preload("Images", "status = ?", enum.ItemImageStatusAttached).
+		Preload("Categories").
+		Preload("Tags").
+		Order("id").
+		Find(&items).Error
+
+	if err != nil {
+		log.Error("Item FindByFilter error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *ItemRepositoryImpl) ReplaceCategories(db *gorm.DB, itemEntity *entities.Item, categories []entities.Category) (err error) {
+	err = db.Model(itemEntity).Association("Categories").Replace(categories)
+
+	if err != nil {
+		log.Error("Item ReplaceCategories error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *ItemRepositoryImpl) ReplaceTags(db *gorm.DB, itemEntity *entities.Item, tags []entities.Tag) (err error) {
+	err = db.Model(itemEntity).Association("Tags").Replace(tags)
+
+	if err != nil {
+		log.Error("Item ReplaceTags error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+// FindInBatches walks the items matching filter in id order, batchSize rows at a time,
+// using the last seen id as cursor so memory stays flat regardless of the table size.
+func (r *ItemRepositoryImpl) FindInBatches(db *gorm.DB, filter ItemFilter, batchSize int, fn func(items []entities.Item) error) (err error) {
+	var lastID uint
+	for {
+		var items []entities.Item
+		err = filter.apply(db.Model(&entities.Item{})).
+			Where("id > ?", lastID).
+			Order("id").
+			Limit(batchSize).
+			Find(&items).Error
+
+		if err != nil {
+			log.Error("Item FindInBatches error", zap.Error(err))
+			err = appErr.DBClientError
+			return
+		}
+		if len(items) == 0 {
+			return
+		}
+		if err = fn(items); err != nil {
+			return
+		}
+		if len(items) < batchSize {
+			return
+		}
+		lastID = items[len(items)-1].ID
+	}
+}
+
+func (r *ItemRepositoryImpl) FindByCodes(db *gorm.DB, codes []string) (items *[]entities.Item, err error) {
+	err = db.Model(&entities.Item{}).
+		Where("code IN ?", codes).
+		Find(&items).Error
+
+	if err != nil {
+		log.Error("Item FindByCodes error", zap.Error(err))
 		err = appErr.DBClientError
 		return
 	}
+
+	return
+}
+
+// UpsertByCode inserts the items, updating name and price of the ones whose code
+// already exists. A deleted item with the code is restored.
+func (r *ItemRepositoryImpl) UpsertByCode(db *gorm.DB, items *[]entities.Item) (err error) {
+	err = db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "code"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "price", "updated_at", "deleted_at"}),
+	}).
+		Omit("Images", "Categories", "Tags", "Variants").
+		CreateInBatches(items, 500).
+		Error
+
+	if err != nil {
+		log.Error("Item UpsertByCode error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+// Restore writes the versioned columns of revisionEntity back to the item.
+func (r *ItemRepositoryImpl) Restore(db *gorm.DB, itemID int, revisionEntity *entities.ItemRevision) (err error) {
+	var code interface{}
+	if revisionEntity.Code != "" {
+		code = revisionEntity.Code
+	}
+	err = db.Model(&entities.Item{}).
+		Where("id = ?", itemID).
+		Updates(map[string]interface{}{
+			"code": code,
+			"name": revisionEntity.Name,
+		}).
+		Error
+
+	if err != nil {
+		log.Error("Item Restore error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
 	return
 }
@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/domain/enum"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// itemRevisionSnapshotSQL copies the current row of the selected items, deleted
+// or not, into item_revision with the next revision number of each item.
+const itemRevisionSnapshotSQL = `INSERT INTO item_revision (item_id, revision, action, code, name, deleted, created_at)
+SELECT i.id,
+	COALESCE((SELECT MAX(r.revision) FROM item_revision r WHERE r.item_id = i.id), 0) + 1,
+	?, i.code, i.name, i.deleted_at IS NOT NULL, ?
+FROM item i WHERE `
+
+type (
+	ItemRevisionRepository interface {
+		FindByItemID(db *gorm.DB, itemID int) (revisions *[]entities.ItemRevision, err error)
+		FindByRevision(db *gorm.DB, itemID int, revision int) (revisionEntity *entities.ItemRevision, err error)
+		FindAsOf(db *gorm.DB, itemID int, at time.Time) (revisionEntity *entities.ItemRevision, err error)
+		Record(db *gorm.DB, itemIDs []uint, action enum.ItemRevisionAction, at time.Time) (err error)
+		RecordByCodes(db *gorm.DB, codes []string, action enum.ItemRevisionAction, at time.Time) (err error)
+	}
+	ItemRevisionRepositoryImpl struct{}
+)
+
+func NewItemRevisionRepository() ItemRevisionRepository {
+	return &ItemRevisionRepositoryImpl{}
+}
+
+func (r *ItemRevisionRepositoryImpl) FindByItemID(db *gorm.DB, itemID int) (revisions *[]entities.ItemRevision, err error) {
+	err = db.Model(&entities.ItemRevision{}).
+		Where("item_id = ?", itemID).
+		Order("revision DESC").
+		Find(&revisions).Error
+
+	if err != nil {
+		log.Error("ItemRevision FindByItemID error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *ItemRevisionRepositoryImpl) FindByRevision(db *gorm.DB, itemID int, revision int) (revisionEntity *entities.ItemRevision, err error) {
+	err = db.Model(&entities.ItemRevision{}).
+		Where("item_id = ? AND revision = ?", itemID, revision).
+		First(&revisionEntity).
+		Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Info("ItemRevision. record not found.")
+		return nil, nil
+	}
+
+	if err != nil {
+		log.Error("ItemRevision FindByRevision error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+// FindAsOf returns the revision of the item that was current at the given time.
+func (r *ItemRevisionRepositoryImpl) FindAsOf(db *gorm.DB, itemID int, at time.Time) (revisionEntity *entities.ItemRevision, err error) {
+	err = db.Model(&entities.ItemRevision{}).
+		Where("item_id = ? AND created_at <= ?", itemID, at).
+		Order("revision DESC").
+		First(&revisionEntity).
+		Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Info("ItemRevision. record not found.")
+		return nil, nil
+	}
+
+	if err != nil {
+		log.Error("ItemRevision FindAsOf error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+// Record snapshots the items after a change. It has to run in the transaction
+// of the change, which holds the row locks that keep revision numbers unique.
+func (r *ItemRevisionRepositoryImpl) Record(db *gorm.DB, itemIDs []uint, action enum.ItemRevisionAction, at time.Time) (err error) {
+	if len(itemIDs) == 0 {
+		return nil
+	}
+	err = db.Exec(itemRevisionSnapshotSQL+"i.id IN ?", action, at, itemIDs).Error
+
+	if err != nil {
+		log.Error("ItemRevision Record error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *ItemRevisionRepositoryImpl) RecordByCodes(db *gorm.DB, codes []string, action enum.ItemRevisionAction, at time.Time) (err error) {
+	if len(codes) == 0 {
+		return nil
+	}
+	err = db.Exec(itemRevisionSnapshotSQL+"i.code IN ?", action, at, codes).Error
+
+	if err != nil {
+		log.Error("ItemRevision RecordByCodes error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/domain/enum"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type (
+	// ItemSearchRepository finds items by keywords, most relevant first.
+	ItemSearchRepository interface {
+		Search(db *gorm.DB, query string, limit int, offset int) (hits *[]ItemSearchHit, total int64, err error)
+	}
+	ItemSearchRepositoryImpl struct{}
+
+	ItemSearchHit struct {
+		Item  entities.Item
+		Score float64
+	}
+)
+
+// NewItemSearchRepository searches with the ngram FULLTEXT index on item.name,
+// so names written in Japanese without spaces are matched as well.
+func NewItemSearchRepository() ItemSearchRepository {
+	return &ItemSearchRepositoryImpl{}
+}
+
+func (r *ItemSearchRepositoryImpl) Search(db *gorm.DB, query string, limit int, offset int) (hits *[]ItemSearchHit, total int64, err error) {
+	const match = "MATCH(name) AGAINST(? IN NATURAL LANGUAGE MODE)"
+
+	err = db.Model(&entities.Item{}).
+		Where(match, query).
+		Count(&total).Error
+
+	if err != nil {
+		log.Error("Item Search count error", zap.Error(err))
+		return nil, 0, appErr.DBClientError
+	}
+
+	var rows []struct {
+		ID    uint
+		Score float64
+	}
+	err = db.Model(&entities.Item{}).
+		Select("id, "+match+" AS score", query).
+		Where(match, query).
+		Order("score DESC, id").
+		Limit(limit).
+		Offset(offset).
+		Find(&rows).Error
+
+	if err != nil {
+		log.Error("Item Search error", zap.Error(err))
+		return nil, 0, appErr.DBClientError
+	}
+
+	// the associations are loaded as FindAll does, then put in score order
+	ids := make([]uint, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	var items []entities.Item
+	if len(ids) > 0 {
+		err = db.Model(&entities.Item{}).
+			Preload("Images", "status = ?", enum.ItemImageStatusAttached).
+			Preload("Categories").
+			Preload("Tags").
+			Where("id IN ?", ids).
+			Find(&items).Error
+
+		if err != nil {
+			log.Error("Item Search error", zap.Error(err))
+			return nil, 0, appErr.DBClientError
+		}
+	}
+	byID := make(map[uint]entities.Item, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+
+	result := make([]ItemSearchHit, 0, len(rows))
+	for _, row := range rows {
+		if item, ok := byID[row.ID]; ok {
+			result = append(result, ItemSearchHit{Item: item, Score: row.Score})
+		}
+	}
+	return &result, total, nil
+}
@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"errors"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type (
+	ItemVariantRepository interface {
+		FindByItemIDs(db *gorm.DB, itemIDs []uint) (variants *[]entities.ItemVariant, err error)
+		FindByID(db *gorm.DB, itemID int, variantID int) (variantEntity *entities.ItemVariant, err error)
+		FindBySKU(db *gorm.DB, sku string) (variantEntity *entities.ItemVariant, err error)
+		Create(db *gorm.DB, variantEntity *entities.ItemVariant) (err error)
+		Update(db *gorm.DB, variantID int, variantEntity *entities.ItemVariant) (err error)
+		Delete(db *gorm.DB, variantID int) (err error)
+	}
+	ItemVariantRepositoryImpl struct{}
+)
+
+func NewItemVariantRepository() ItemVariantRepository {
+	return &ItemVariantRepositoryImpl{}
+}
+
+func (r *ItemVariantRepositoryImpl) FindByItemIDs(db *gorm.DB, itemIDs []uint) (variants *[]entities.ItemVariant, err error) {
+	err = db.Model(&entities.ItemVariant{}).
+		Where("item_id IN ?", itemIDs).
+		Order("id").
+		Find(&variants).Error
+
+	if err != nil {
+		log.Error("ItemVariant FindByItemIDs error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *ItemVariantRepositoryImpl) FindByID(db *gorm.DB, itemID int, variantID int) (variantEntity *entities.ItemVariant, err error) {
+	err = db.Model(&entities.ItemVariant{}).
+		Where("id = ? AND item_id = ?", variantID, itemID).
+		First(&variantEntity).
+		Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Info("ItemVariant. record not found.")
+		return nil, nil
+	}
+
+	if err != nil {
+		log.Error("ItemVariant FindByID error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+// FindBySKU also finds soft deleted variants, which still hold their SKU in sku_UNIQUE.
+func (r *ItemVariantRepositoryImpl) FindBySKU(db *gorm.DB, sku string) (variantEntity *entities.ItemVariant, err error) {
+	err = db.Unscoped().Model(&entities.ItemVariant{}).
+		Where("sku = ?", sku).
+		First(&variantEntity).
+		Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Info("ItemVariant. record not found.")
+		return nil, nil
+	}
+
+	if err != nil {
+		log.Error("ItemVariant FindBySKU error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *ItemVariantRepositoryImpl) Create(db *gorm.DB, variantEntity *entities.ItemVariant) (err error) {
+	err = db.Create(&variantEntity).Error
+
+	if isDuplicateKey(err) {
+		log.Info("ItemVariant Create duplicate sku", zap.String("sku", variantEntity.SKU))
+		return ErrDuplicateKey
+	}
+
+	if err != nil {
+		log.Error("ItemVariant Create error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *ItemVariantRepositoryImpl) Update(db *gorm.DB, variantID int, variantEntity *entities.ItemVariant) (err error) {
+	err = db.Model(&entities.ItemVariant{}).
+		Where("id = ?", variantID).
+		Updates(map[string]interface{}{
+			"sku":     variantEntity.SKU,
+			"options": variantEntity.Options,
+			"price":   variantEntity.Price,
+			"barcode": variantEntity.Barcode,
+		}).
+		Error
+
+	if isDuplicateKey(err) {
+		log.Info("ItemVariant Update duplicate sku", zap.String("sku", variantEntity.SKU))
+		return ErrDuplicateKey
+	}
+
+	if err != nil {
+		log.Error("ItemVariant Update error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+// Delete removes the variant physically so its SKU can be used again.
+func (r *ItemVariantRepositoryImpl) Delete(db *gorm.DB, variantID int) (err error) {
+	variantEntity := entities.ItemVariant{}
+	err = db.Unscoped().Model(&variantEntity).Where("id = ?", variantID).Delete(&variantEntity).Error
+	if err != nil {
+		log.Error("ItemVariant Delete error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+	return
+}
@@ -0,0 +1,192 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/domain/enum"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	JobRepository interface {
+		FindByID(db *gorm.DB, createdBy string, jobID int) (jobEntity *entities.Job, err error)
+		Create(db *gorm.DB, jobEntity *entities.Job) (err error)
+		Lease(db *gorm.DB, workerID string, now time.Time, leaseUntil time.Time) (jobEntity *entities.Job, err error)
+		FailExhausted(db *gorm.DB, now time.Time) (n int64, err error)
+		Heartbeat(db *gorm.DB, jobID uint, workerID string, leaseUntil time.Time) (ok bool, err error)
+		Complete(db *gorm.DB, jobID uint, workerID string, result string, finishedAt time.Time) (err error)
+		Fail(db *gorm.DB, jobID uint, workerID string, lastError string, retryAt *time.Time, finishedAt time.Time) (err error)
+	}
+	JobRepositoryImpl struct{}
+)
+
+func NewJobRepository() JobRepository {
+	return &JobRepositoryImpl{}
+}
+
+// FindByID only finds the jobs createdBy enqueued.
+func (r *JobRepositoryImpl) FindByID(db *gorm.DB, createdBy string, jobID int) (jobEntity *entities.Job, err error) {
+	err = db.Model(&entities.Job{}).
+		Where("id = ? AND created_by = ?", jobID, createdBy).
+		First(&jobEntity).
+		Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Info("Job. record not found.")
+		return nil, nil
+	}
+
+	if err != nil {
+		log.Error("Job FindByID error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *JobRepositoryImpl) Create(db *gorm.DB, jobEntity *entities.Job) (err error) {
+	err = db.Create(&jobEntity).Error
+
+	if err != nil {
+		log.Error("Job Create error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+// Lease picks the next runnable job, either queued and due or running with an
+// expired lease and attempts left, and assigns it to workerID until leaseUntil.
+// It must be called inside a transaction so the row lock is held until commit.
+func (r *JobRepositoryImpl) Lease(db *gorm.DB, workerID string, now time.Time, leaseUntil time.Time) (jobEntity *entities.Job, err error) {
+	err = db.Model(&entities.Job{}).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("(status = ? AND run_at <= ?) OR (status = ? AND locked_until < ? AND attempts < max_attempts)",
+			enum.JobStatusQueued, now, enum.JobStatusRunning, now).
+		Order("run_at").
+		First(&jobEntity).
+		Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		log.Error("Job Lease error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	jobEntity.Status = enum.JobStatusRunning
+	jobEntity.LockedBy = workerID
+	jobEntity.LockedUntil = &leaseUntil
+	jobEntity.Attempts++
+	err = db.Model(&entities.Job{}).
+		Where("id = ?", jobEntity.ID).
+		Updates(map[string]interface{}{
+			"status":       jobEntity.Status,
+			"locked_by":    jobEntity.LockedBy,
+			"locked_until": jobEntity.LockedUntil,
+			"attempts":     jobEntity.Attempts,
+		}).
+		Error
+
+	if err != nil {
+		log.Error("Job Lease error", zap.Error(err))
+		err = appErr.DBClientError
+		return nil, err
+	}
+
+	return
+}
+
+// FailExhausted marks the jobs failed whose lease expired on their last attempt,
+// e.g. because the worker crashed every time, and returns how many it marked.
+func (r *JobRepositoryImpl) FailExhausted(db *gorm.DB, now time.Time) (n int64, err error) {
+	result := db.Model(&entities.Job{}).
+		Where("status = ? AND locked_until < ? AND attempts >= max_attempts", enum.JobStatusRunning, now).
+		Updates(map[string]interface{}{
+			"status":       enum.JobStatusFailed,
+			"last_error":   "lease expired on the last attempt",
+			"locked_by":    "",
+			"locked_until": nil,
+			"finished_at":  now,
+		})
+
+	if result.Error != nil {
+		log.Error("Job FailExhausted error", zap.Error(result.Error))
+		return 0, appErr.DBClientError
+	}
+
+	return result.RowsAffected, nil
+}
+
+// Heartbeat extends the lease. ok is false when the job is no longer leased by workerID.
+func (r *JobRepositoryImpl) Heartbeat(db *gorm.DB, jobID uint, workerID string, leaseUntil time.Time) (ok bool, err error) {
+	result := db.Model(&entities.Job{}).
+		Where("id = ? AND status = ? AND locked_by = ?", jobID, enum.JobStatusRunning, workerID).
+		Update("locked_until", leaseUntil)
+
+	if result.Error != nil {
+		log.Error("Job Heartbeat error", zap.Error(result.Error))
+		return false, appErr.DBClientError
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *JobRepositoryImpl) Complete(db *gorm.DB, jobID uint, workerID string, result string, finishedAt time.Time) (err error) {
+	err = db.Model(&entities.Job{}).
+		Where("id = ? AND locked_by = ?", jobID, workerID).
+		Updates(map[string]interface{}{
+			"status":       enum.JobStatusSucceeded,
+			"result":       result,
+			"locked_by":    "",
+			"locked_until": nil,
+			"finished_at":  finishedAt,
+		}).
+		Error
+
+	if err != nil {
+		log.Error("Job Complete error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+// Fail records the error. The job is queued again at retryAt, or marked failed when retryAt is nil.
+func (r *JobRepositoryImpl) Fail(db *gorm.DB, jobID uint, workerID string, lastError string, retryAt *time.Time, finishedAt time.Time) (err error) {
+	values := map[string]interface{}{
+		"last_error":   lastError,
+		"locked_by":    "",
+		"locked_until": nil,
+	}
+	if retryAt != nil {
+		values["status"] = enum.JobStatusQueued
+		values["run_at"] = *retryAt
+	} else {
+		values["status"] = enum.JobStatusFailed
+		values["finished_at"] = finishedAt
+	}
+	err = db.Model(&entities.Job{}).
+		Where("id = ? AND locked_by = ?", jobID, workerID).
+		Updates(values).
+		Error
+
+	if err != nil {
+		log.Error("Job Fail error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
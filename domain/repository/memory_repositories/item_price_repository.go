@@ -0,0 +1,98 @@
+package memory_repositories
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	"gorm.io/gorm"
+)
+
+// ItemPriceRepository keeps the price ranges in memory. It ignores the db
+// argument, so nothing it does is rolled back with the transaction.
+type ItemPriceRepository struct {
+	mu     sync.RWMutex
+	prices []entities.ItemPrice
+	nextID uint
+}
+
+func NewItemPriceRepository(prices ...entities.ItemPrice) *ItemPriceRepository {
+	r := &ItemPriceRepository{}
+	for _, p := range prices {
+		r.Create(nil, &p)
+	}
+	return r
+}
+
+func (r *ItemPriceRepository) find(match func(p entities.ItemPrice) bool) *[]entities.ItemPrice {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	prices := []entities.ItemPrice{}
+	for _, p := range r.prices {
+		if match(p) {
+			prices = append(prices, p)
+		}
+	}
+	sort.SliceStable(prices, func(i, j int) bool {
+		if prices[i].ItemID != prices[j].ItemID {
+			return prices[i].ItemID < prices[j].ItemID
+		}
+		return prices[i].EffectiveFrom.Before(prices[j].EffectiveFrom)
+	})
+	return &prices
+}
+
+func (r *ItemPriceRepository) FindByItemID(db *gorm.DB, itemID int) (prices *[]entities.ItemPrice, err error) {
+	return r.find(func(p entities.ItemPrice) bool { return p.ItemID == uint(itemID) }), nil
+}
+
+func (r *ItemPriceRepository) FindByItemIDsForUpdate(db *gorm.DB, itemIDs []uint) (prices *[]entities.ItemPrice, err error) {
+	return r.find(func(p entities.ItemPrice) bool { return containsID(itemIDs, p.ItemID) }), nil
+}
+
+func (r *ItemPriceRepository) FindEffective(db *gorm.DB, itemIDs []uint, at time.Time) (prices *[]entities.ItemPrice, err error) {
+	return r.find(func(p entities.ItemPrice) bool { return containsID(itemIDs, p.ItemID) && p.EffectiveAt(at) }), nil
+}
+
+func (r *ItemPriceRepository) Create(db *gorm.DB, priceEntity *entities.ItemPrice) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	priceEntity.ID = r.nextID
+	r.prices = append(r.prices, *priceEntity)
+	return nil
+}
+
+func (r *ItemPriceRepository) UpdateRange(db *gorm.DB, priceID uint, effectiveFrom time.Time, effectiveTo *time.Time) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.prices {
+		if r.prices[i].ID == priceID {
+			r.prices[i].EffectiveFrom = effectiveFrom
+			r.prices[i].EffectiveTo = effectiveTo
+		}
+	}
+	return nil
+}
+
+func (r *ItemPriceRepository) Delete(db *gorm.DB, priceID uint) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.prices {
+		if r.prices[i].ID == priceID {
+			r.prices = append(r.prices[:i], r.prices[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func containsID(ids []uint, id uint) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+// Package memory_repositories holds in-memory repository implementations for tests.
+package memory_repositories
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	repositories "github.com/genpsp/go-app/domain/repository"
+	"gorm.io/gorm"
+)
+
+// ItemSearchRepository scores items by how often the query terms occur in their name.
+// It ignores the db argument.
+type ItemSearchRepository struct {
+	mu    sync.RWMutex
+	items []entities.Item
+}
+
+func NewItemSearchRepository(items ...entities.Item) *ItemSearchRepository {
+	return &ItemSearchRepository{items: items}
+}
+
+func (r *ItemSearchRepository) Add(items ...entities.Item) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.items = append(r.items, items...)
+}
+
+func (r *ItemSearchRepository) Search(db *gorm.DB, query string, limit int, offset int) (hits *[]repositories.ItemSearchHit, total int64, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	terms := strings.Fields(strings.ToLower(query))
+	matched := []repositories.ItemSearchHit{}
+	for _, item := range r.items {
+		name := strings.ToLower(item.Name)
+		score := 0.0
+		for _, term := range terms {
+			score += float64(strings.Count(name, term))
+		}
+		if score > 0 {
+			matched = append(matched, repositories.ItemSearchHit{Item: item, Score: score})
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		if matched[i].Score != matched[j].Score {
+			return matched[i].Score > matched[j].Score
+		}
+		return matched[i].Item.ID < matched[j].Item.ID
+	})
+
+	total = int64(len(matched))
+	if offset > len(matched) {
+		offset = len(matched)
+	}
+	end := offset + limit
+	if end > len(matched) {
+		end = len(matched)
+	}
+	result := matched[offset:end]
+	return &result, total, nil
+}
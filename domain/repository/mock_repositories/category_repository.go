@@ -0,0 +1,154 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/category_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	gormmodel "github.com/genpsp/go-app/domain/entities"
+	repositories "github.com/genpsp/go-app/domain/repository"
+	gomock "github.com/golang/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockCategoryRepository is a mock of CategoryRepository interface.
+type MockCategoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryRepositoryMockRecorder
+}
+
+// MockCategoryRepositoryMockRecorder is the mock recorder for MockCategoryRepository.
+type MockCategoryRepositoryMockRecorder struct {
+	mock *MockCategoryRepository
+}
+
+// NewMockCategoryRepository creates a new mock instance.
+func NewMockCategoryRepository(ctrl *gomock.Controller) *MockCategoryRepository {
+	mock := &MockCategoryRepository{ctrl: ctrl}
+	mock.recorder = &MockCategoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryRepository) EXPECT() *MockCategoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountItems mocks base method.
+func (m *MockCategoryRepository) CountItems(db *gorm.DB) (*[]repositories.CategoryItemCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountItems", db)
+	ret0, _ := ret[0].(*[]repositories.CategoryItemCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountItems indicates an expected call of CountItems.
+func (mr *MockCategoryRepositoryMockRecorder) CountItems(db interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountItems", reflect.TypeOf((*MockCategoryRepository)(nil).CountItems), db)
+}
+
+// Create mocks base method.
+func (m *MockCategoryRepository) Create(db *gorm.DB, categoryEntity *gormmodel.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", db, categoryEntity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCategoryRepositoryMockRecorder) Create(db, categoryEntity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCategoryRepository)(nil).Create), db, categoryEntity)
+}
+
+// Delete mocks base method.
+func (m *MockCategoryRepository) Delete(db *gorm.DB, categoryID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", db, categoryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCategoryRepositoryMockRecorder) Delete(db, categoryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategoryRepository)(nil).Delete), db, categoryID)
+}
+
+// FindAll mocks base method.
+func (m *MockCategoryRepository) FindAll(db *gorm.DB) (*[]gormmodel.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", db)
+	ret0, _ := ret[0].(*[]gormmodel.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockCategoryRepositoryMockRecorder) FindAll(db interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCategoryRepository)(nil).FindAll), db)
+}
+
+// FindByID mocks base method.
+func (m *MockCategoryRepository) FindByID(db *gorm.DB, categoryID int) (*gormmodel.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", db, categoryID)
+	ret0, _ := ret[0].(*gormmodel.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockCategoryRepositoryMockRecorder) FindByID(db, categoryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockCategoryRepository)(nil).FindByID), db, categoryID)
+}
+
+// FindByIDs mocks base method.
+func (m *MockCategoryRepository) FindByIDs(db *gorm.DB, categoryIDs []uint) (*[]gormmodel.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", db, categoryIDs)
+	ret0, _ := ret[0].(*[]gormmodel.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockCategoryRepositoryMockRecorder) FindByIDs(db, categoryIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockCategoryRepository)(nil).FindByIDs), db, categoryIDs)
+}
+
+// FindSubtreeIDs mocks base method.
+func (m *MockCategoryRepository) FindSubtreeIDs(db *gorm.DB, categoryID int) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSubtreeIDs", db, categoryID)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSubtreeIDs indicates an expected call of FindSubtreeIDs.
+func (mr *MockCategoryRepositoryMockRecorder) FindSubtreeIDs(db, categoryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSubtreeIDs", reflect.TypeOf((*MockCategoryRepository)(nil).FindSubtreeIDs), db, categoryID)
+}
+
+// Update mocks base method.
+func (m *MockCategoryRepository) Update(db *gorm.DB, categoryID int, categoryEntity *gormmodel.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", db, categoryID, categoryEntity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCategoryRepositoryMockRecorder) Update(db, categoryID, categoryEntity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCategoryRepository)(nil).Update), db, categoryID, categoryEntity)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/item_image_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	gormmodel "github.com/genpsp/go-app/domain/entities"
+	gomock "github.com/golang/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockItemImageRepository is a mock of ItemImageRepository interface.
+type MockItemImageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemImageRepositoryMockRecorder
+}
+
+// MockItemImageRepositoryMockRecorder is the mock recorder for MockItemImageRepository.
+type MockItemImageRepositoryMockRecorder struct {
+	mock *MockItemImageRepository
+}
+
+// NewMockItemImageRepository creates a new mock instance.
+func NewMockItemImageRepository(ctrl *gomock.Controller) *MockItemImageRepository {
+	mock := &MockItemImageRepository{ctrl: ctrl}
+	mock.recorder = &MockItemImageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemImageRepository) EXPECT() *MockItemImageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Attach mocks base method.
+func (m *MockItemImageRepository) Attach(db *gorm.DB, imageID int, size int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attach", db, imageID, size)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Attach indicates an expected call of Attach.
+func (mr *MockItemImageRepositoryMockRecorder) Attach(db, imageID, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attach", reflect.TypeOf((*MockItemImageRepository)(nil).Attach), db, imageID, size)
+}
+
+// Create mocks base method.
+func (m *MockItemImageRepository) Create(db *gorm.DB, imageEntity *gormmodel.ItemImage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", db, imageEntity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockItemImageRepositoryMockRecorder) Create(db, imageEntity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockItemImageRepository)(nil).Create), db, imageEntity)
+}
+
+// FindByID mocks base method.
+func (m *MockItemImageRepository) FindByID(db *gorm.DB, itemID, imageID int) (*gormmodel.ItemImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", db, itemID, imageID)
+	ret0, _ := ret[0].(*gormmodel.ItemImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockItemImageRepositoryMockRecorder) FindByID(db, itemID, imageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockItemImageRepository)(nil).FindByID), db, itemID, imageID)
+}
@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/item_price_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gormmodel "github.com/genpsp/go-app/domain/entities"
+	gomock "github.com/golang/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockItemPriceRepository is a mock of ItemPriceRepository interface.
+type MockItemPriceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemPriceRepositoryMockRecorder
+}
+
+// MockItemPriceRepositoryMockRecorder is the mock recorder for MockItemPriceRepository.
+type MockItemPriceRepositoryMockRecorder struct {
+	mock *MockItemPriceRepository
+}
+
+// NewMockItemPriceRepository creates a new mock instance.
+func NewMockItemPriceRepository(ctrl *gomock.Controller) *MockItemPriceRepository {
+	mock := &MockItemPriceRepository{ctrl: ctrl}
+	mock.recorder = &MockItemPriceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemPriceRepository) EXPECT() *MockItemPriceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockItemPriceRepository) Create(db *gorm.DB, priceEntity *gormmodel.ItemPrice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", db, priceEntity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockItemPriceRepositoryMockRecorder) Create(db, priceEntity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockItemPriceRepository)(nil).Create), db, priceEntity)
+}
+
+// Delete mocks base method.
+func (m *MockItemPriceRepository) Delete(db *gorm.DB, priceID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", db, priceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockItemPriceRepositoryMockRecorder) Delete(db, priceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockItemPriceRepository)(nil).Delete), db, priceID)
+}
+
+// FindByItemID mocks base method.
+func (m *MockItemPriceRepository) FindByItemID(db *gorm.DB, itemID int) (*[]gormmodel.ItemPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByItemID", db, itemID)
+	ret0, _ := ret[0].(*[]gormmodel.ItemPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByItemID indicates an expected call of FindByItemID.
+func (mr *MockItemPriceRepositoryMockRecorder) FindByItemID(db, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByItemID", reflect.TypeOf((*MockItemPriceRepository)(nil).FindByItemID), db, itemID)
+}
+
+// FindByItemIDsForUpdate mocks base method.
+func (m *MockItemPriceRepository) FindByItemIDsForUpdate(db *gorm.DB, itemIDs []uint) (*[]gormmodel.ItemPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByItemIDsForUpdate", db, itemIDs)
+	ret0, _ := ret[0].(*[]gormmodel.ItemPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByItemIDsForUpdate indicates an expected call of FindByItemIDsForUpdate.
+func (mr *MockItemPriceRepositoryMockRecorder) FindByItemIDsForUpdate(db, itemIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByItemIDsForUpdate", reflect.TypeOf((*MockItemPriceRepository)(nil).FindByItemIDsForUpdate), db, itemIDs)
+}
+
+// FindEffective mocks base method.
+func (m *MockItemPriceRepository) FindEffective(db *gorm.DB, itemIDs []uint, at time.Time) (*[]gormmodel.ItemPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEffective", db, itemIDs, at)
+	ret0, _ := ret[0].(*[]gormmodel.ItemPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEffective indicates an expected call of FindEffective.
+func (mr *MockItemPriceRepositoryMockRecorder) FindEffective(db, itemIDs, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEffective", reflect.TypeOf((*MockItemPriceRepository)(nil).FindEffective), db, itemIDs, at)
+}
+
+// UpdateRange mocks base method.
+func (m *MockItemPriceRepository) UpdateRange(db *gorm.DB, priceID uint, effectiveFrom time.Time, effectiveTo *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRange", db, priceID, effectiveFrom, effectiveTo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRange indicates an expected call of UpdateRange.
+func (mr *MockItemPriceRepositoryMockRecorder) UpdateRange(db, priceID, effectiveFrom, effectiveTo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRange", reflect.TypeOf((*MockItemPriceRepository)(nil).UpdateRange), db, priceID, effectiveFrom, effectiveTo)
+}
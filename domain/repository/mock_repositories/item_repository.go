@@ -8,6 +8,7 @@ import (
 	reflect "reflect"
 
 	gormmodel "github.com/genpsp/go-app/domain/entities"
+	repositories "github.com/genpsp/go-app/domain/repository"
 	gomock "github.com/golang/mock/gomock"
 	gorm "gorm.io/gorm"
 )
@@ -78,6 +79,36 @@ func (mr *MockItemRepositoryMockRecorder) FindAll(db interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockItemRepository)(nil).FindAll), db)
 }
 
+// FindByCodes mocks base method.
+func (m *MockItemRepository) FindByCodes(db *gorm.DB, codes []string) (*[]gormmodel.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCodes", db, codes)
+	ret0, _ := ret[0].(*[]gormmodel.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCodes indicates an expected call of FindByCodes.
+func (mr *MockItemRepositoryMockRecorder) FindByCodes(db, codes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCodes", reflect.TypeOf((*MockItemRepository)(nil).FindByCodes), db, codes)
+}
+
+// FindByFilter mocks base method.
+func (m *MockItemRepository) FindByFilter(db *gorm.DB, filter repositories.ItemFilter) (*[]gormmodel.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByFilter", db, filter)
+	ret0, _ := ret[0].(*[]gormmodel.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByFilter indicates an expected call of FindByFilter.
+func (mr *MockItemRepositoryMockRecorder) FindByFilter(db, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByFilter", reflect.TypeOf((*MockItemRepository)(nil).FindByFilter), db, filter)
+}
+
 // FindByID mocks base method.
 func (m *MockItemRepository) FindByID(db *gorm.DB, itemID int) (*gormmodel.Item, error) {
 	m.ctrl.T.Helper()
@@ -93,6 +124,62 @@ func (mr *MockItemRepositoryMockRecorder) FindByID(db, itemID interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockItemRepository)(nil).FindByID), db, itemID)
 }
 
+// FindInBatches mocks base method.
+func (m *MockItemRepository) FindInBatches(db *gorm.DB, filter repositories.ItemFilter, batchSize int, fn func([]gormmodel.Item) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindInBatches", db, filter, batchSize, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindInBatches indicates an expected call of FindInBatches.
+func (mr *MockItemRepositoryMockRecorder) FindInBatches(db, filter, batchSize, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInBatches", reflect.TypeOf((*MockItemRepository)(nil).FindInBatches), db, filter, batchSize, fn)
+}
+
+// ReplaceCategories mocks base method.
+func (m *MockItemRepository) ReplaceCategories(db *gorm.DB, itemEntity *gormmodel.Item, categories []gormmodel.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceCategories", db, itemEntity, categories)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceCategories indicates an expected call of ReplaceCategories.
+func (mr *MockItemRepositoryMockRecorder) ReplaceCategories(db, itemEntity, categories interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceCategories", reflect.TypeOf((*MockItemRepository)(nil).ReplaceCategories), db, itemEntity, categories)
+}
+
+// ReplaceTags mocks base method.
+func (m *MockItemRepository) ReplaceTags(db *gorm.DB, itemEntity *gormmodel.Item, tags []gormmodel.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceTags", db, itemEntity, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceTags indicates an expected call of ReplaceTags.
+func (mr *MockItemRepositoryMockRecorder) ReplaceTags(db, itemEntity, tags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTags", reflect.TypeOf((*MockItemRepository)(nil).ReplaceTags), db, itemEntity, tags)
+}
+
+// Restore mocks base method.
+func (m *MockItemRepository) Restore(db *gorm.DB, itemID int, revisionEntity *gormmodel.ItemRevision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", db, itemID, revisionEntity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockItemRepositoryMockRecorder) Restore(db, itemID, revisionEntity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockItemRepository)(nil).Restore), db, itemID, revisionEntity)
+}
+
 // Update mocks base method.
 func (m *MockItemRepository) Update(db *gorm.DB, itemID int, itemEntity *gormmodel.Item) error {
 	m.ctrl.T.Helper()
@@ -106,3 +193,17 @@ func (mr *MockItemRepositoryMockRecorder) Update(db, itemID, itemEntity interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockItemRepository)(nil).Update), db, itemID, itemEntity)
 }
+
+// UpsertByCode mocks base method.
+func (m *MockItemRepository) UpsertByCode(db *gorm.DB, items *[]gormmodel.Item) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertByCode", db, items)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertByCode indicates an expected call of UpsertByCode.
+func (mr *MockItemRepositoryMockRecorder) UpsertByCode(db, items interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertByCode", reflect.TypeOf((*MockItemRepository)(nil).UpsertByCode), db, items)
+}
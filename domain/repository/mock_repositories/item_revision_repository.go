@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/item_revision_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gormmodel "github.com/genpsp/go-app/domain/entities"
+	enum "github.com/genpsp/go-app/domain/enum"
+	gomock "github.com/golang/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockItemRevisionRepository is a mock of ItemRevisionRepository interface.
+type MockItemRevisionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemRevisionRepositoryMockRecorder
+}
+
+// MockItemRevisionRepositoryMockRecorder is the mock recorder for MockItemRevisionRepository.
+type MockItemRevisionRepositoryMockRecorder struct {
+	mock *MockItemRevisionRepository
+}
+
+// NewMockItemRevisionRepository creates a new mock instance.
+func NewMockItemRevisionRepository(ctrl *gomock.Controller) *MockItemRevisionRepository {
+	mock := &MockItemRevisionRepository{ctrl: ctrl}
+	mock.recorder = &MockItemRevisionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemRevisionRepository) EXPECT() *MockItemRevisionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindAsOf mocks base method.
+func (m *MockItemRevisionRepository) FindAsOf(db *gorm.DB, itemID int, at time.Time) (*gormmodel.ItemRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAsOf", db, itemID, at)
+	ret0, _ := ret[0].(*gormmodel.ItemRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAsOf indicates an expected call of FindAsOf.
+func (mr *MockItemRevisionRepositoryMockRecorder) FindAsOf(db, itemID, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAsOf", reflect.TypeOf((*MockItemRevisionRepository)(nil).FindAsOf), db, itemID, at)
+}
+
+// FindByItemID mocks base method.
+func (m *MockItemRevisionRepository) FindByItemID(db *gorm.DB, itemID int) (*[]gormmodel.ItemRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByItemID", db, itemID)
+	ret0, _ := ret[0].(*[]gormmodel.ItemRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByItemID indicates an expected call of FindByItemID.
+func (mr *MockItemRevisionRepositoryMockRecorder) FindByItemID(db, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByItemID", reflect.TypeOf((*MockItemRevisionRepository)(nil).FindByItemID), db, itemID)
+}
+
+// FindByRevision mocks base method.
+func (m *MockItemRevisionRepository) FindByRevision(db *gorm.DB, itemID, revision int) (*gormmodel.ItemRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByRevision", db, itemID, revision)
+	ret0, _ := ret[0].(*gormmodel.ItemRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByRevision indicates an expected call of FindByRevision.
+func (mr *MockItemRevisionRepositoryMockRecorder) FindByRevision(db, itemID, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByRevision", reflect.TypeOf((*MockItemRevisionRepository)(nil).FindByRevision), db, itemID, revision)
+}
+
+// Record mocks base method.
+func (m *MockItemRevisionRepository) Record(db *gorm.DB, itemIDs []uint, action enum.ItemRevisionAction, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", db, itemIDs, action, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockItemRevisionRepositoryMockRecorder) Record(db, itemIDs, action, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockItemRevisionRepository)(nil).Record), db, itemIDs, action, at)
+}
+
+// RecordByCodes mocks base method.
+func (m *MockItemRevisionRepository) RecordByCodes(db *gorm.DB, codes []string, action enum.ItemRevisionAction, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordByCodes", db, codes, action, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordByCodes indicates an expected call of RecordByCodes.
+func (mr *MockItemRevisionRepositoryMockRecorder) RecordByCodes(db, codes, action, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordByCodes", reflect.TypeOf((*MockItemRevisionRepository)(nil).RecordByCodes), db, codes, action, at)
+}
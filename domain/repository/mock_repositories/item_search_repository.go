@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/item_search_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	repositories "github.com/genpsp/go-app/domain/repository"
+	gomock "github.com/golang/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockItemSearchRepository is a mock of ItemSearchRepository interface.
+type MockItemSearchRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemSearchRepositoryMockRecorder
+}
+
+// MockItemSearchRepositoryMockRecorder is the mock recorder for MockItemSearchRepository.
+type MockItemSearchRepositoryMockRecorder struct {
+	mock *MockItemSearchRepository
+}
+
+// NewMockItemSearchRepository creates a new mock instance.
+func NewMockItemSearchRepository(ctrl *gomock.Controller) *MockItemSearchRepository {
+	mock := &MockItemSearchRepository{ctrl: ctrl}
+	mock.recorder = &MockItemSearchRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemSearchRepository) EXPECT() *MockItemSearchRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockItemSearchRepository) Search(db *gorm.DB, query string, limit, offset int) (*[]repositories.ItemSearchHit, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", db, query, limit, offset)
+	ret0, _ := ret[0].(*[]repositories.ItemSearchHit)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockItemSearchRepositoryMockRecorder) Search(db, query, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockItemSearchRepository)(nil).Search), db, query, limit, offset)
+}
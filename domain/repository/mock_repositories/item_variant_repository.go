@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/item_variant_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	gormmodel "github.com/genpsp/go-app/domain/entities"
+	gomock "github.com/golang/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockItemVariantRepository is a mock of ItemVariantRepository interface.
+type MockItemVariantRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemVariantRepositoryMockRecorder
+}
+
+// MockItemVariantRepositoryMockRecorder is the mock recorder for MockItemVariantRepository.
+type MockItemVariantRepositoryMockRecorder struct {
+	mock *MockItemVariantRepository
+}
+
+// NewMockItemVariantRepository creates a new mock instance.
+func NewMockItemVariantRepository(ctrl *gomock.Controller) *MockItemVariantRepository {
+	mock := &MockItemVariantRepository{ctrl: ctrl}
+	mock.recorder = &MockItemVariantRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemVariantRepository) EXPECT() *MockItemVariantRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockItemVariantRepository) Create(db *gorm.DB, variantEntity *gormmodel.ItemVariant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", db, variantEntity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockItemVariantRepositoryMockRecorder) Create(db, variantEntity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockItemVariantRepository)(nil).Create), db, variantEntity)
+}
+
+// Delete mocks base method.
+func (m *MockItemVariantRepository) Delete(db *gorm.DB, variantID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", db, variantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockItemVariantRepositoryMockRecorder) Delete(db, variantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockItemVariantRepository)(nil).Delete), db, variantID)
+}
+
+// FindByID mocks base method.
+func (m *MockItemVariantRepository) FindByID(db *gorm.DB, itemID, variantID int) (*gormmodel.ItemVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", db, itemID, variantID)
+	ret0, _ := ret[0].(*gormmodel.ItemVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockItemVariantRepositoryMockRecorder) FindByID(db, itemID, variantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockItemVariantRepository)(nil).FindByID), db, itemID, variantID)
+}
+
+// FindByItemIDs mocks base method.
+func (m *MockItemVariantRepository) FindByItemIDs(db *gorm.DB, itemIDs []uint) (*[]gormmodel.ItemVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByItemIDs", db, itemIDs)
+	ret0, _ := ret[0].(*[]gormmodel.ItemVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByItemIDs indicates an expected call of FindByItemIDs.
+func (mr *MockItemVariantRepositoryMockRecorder) FindByItemIDs(db, itemIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByItemIDs", reflect.TypeOf((*MockItemVariantRepository)(nil).FindByItemIDs), db, itemIDs)
+}
+
+// FindBySKU mocks base method.
+func (m *MockItemVariantRepository) FindBySKU(db *gorm.DB, sku string) (*gormmodel.ItemVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySKU", db, sku)
+	ret0, _ := ret[0].(*gormmodel.ItemVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBySKU indicates an expected call of FindBySKU.
+func (mr *MockItemVariantRepositoryMockRecorder) FindBySKU(db, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySKU", reflect.TypeOf((*MockItemVariantRepository)(nil).FindBySKU), db, sku)
+}
+
+// Update mocks base method.
+func (m *MockItemVariantRepository) Update(db *gorm.DB, variantID int, variantEntity *gormmodel.ItemVariant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", db, variantID, variantEntity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockItemVariantRepositoryMockRecorder) Update(db, variantID, variantEntity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockItemVariantRepository)(nil).Update), db, variantID, variantEntity)
+}
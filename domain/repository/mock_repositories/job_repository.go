@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/job_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gormmodel "github.com/genpsp/go-app/domain/entities"
+	gomock "github.com/golang/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockJobRepository is a mock of JobRepository interface.
+type MockJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobRepositoryMockRecorder
+}
+
+// MockJobRepositoryMockRecorder is the mock recorder for MockJobRepository.
+type MockJobRepositoryMockRecorder struct {
+	mock *MockJobRepository
+}
+
+// NewMockJobRepository creates a new mock instance.
+func NewMockJobRepository(ctrl *gomock.Controller) *MockJobRepository {
+	mock := &MockJobRepository{ctrl: ctrl}
+	mock.recorder = &MockJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobRepository) EXPECT() *MockJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method.
+func (m *MockJobRepository) Complete(db *gorm.DB, jobID uint, workerID, result string, finishedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", db, jobID, workerID, result, finishedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockJobRepositoryMockRecorder) Complete(db, jobID, workerID, result, finishedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockJobRepository)(nil).Complete), db, jobID, workerID, result, finishedAt)
+}
+
+// Create mocks base method.
+func (m *MockJobRepository) Create(db *gorm.DB, jobEntity *gormmodel.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", db, jobEntity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockJobRepositoryMockRecorder) Create(db, jobEntity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockJobRepository)(nil).Create), db, jobEntity)
+}
+
+// Fail mocks base method.
+func (m *MockJobRepository) Fail(db *gorm.DB, jobID uint, workerID, lastError string, retryAt *time.Time, finishedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", db, jobID, workerID, lastError, retryAt, finishedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockJobRepositoryMockRecorder) Fail(db, jobID, workerID, lastError, retryAt, finishedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockJobRepository)(nil).Fail), db, jobID, workerID, lastError, retryAt, finishedAt)
+}
+
+// FailExhausted mocks base method.
+func (m *MockJobRepository) FailExhausted(db *gorm.DB, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailExhausted", db, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailExhausted indicates an expected call of FailExhausted.
+func (mr *MockJobRepositoryMockRecorder) FailExhausted(db, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailExhausted", reflect.TypeOf((*MockJobRepository)(nil).FailExhausted), db, now)
+}
+
+// FindByID mocks base method.
+func (m *MockJobRepository) FindByID(db *gorm.DB, createdBy string, jobID int) (*gormmodel.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", db, createdBy, jobID)
+	ret0, _ := ret[0].(*gormmodel.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockJobRepositoryMockRecorder) FindByID(db, createdBy, jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockJobRepository)(nil).FindByID), db, createdBy, jobID)
+}
+
+// Heartbeat mocks base method.
+func (m *MockJobRepository) Heartbeat(db *gorm.DB, jobID uint, workerID string, leaseUntil time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Heartbeat", db, jobID, workerID, leaseUntil)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Heartbeat indicates an expected call of Heartbeat.
+func (mr *MockJobRepositoryMockRecorder) Heartbeat(db, jobID, workerID, leaseUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Heartbeat", reflect.TypeOf((*MockJobRepository)(nil).Heartbeat), db, jobID, workerID, leaseUntil)
+}
+
+// Lease mocks base method.
+func (m *MockJobRepository) Lease(db *gorm.DB, workerID string, now, leaseUntil time.Time) (*gormmodel.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lease", db, workerID, now, leaseUntil)
+	ret0, _ := ret[0].(*gormmodel.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lease indicates an expected call of Lease.
+func (mr *MockJobRepositoryMockRecorder) Lease(db, workerID, now, leaseUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lease", reflect.TypeOf((*MockJobRepository)(nil).Lease), db, workerID, now, leaseUntil)
+}
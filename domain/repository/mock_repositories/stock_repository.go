@@ -0,0 +1,171 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/stock_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gormmodel "github.com/genpsp/go-app/domain/entities"
+	enum "github.com/genpsp/go-app/domain/enum"
+	repositories "github.com/genpsp/go-app/domain/repository"
+	gomock "github.com/golang/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockStockRepository is a mock of StockRepository interface.
+type MockStockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockRepositoryMockRecorder
+}
+
+// MockStockRepositoryMockRecorder is the mock recorder for MockStockRepository.
+type MockStockRepositoryMockRecorder struct {
+	mock *MockStockRepository
+}
+
+// NewMockStockRepository creates a new mock instance.
+func NewMockStockRepository(ctrl *gomock.Controller) *MockStockRepository {
+	mock := &MockStockRepository{ctrl: ctrl}
+	mock.recorder = &MockStockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockRepository) EXPECT() *MockStockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateMovement mocks base method.
+func (m *MockStockRepository) CreateMovement(db *gorm.DB, movementEntity *gormmodel.StockMovement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMovement", db, movementEntity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMovement indicates an expected call of CreateMovement.
+func (mr *MockStockRepositoryMockRecorder) CreateMovement(db, movementEntity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMovement", reflect.TypeOf((*MockStockRepository)(nil).CreateMovement), db, movementEntity)
+}
+
+// CreateReservation mocks base method.
+func (m *MockStockRepository) CreateReservation(db *gorm.DB, reservationEntity *gormmodel.StockReservation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReservation", db, reservationEntity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateReservation indicates an expected call of CreateReservation.
+func (mr *MockStockRepositoryMockRecorder) CreateReservation(db, reservationEntity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReservation", reflect.TypeOf((*MockStockRepository)(nil).CreateReservation), db, reservationEntity)
+}
+
+// ExpireReservations mocks base method.
+func (m *MockStockRepository) ExpireReservations(db *gorm.DB, key repositories.StockKey, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireReservations", db, key, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpireReservations indicates an expected call of ExpireReservations.
+func (mr *MockStockRepositoryMockRecorder) ExpireReservations(db, key, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireReservations", reflect.TypeOf((*MockStockRepository)(nil).ExpireReservations), db, key, now)
+}
+
+// FindMovements mocks base method.
+func (m *MockStockRepository) FindMovements(db *gorm.DB, key repositories.StockKey, limit, offset int) (*[]gormmodel.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMovements", db, key, limit, offset)
+	ret0, _ := ret[0].(*[]gormmodel.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMovements indicates an expected call of FindMovements.
+func (mr *MockStockRepositoryMockRecorder) FindMovements(db, key, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMovements", reflect.TypeOf((*MockStockRepository)(nil).FindMovements), db, key, limit, offset)
+}
+
+// FindReservationByID mocks base method.
+func (m *MockStockRepository) FindReservationByID(db *gorm.DB, reservationID int) (*gormmodel.StockReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindReservationByID", db, reservationID)
+	ret0, _ := ret[0].(*gormmodel.StockReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindReservationByID indicates an expected call of FindReservationByID.
+func (mr *MockStockRepositoryMockRecorder) FindReservationByID(db, reservationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindReservationByID", reflect.TypeOf((*MockStockRepository)(nil).FindReservationByID), db, reservationID)
+}
+
+// LockItem mocks base method.
+func (m *MockStockRepository) LockItem(db *gorm.DB, itemID uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockItem", db, itemID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockItem indicates an expected call of LockItem.
+func (mr *MockStockRepositoryMockRecorder) LockItem(db, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockItem", reflect.TypeOf((*MockStockRepository)(nil).LockItem), db, itemID)
+}
+
+// OnHand mocks base method.
+func (m *MockStockRepository) OnHand(db *gorm.DB, key repositories.StockKey) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OnHand", db, key)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OnHand indicates an expected call of OnHand.
+func (mr *MockStockRepositoryMockRecorder) OnHand(db, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnHand", reflect.TypeOf((*MockStockRepository)(nil).OnHand), db, key)
+}
+
+// Reserved mocks base method.
+func (m *MockStockRepository) Reserved(db *gorm.DB, key repositories.StockKey, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserved", db, key, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserved indicates an expected call of Reserved.
+func (mr *MockStockRepositoryMockRecorder) Reserved(db, key, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserved", reflect.TypeOf((*MockStockRepository)(nil).Reserved), db, key, now)
+}
+
+// TransitReservation mocks base method.
+func (m *MockStockRepository) TransitReservation(db *gorm.DB, reservationID uint, to enum.StockReservationStatus, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitReservation", db, reservationID, to, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransitReservation indicates an expected call of TransitReservation.
+func (mr *MockStockRepositoryMockRecorder) TransitReservation(db, reservationID, to, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitReservation", reflect.TypeOf((*MockStockRepository)(nil).TransitReservation), db, reservationID, to, now)
+}
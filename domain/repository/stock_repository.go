@@ -0,0 +1,187 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/domain/enum"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	StockRepository interface {
+		LockItem(db *gorm.DB, itemID uint) (ok bool, err error)
+		OnHand(db *gorm.DB, key StockKey) (quantity int64, err error)
+		Reserved(db *gorm.DB, key StockKey, now time.Time) (quantity int64, err error)
+		FindMovements(db *gorm.DB, key StockKey, limit int, offset int) (movements *[]entities.StockMovement, err error)
+		CreateMovement(db *gorm.DB, movementEntity *entities.StockMovement) (err error)
+		FindReservationByID(db *gorm.DB, reservationID int) (reservationEntity *entities.StockReservation, err error)
+		CreateReservation(db *gorm.DB, reservationEntity *entities.StockReservation) (err error)
+		TransitReservation(db *gorm.DB, reservationID uint, to enum.StockReservationStatus, now time.Time) (ok bool, err error)
+		ExpireReservations(db *gorm.DB, key StockKey, now time.Time) (err error)
+	}
+	StockRepositoryImpl struct{}
+
+	// StockKey identifies the stock of an item, or of one of its variants when ItemVariantID is set.
+	StockKey struct {
+		ItemID        uint
+		ItemVariantID *uint
+	}
+)
+
+func NewStockRepository() StockRepository {
+	return &StockRepositoryImpl{}
+}
+
+func (k StockKey) apply(db *gorm.DB) *gorm.DB {
+	db = db.Where("item_id = ?", k.ItemID)
+	if k.ItemVariantID == nil {
+		return db.Where("item_variant_id IS NULL")
+	}
+	return db.Where("item_variant_id = ?", *k.ItemVariantID)
+}
+
+// LockItem takes a row lock on the item with SELECT ... FOR UPDATE. Every
+// stock change of the item and of its variants goes through this lock, so it
+// must be called inside the transaction that checks and writes the stock.
+func (r *StockRepositoryImpl) LockItem(db *gorm.DB, itemID uint) (ok bool, err error) {
+	var ids []uint
+	err = db.Model(&entities.Item{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", itemID).
+		Pluck("id", &ids).Error
+
+	if err != nil {
+		log.Error("Stock LockItem error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return len(ids) == 1, nil
+}
+
+// OnHand derives the on-hand quantity from the movement ledger.
+func (r *StockRepositoryImpl) OnHand(db *gorm.DB, key StockKey) (quantity int64, err error) {
+	err = key.apply(db.Model(&entities.StockMovement{})).
+		Select("COALESCE(SUM(quantity), 0)").
+		Scan(&quantity).Error
+
+	if err != nil {
+		log.Error("Stock OnHand error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+// Reserved sums the active reservations that have not expired at now.
+func (r *StockRepositoryImpl) Reserved(db *gorm.DB, key StockKey, now time.Time) (quantity int64, err error) {
+	err = key.apply(db.Model(&entities.StockReservation{})).
+		Where("status = ? AND expires_at > ?", enum.StockReservationStatusActive, now).
+		Select("COALESCE(SUM(quantity), 0)").
+		Scan(&quantity).Error
+
+	if err != nil {
+		log.Error("Stock Reserved error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *StockRepositoryImpl) FindMovements(db *gorm.DB, key StockKey, limit int, offset int) (movements *[]entities.StockMovement, err error) {
+	err = key.apply(db.Model(&entities.StockMovement{})).
+		Order("id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&movements).Error
+
+	if err != nil {
+		log.Error("Stock FindMovements error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *StockRepositoryImpl) CreateMovement(db *gorm.DB, movementEntity *entities.StockMovement) (err error) {
+	err = db.Create(&movementEntity).Error
+
+	if err != nil {
+		log.Error("Stock CreateMovement error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *StockRepositoryImpl) FindReservationByID(db *gorm.DB, reservationID int) (reservationEntity *entities.StockReservation, err error) {
+	err = db.Model(&entities.StockReservation{}).
+		Where("id = ?", reservationID).
+		First(&reservationEntity).
+		Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Info("StockReservation. record not found.")
+		return nil, nil
+	}
+
+	if err != nil {
+		log.Error("Stock FindReservationByID error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *StockRepositoryImpl) CreateReservation(db *gorm.DB, reservationEntity *entities.StockReservation) (err error) {
+	err = db.Create(&reservationEntity).Error
+
+	if err != nil {
+		log.Error("Stock CreateReservation error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+// TransitReservation moves an active, unexpired reservation to status to.
+// ok is false when the reservation was no longer active.
+func (r *StockRepositoryImpl) TransitReservation(db *gorm.DB, reservationID uint, to enum.StockReservationStatus, now time.Time) (ok bool, err error) {
+	result := db.Model(&entities.StockReservation{}).
+		Where("id = ? AND status = ? AND expires_at > ?", reservationID, enum.StockReservationStatusActive, now).
+		Update("status", to)
+
+	if result.Error != nil {
+		log.Error("Stock TransitReservation error", zap.Error(result.Error))
+		err = appErr.DBClientError
+		return
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// ExpireReservations marks the active reservations past their expiry as expired.
+func (r *StockRepositoryImpl) ExpireReservations(db *gorm.DB, key StockKey, now time.Time) (err error) {
+	err = key.apply(db.Model(&entities.StockReservation{})).
+		Where("status = ? AND expires_at <= ?", enum.StockReservationStatusActive, now).
+		Update("status", enum.StockReservationStatusExpired).Error
+
+	if err != nil {
+		log.Error("Stock ExpireReservations error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
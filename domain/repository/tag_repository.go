@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	entities "github.com/genpsp/go-app/domain/entities"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	TagRepository interface {
+		FindAll(db *gorm.DB) (tags *[]entities.Tag, err error)
+		FindOrCreateByNames(db *gorm.DB, names []string) (tags *[]entities.Tag, err error)
+		Create(db *gorm.DB, tagEntity *entities.Tag) (err error)
+		Delete(db *gorm.DB, tagID int) (err error)
+	}
+	TagRepositoryImpl struct{}
+)
+
+func NewTagRepository() TagRepository {
+	return &TagRepositoryImpl{}
+}
+
+func (r *TagRepositoryImpl) FindAll(db *gorm.DB) (tags *[]entities.Tag, err error) {
+	err = db.Model(&entities.Tag{}).
+		Order("name").
+		Find(&tags).Error
+
+	if err != nil {
+		log.Error("Tag FindAll error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+// FindOrCreateByNames returns the tags named names, creating the missing ones.
+func (r *TagRepositoryImpl) FindOrCreateByNames(db *gorm.DB, names []string) (tags *[]entities.Tag, err error) {
+	if len(names) > 0 {
+		newTags := make([]entities.Tag, len(names))
+		for i, name := range names {
+			newTags[i] = entities.Tag{Name: name}
+		}
+		err = db.Clauses(clause.OnConflict{DoNothing: true}).Create(&newTags).Error
+		if err != nil {
+			log.Error("Tag FindOrCreateByNames error", zap.Error(err))
+			err = appErr.DBClientError
+			return
+		}
+	}
+
+	err = db.Model(&entities.Tag{}).
+		Where("name IN ?", names).
+		Find(&tags).Error
+
+	if err != nil {
+		log.Error("Tag FindOrCreateByNames error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+func (r *TagRepositoryImpl) Create(db *gorm.DB, tagEntity *entities.Tag) (err error) {
+	err = db.Create(&tagEntity).Error
+
+	if isDuplicateKey(err) {
+		log.Info("Tag Create duplicate name", zap.String("name", tagEntity.Name))
+		return ErrDuplicateKey
+	}
+
+	if err != nil {
+		log.Error("Tag Create error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+
+	return
+}
+
+// Delete removes the tag physically so its name can be used again.
+func (r *TagRepositoryImpl) Delete(db *gorm.DB, tagID int) (err error) {
+	tagEntity := entities.Tag{}
+	err = db.Unscoped().Model(&tagEntity).Where("id = ?", tagID).Delete(&tagEntity).Error
+	if err != nil {
+		log.Error("Tag Delete error", zap.Error(err))
+		err = appErr.DBClientError
+		return
+	}
+	return
+}
@@ -0,0 +1,34 @@
+package accesslog
+
+import (
+	"strconv"
+
+	"github.com/genpsp/go-app/pkg/env"
+)
+
+type AccessLog struct {
+	Enabled bool
+	// SampleRate is the fraction of successful requests logged, between 0 and 1.
+	// 4xx and 5xx responses and requests with a sampled trace are always logged.
+	SampleRate float64 `validate:"gte=0,lte=1"`
+	// RouteSampleRates is keyed by method and route path, e.g. "GET /app/items/search".
+	RouteSampleRates map[string]float64 `validate:"dive,gte=0,lte=1"`
+}
+
+func NewConfig(env env.Env) AccessLog {
+	// "<method> <path>=<rate>", e.g. "GET /app/items/search=0.05"
+	rates := map[string]float64{}
+	env.Pairs("ACCESS_LOG_ROUTE_SAMPLE_RATES", func(k string, v string) error {
+		r, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return err
+		}
+		rates[k] = r
+		return nil
+	})
+	return AccessLog{
+		Enabled:          env.Bool("ACCESS_LOG_ENABLED", true),
+		SampleRate:       env.Float("ACCESS_LOG_SAMPLE_RATE", 1),
+		RouteSampleRates: rates,
+	}
+}
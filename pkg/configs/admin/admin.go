@@ -0,0 +1,21 @@
+package admin
+
+import (
+	"github.com/genpsp/go-app/pkg/env"
+)
+
+type Admin struct {
+	// Enabled serves the admin routes such as the log levels. The admin
+	// server also starts for the metrics alone.
+	Enabled bool
+	// Addr is the port of the admin server. It must not be exposed publicly,
+	// the admin server has no authentication.
+	Addr string `validate:"required,numeric"`
+}
+
+func NewConfig(env env.Env) Admin {
+	return Admin{
+		Enabled: env.Bool("ADMIN_ENABLED", true),
+		Addr:    env.String("ADMIN_ADDR", "9090"),
+	}
+}
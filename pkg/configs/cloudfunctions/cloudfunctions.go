@@ -0,0 +1,15 @@
+package cloudfunctions
+
+import "github.com/genpsp/go-app/pkg/env"
+
+type CloudFunctions struct {
+	// BaseURL is where the functions are deployed, e.g.
+	// "https://asia-northeast1-<project>.cloudfunctions.net".
+	BaseURL string `validate:"omitempty,url"`
+}
+
+func NewConfig(env env.Env) CloudFunctions {
+	return CloudFunctions{
+		BaseURL: env.String("CLOUD_FUNCTIONS_BASE_URL", ""),
+	}
+}
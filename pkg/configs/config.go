@@ -1,14 +1,28 @@
 package configs
 
 import (
-	"github.com/genpsp/go-app/pkg/configs/cloudfunctions"
+	"fmt"
+	"os"
 	"sync"
 
+	"github.com/genpsp/go-app/pkg/configs/accesslog"
+	"github.com/genpsp/go-app/pkg/configs/admin"
+	"github.com/genpsp/go-app/pkg/configs/cloudfunctions"
 	"github.com/genpsp/go-app/pkg/configs/firebase"
 	"github.com/genpsp/go-app/pkg/configs/gcs"
+	"github.com/genpsp/go-app/pkg/configs/health"
+	"github.com/genpsp/go-app/pkg/configs/idempotency"
+	"github.com/genpsp/go-app/pkg/configs/job"
 	"github.com/genpsp/go-app/pkg/configs/logger"
+	"github.com/genpsp/go-app/pkg/configs/metrics"
+	"github.com/genpsp/go-app/pkg/configs/migration"
 	"github.com/genpsp/go-app/pkg/configs/mysql"
+	"github.com/genpsp/go-app/pkg/configs/ratelimit"
+	"github.com/genpsp/go-app/pkg/configs/secrets"
+	"github.com/genpsp/go-app/pkg/configs/security"
+	"github.com/genpsp/go-app/pkg/configs/storage"
 	"github.com/genpsp/go-app/pkg/configs/system"
+	"github.com/genpsp/go-app/pkg/configs/tracing"
 	env "github.com/genpsp/go-app/pkg/env"
 )
 
@@ -16,15 +30,46 @@ var Config *Configuration
 var once sync.Once
 
 type Configuration struct {
-	MySQL mysql.MySql
+	System         system.System
+	Logger         logger.Logger
+	MySQL          mysql.MySql
+	Migration      migration.Migration
+	Secrets        secrets.Secrets
+	Firebase       firebase.Firebase
+	CloudFunctions cloudfunctions.CloudFunctions
+	GCS            gcs.GCS
+	Storage        storage.Storage
+	Job            job.Job
+	Idempotency    idempotency.Idempotency
+	RateLimit      ratelimit.RateLimit
+	Security       security.Security
+	AccessLog      accesslog.AccessLog
+	Metrics        metrics.Metrics
+	Admin          admin.Admin
+	Tracing        tracing.Tracing
+	Health         health.Health
 }
 
+// LoadConfig loads the configuration once and exits when it is invalid,
+// listing every problem.
 func LoadConfig() {
 	once.Do(func() {
-		env := env.NewEnv()
+		cfg, err := Load()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		Config = cfg
+	})
+}
 
-		Config = &Configuration{
-			MySQL: mysql.NewConfig(env),
+// TestLoadConfig loads the configuration without validating it, as tests
+// run without the settings a server needs.
+func TestLoadConfig() {
+	once.Do(func() {
+		Config, _ = Load()
+		if Config == nil {
+			Config = newConfiguration(env.New("", func(string) (string, bool) { return "", false }))
 		}
 	})
 }
@@ -0,0 +1,35 @@
+package firebase
+
+import (
+	"github.com/genpsp/go-app/pkg/env"
+	"google.golang.org/api/option"
+)
+
+type Firebase struct {
+	ProjectID string
+	// CredentialsFile empty uses the application default credentials.
+	CredentialsFile string
+	// Credentials is a service account JSON key, usually a secret reference
+	// like "sm://<project>/firebase-admin". It wins over CredentialsFile.
+	Credentials string `secret:"true"`
+}
+
+func NewConfig(env env.Env) Firebase {
+	return Firebase{
+		ProjectID:       env.String("FIREBASE_PROJECT_ID", env.String("GOOGLE_CLOUD_PROJECT", "")),
+		CredentialsFile: env.String("FIREBASE_CREDENTIALS_FILE", ""),
+		Credentials:     env.String("FIREBASE_CREDENTIALS", ""),
+	}
+}
+
+// ClientOptions are the options the Firebase app is created with, once
+// Credentials is resolved. None leaves the SDK to its default credentials.
+func (f Firebase) ClientOptions() []option.ClientOption {
+	switch {
+	case f.Credentials != "":
+		return []option.ClientOption{option.WithCredentialsJSON([]byte(f.Credentials))}
+	case f.CredentialsFile != "":
+		return []option.ClientOption{option.WithCredentialsFile(f.CredentialsFile)}
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package gcs
+
+import (
+	"github.com/genpsp/go-app/pkg/env"
+)
+
+type GCS struct {
+	BucketName      string
+	CredentialsFile string
+	// SignerEmail is the service account signing URLs through IAM when
+	// CredentialsFile holds no private key, e.g. with workload identity.
+	// It defaults to the account of the metadata server.
+	SignerEmail string
+}
+
+func NewConfig(env env.Env) GCS {
+	return GCS{
+		BucketName:      env.String("GCS_BUCKET_NAME", ""),
+		CredentialsFile: env.String("GOOGLE_APPLICATION_CREDENTIALS", ""),
+		SignerEmail:     env.String("GCS_SIGNER_EMAIL", ""),
+	}
+}
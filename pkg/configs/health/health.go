@@ -0,0 +1,32 @@
+package health
+
+import (
+	"time"
+
+	"github.com/genpsp/go-app/pkg/env"
+)
+
+type Health struct {
+	// Timeout bounds every readiness check.
+	Timeout time.Duration `validate:"gt=0"`
+	// DrainDelay is how long the server keeps serving with failing readiness
+	// after a shutdown signal, so the load balancer stops sending traffic first.
+	DrainDelay time.Duration `validate:"gte=0"`
+	// CheckFirebase adds a reachability check of FirebaseURL to readiness.
+	CheckFirebase bool
+	FirebaseURL   string `validate:"url"`
+}
+
+func NewConfig(env env.Env) Health {
+	drainDelay := time.Duration(0)
+	if env.Deployed() {
+		drainDelay = 10 * time.Second
+	}
+	return Health{
+		Timeout:       env.Seconds("HEALTH_CHECK_TIMEOUT_SEC", 2*time.Second),
+		DrainDelay:    env.Seconds("HEALTH_DRAIN_DELAY_SEC", drainDelay),
+		CheckFirebase: env.Bool("HEALTH_CHECK_FIREBASE", false),
+		// the keys Firebase ID tokens are verified with
+		FirebaseURL: env.String("HEALTH_FIREBASE_URL", "https://www.googleapis.com/robot/v1/metadata/x509/securetoken@system.gserviceaccount.com"),
+	}
+}
@@ -0,0 +1,22 @@
+package idempotency
+
+import (
+	"time"
+
+	"github.com/genpsp/go-app/pkg/env"
+)
+
+type Idempotency struct {
+	// TTL is how long a stored response is replayed for retries with the same key.
+	TTL time.Duration `validate:"gt=0"`
+	// LockTimeout is how long a request may hold its key in flight before a
+	// retry is allowed to take it over, e.g. after the process died.
+	LockTimeout time.Duration `validate:"gt=0"`
+}
+
+func NewConfig(env env.Env) Idempotency {
+	return Idempotency{
+		TTL:         env.Seconds("IDEMPOTENCY_TTL_SEC", 24*time.Hour),
+		LockTimeout: env.Seconds("IDEMPOTENCY_LOCK_TIMEOUT_SEC", time.Minute),
+	}
+}
@@ -0,0 +1,32 @@
+package job
+
+import (
+	"time"
+
+	"github.com/genpsp/go-app/pkg/env"
+)
+
+type Job struct {
+	// Embedded runs the workers inside the HTTP server process.
+	Embedded          bool
+	Concurrency       int           `validate:"gt=0"`
+	MaxAttempts       int           `validate:"gt=0"`
+	PollInterval      time.Duration `validate:"gt=0"`
+	LeaseDuration     time.Duration `validate:"gtfield=HeartbeatInterval"`
+	HeartbeatInterval time.Duration `validate:"gt=0"`
+	BaseBackoff       time.Duration `validate:"gt=0"`
+	MaxBackoff        time.Duration `validate:"gtefield=BaseBackoff"`
+}
+
+func NewConfig(env env.Env) Job {
+	return Job{
+		Embedded:          env.Bool("JOB_EMBEDDED", !env.Deployed()),
+		Concurrency:       env.Int("JOB_CONCURRENCY", 4),
+		MaxAttempts:       env.Int("JOB_MAX_ATTEMPTS", 5),
+		PollInterval:      env.Seconds("JOB_POLL_INTERVAL_SEC", 2*time.Second),
+		LeaseDuration:     env.Seconds("JOB_LEASE_DURATION_SEC", 60*time.Second),
+		HeartbeatInterval: env.Seconds("JOB_HEARTBEAT_INTERVAL_SEC", 20*time.Second),
+		BaseBackoff:       env.Seconds("JOB_BASE_BACKOFF_SEC", 10*time.Second),
+		MaxBackoff:        env.Seconds("JOB_MAX_BACKOFF_SEC", time.Hour),
+	}
+}
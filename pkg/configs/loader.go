@@ -0,0 +1,298 @@
+package configs
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/genpsp/go-app/pkg/configs/accesslog"
+	"github.com/genpsp/go-app/pkg/configs/admin"
+	"github.com/genpsp/go-app/pkg/configs/cloudfunctions"
+	"github.com/genpsp/go-app/pkg/configs/firebase"
+	"github.com/genpsp/go-app/pkg/configs/gcs"
+	"github.com/genpsp/go-app/pkg/configs/health"
+	"github.com/genpsp/go-app/pkg/configs/idempotency"
+	"github.com/genpsp/go-app/pkg/configs/job"
+	"github.com/genpsp/go-app/pkg/configs/logger"
+	"github.com/genpsp/go-app/pkg/configs/metrics"
+	"github.com/genpsp/go-app/pkg/configs/migration"
+	"github.com/genpsp/go-app/pkg/configs/mysql"
+	"github.com/genpsp/go-app/pkg/configs/ratelimit"
+	"github.com/genpsp/go-app/pkg/configs/secrets"
+	"github.com/genpsp/go-app/pkg/configs/security"
+	"github.com/genpsp/go-app/pkg/configs/storage"
+	"github.com/genpsp/go-app/pkg/configs/system"
+	"github.com/genpsp/go-app/pkg/configs/tracing"
+	env "github.com/genpsp/go-app/pkg/env"
+	validator "gopkg.in/go-playground/validator.v9"
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "[REDACTED]"
+
+// mapKeys matches the map keys in a validator namespace, which may contain dots.
+var mapKeys = regexp.MustCompile(`\[[^\]]*\]`)
+
+// Error lists every problem of a configuration, so they can all be fixed at once.
+type Error struct {
+	Problems []string
+}
+
+func (e *Error) Error() string {
+	return "invalid configuration:\n  " + strings.Join(e.Problems, "\n  ")
+}
+
+// Load reads the configuration. Each source overrides the ones before it:
+//
+//  1. the defaults of each NewConfig
+//  2. the YAML file at CONFIG_FILE, if set
+//  3. the overlay <CONFIG_ENV_DIR>/<ENV>.env, "envs/local.env" by default, if it exists
+//  4. the process environment
+//
+// YAML keys are the env keys split at their nesting, e.g. mysql.db_name is
+// MYSQL_DB_NAME. Load returns the configuration with an *Error when a value
+// does not parse or does not validate.
+func Load() (*Configuration, error) {
+	name := os.Getenv("ENV")
+	settings := map[string]string{}
+
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		if err := readYAML(path, settings); err != nil {
+			return nil, err
+		}
+	}
+
+	dir := os.Getenv("CONFIG_ENV_DIR")
+	if dir == "" {
+		dir = "envs"
+	}
+	overlay := name
+	if overlay == "" {
+		overlay = "local"
+	}
+	if err := readEnvFile(filepath.Join(dir, overlay+".env"), settings); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if name == "" {
+		name = settings["ENV"]
+	}
+
+	e := env.New(name, func(key string) (string, bool) {
+		if v, ok := os.LookupEnv(key); ok && strings.TrimSpace(v) != "" {
+			return v, true
+		}
+		v, ok := settings[key]
+		return v, ok
+	})
+	cfg := newConfiguration(e)
+	problems := append(e.Errs(), validate(cfg)...)
+	if len(problems) > 0 {
+		return cfg, &Error{Problems: problems}
+	}
+	return cfg, nil
+}
+
+func newConfiguration(env env.Env) *Configuration {
+	return &Configuration{
+		System:         system.NewConfig(env),
+		Logger:         logger.NewConfig(env),
+		MySQL:          mysql.NewConfig(env),
+		Migration:      migration.NewConfig(env),
+		Secrets:        secrets.NewConfig(env),
+		Firebase:       firebase.NewConfig(env),
+		CloudFunctions: cloudfunctions.NewConfig(env),
+		GCS:            gcs.NewConfig(env),
+		Storage:        storage.NewConfig(env),
+		Job:            job.NewConfig(env),
+		Idempotency:    idempotency.NewConfig(env),
+		RateLimit:      ratelimit.NewConfig(env),
+		Security:       security.NewConfig(env),
+		AccessLog:      accesslog.NewConfig(env),
+		Metrics:        metrics.NewConfig(env),
+		Admin:          admin.NewConfig(env),
+		Tracing:        tracing.NewConfig(env),
+		Health:         health.NewConfig(env),
+	}
+}
+
+// readEnvFile reads KEY=VALUE lines in the format of envs/*.env. Blank
+// lines, "#" comments and a leading "export" are allowed, values may be quoted.
+func readEnvFile(path string, settings map[string]string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+		i := strings.Index(line, "=")
+		if i <= 0 {
+			return fmt.Errorf("%s:%d: want KEY=VALUE", path, n)
+		}
+		value := strings.TrimSpace(line[i+1:])
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			if value[0] == '"' {
+				if value, err = strconv.Unquote(value); err != nil {
+					return fmt.Errorf("%s:%d: %w", path, n, err)
+				}
+			} else {
+				value = value[1 : len(value)-1]
+			}
+		}
+		settings[strings.TrimSpace(line[:i])] = value
+	}
+	return scanner.Err()
+}
+
+func readYAML(path string, settings map[string]string) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	flatten("", doc, settings)
+	return nil
+}
+
+// flatten stores nested keys joined by "_" and upper cased, lists comma separated.
+func flatten(prefix string, v interface{}, settings map[string]string) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			key := strings.ToUpper(k)
+			if prefix != "" {
+				key = prefix + "_" + key
+			}
+			flatten(key, child, settings)
+		}
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = fmt.Sprint(item)
+		}
+		settings[prefix] = strings.Join(items, ",")
+	case nil:
+	default:
+		settings[prefix] = fmt.Sprint(v)
+	}
+}
+
+func validate(cfg *Configuration) (problems []string) {
+	v := validator.New()
+	v.RegisterValidation("timezone", func(fl validator.FieldLevel) bool {
+		_, err := time.LoadLocation(fl.Field().String())
+		return err == nil
+	})
+	err := v.Struct(cfg)
+	if err == nil {
+		return nil
+	}
+	errs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return []string{err.Error()}
+	}
+	for _, fe := range errs {
+		// Configuration.MySQL.DBName -> MySQL.DBName
+		field := strings.TrimPrefix(fe.Namespace(), "Configuration.")
+		rule := fe.Tag()
+		if fe.Param() != "" {
+			rule += "=" + fe.Param()
+		}
+		value := fmt.Sprint(fe.Value())
+		if isSecret(field) {
+			value = redacted
+		}
+		problems = append(problems, fmt.Sprintf("%s: %q does not satisfy %s", field, value, rule))
+	}
+	return problems
+}
+
+// isSecret reports whether the field at a validator namespace like
+// "MySQL.MasterPassword" is tagged secret.
+func isSecret(namespace string) bool {
+	t := reflect.TypeOf(Configuration{})
+	for _, name := range strings.Split(mapKeys.ReplaceAllString(namespace, ""), ".") {
+		for t.Kind() == reflect.Map || t.Kind() == reflect.Slice {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return false
+		}
+		f, ok := t.FieldByName(name)
+		if !ok {
+			return false
+		}
+		if f.Tag.Get("secret") == "true" {
+			return true
+		}
+		t = f.Type
+	}
+	return false
+}
+
+// Redacted returns the configuration as nested maps for printing, with the
+// values of fields tagged `secret:"true"` replaced when set.
+func (c *Configuration) Redacted() map[string]interface{} {
+	return redact(reflect.ValueOf(*c)).(map[string]interface{})
+}
+
+func (c *Configuration) String() string {
+	b, _ := yaml.Marshal(c.Redacted())
+	return string(b)
+}
+
+func redact(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Struct:
+		m := map[string]interface{}{}
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+			if f.Tag.Get("secret") == "true" {
+				if !v.Field(i).IsZero() {
+					m[f.Name] = redacted
+				} else {
+					m[f.Name] = ""
+				}
+				continue
+			}
+			m[f.Name] = redact(v.Field(i))
+		}
+		return m
+	case reflect.Map:
+		m := map[string]interface{}{}
+		for _, k := range v.MapKeys() {
+			m[fmt.Sprint(k)] = redact(v.MapIndex(k))
+		}
+		return m
+	case reflect.Slice:
+		list := make([]interface{}, v.Len())
+		for i := range list {
+			list[i] = redact(v.Index(i))
+		}
+		return list
+	case reflect.Int64:
+		if d, ok := v.Interface().(time.Duration); ok {
+			return d.String()
+		}
+	}
+	return v.Interface()
+}
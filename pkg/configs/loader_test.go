@@ -0,0 +1,105 @@
+package configs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// setenv sets key until the end of the current Convey block.
+func setenv(key string, value string) {
+	old, ok := os.LookupEnv(key)
+	os.Setenv(key, value)
+	Reset(func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	if err := ioutil.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_Load(t *testing.T) {
+	Convey("設定を読み込む", t, func() {
+		dir := t.TempDir()
+		setenv("ENV", "")
+		setenv("CONFIG_FILE", "")
+		setenv("CONFIG_ENV_DIR", dir)
+		setenv("MYSQL_DB_NAME", "")
+		setenv("MYSQL_MASTER_PASSWORD", "")
+		writeFile(t, filepath.Join(dir, "local.env"), `
+# local
+MYSQL_MASTER_USERNAME=app
+export MYSQL_MASTER_HOST="localhost:3306"
+MYSQL_MAX_OPEN_CONNS=20
+`)
+
+		Convey("YAML、envsファイル、環境変数の順に上書きする", func() {
+			writeFile(t, filepath.Join(dir, "config.yaml"), `
+mysql:
+  db_name: from_yaml
+  max_open_conns: 5
+  master_password: secret
+cors:
+  allow_origins:
+    - https://example.com
+    - https://admin.example.com
+`)
+			setenv("CONFIG_FILE", filepath.Join(dir, "config.yaml"))
+			setenv("MYSQL_DB_NAME", "from_env")
+
+			cfg, err := Load()
+			So(err, ShouldBeNil)
+			So(cfg.MySQL.DBName, ShouldEqual, "from_env")
+			So(cfg.MySQL.MaxOpenConns, ShouldEqual, 20)
+			So(cfg.MySQL.MasterHost, ShouldEqual, "tcp(localhost:3306)")
+			So(cfg.MySQL.MasterPassword, ShouldEqual, "secret")
+			So(cfg.Security.CORS.AllowOrigins, ShouldResemble, []string{"https://example.com", "https://admin.example.com"})
+			So(cfg.Job.PollInterval, ShouldEqual, 2*time.Second)
+		})
+		Convey("すべての問題をまとめて返す", func() {
+			setenv("MYSQL_MAX_OPEN_CONNS", "many")
+			setenv("LOG_LEVEL", "trace")
+			setenv("TRACE_SAMPLE_RATIO", "2")
+			setenv("RATE_LIMIT_ROUTES", "POST /app/items=30")
+
+			cfg, err := Load()
+			So(cfg, ShouldNotBeNil)
+			So(err, ShouldHaveSameTypeAs, &Error{})
+			So(err.(*Error).Problems, ShouldResemble, []string{
+				`MYSQL_MAX_OPEN_CONNS: "many" is not an integer`,
+				`RATE_LIMIT_ROUTES: "POST /app/items=30": want <requests>/<window sec>[/<burst>]`,
+				`Logger.LogLevel: "trace" does not satisfy oneof=debug info warn error`,
+				`MySQL.DBName: "" does not satisfy required`,
+				`Tracing.SampleRatio: "2" does not satisfy lte=1`,
+			})
+		})
+		Convey("CONFIG_FILEが存在しなければエラー", func() {
+			setenv("CONFIG_FILE", filepath.Join(dir, "missing.yaml"))
+			_, err := Load()
+			So(os.IsNotExist(err), ShouldBeTrue)
+		})
+		Convey("表示するときは秘密の値を伏せる", func() {
+			setenv("MYSQL_DB_NAME", "app")
+			setenv("MYSQL_MASTER_PASSWORD", "p@ssw0rd")
+
+			cfg, err := Load()
+			So(err, ShouldBeNil)
+			mysql := cfg.Redacted()["MySQL"].(map[string]interface{})
+			So(mysql["MasterPassword"], ShouldEqual, redacted)
+			So(mysql["MasterUsername"], ShouldEqual, "app")
+			So(cfg.String(), ShouldNotContainSubstring, "p@ssw0rd")
+			So(cfg.String(), ShouldContainSubstring, "PollInterval: 2s")
+		})
+	})
+}
@@ -0,0 +1,20 @@
+package logger
+
+import "github.com/genpsp/go-app/pkg/env"
+
+type Logger struct {
+	LogLevel string `validate:"oneof=debug info warn error"`
+	// LogEncoding "console" is for reading logs locally, "json" is what Cloud Logging parses.
+	LogEncoding string `validate:"oneof=json console"`
+}
+
+func NewConfig(env env.Env) Logger {
+	encoding := "console"
+	if env.Deployed() {
+		encoding = "json"
+	}
+	return Logger{
+		LogLevel:    env.String("LOG_LEVEL", "info"),
+		LogEncoding: env.String("LOG_ENCODING", encoding),
+	}
+}
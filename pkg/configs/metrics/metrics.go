@@ -0,0 +1,18 @@
+package metrics
+
+import (
+	"github.com/genpsp/go-app/pkg/env"
+)
+
+type Metrics struct {
+	Enabled bool
+	// Path is where the admin server serves the metrics.
+	Path string `validate:"startswith=/"`
+}
+
+func NewConfig(env env.Env) Metrics {
+	return Metrics{
+		Enabled: env.Bool("METRICS_ENABLED", true),
+		Path:    env.String("METRICS_PATH", "/metrics"),
+	}
+}
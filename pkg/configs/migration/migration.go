@@ -0,0 +1,26 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/genpsp/go-app/pkg/env"
+)
+
+type Migration struct {
+	// OnStartup applies pending migrations before the server starts. It is
+	// meant for local runs; deployed environments run "migrate up" once per
+	// release instead.
+	OnStartup bool
+	// LockTimeout is how long a run waits for another instance's run to finish.
+	LockTimeout time.Duration `validate:"gte=0"`
+	// Dir is where "migrate create" writes new migrations.
+	Dir string `validate:"required"`
+}
+
+func NewConfig(env env.Env) Migration {
+	return Migration{
+		OnStartup:   env.Bool("MIGRATE_ON_STARTUP", false),
+		LockTimeout: env.Seconds("MIGRATE_LOCK_TIMEOUT_SEC", time.Minute),
+		Dir:         env.String("MIGRATE_DIR", "database/migration/sqls"),
+	}
+}
@@ -2,40 +2,44 @@ package mysql
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/genpsp/go-app/pkg/env"
-	"github.com/genpsp/go-app/pkg/utils"
 )
 
 type MySql struct {
-	MasterUsername   string
-	MasterPassword   string
-	MasterHost       string
+	MasterUsername string `validate:"required"`
+	// MasterPassword may reference a secret, e.g. "sm://<project>/db-password",
+	// which is resolved at startup and refreshed for new connections.
+	MasterPassword string `secret:"true"`
+	// MasterHost is the DSN address, "unix(/cloudsql/<instance>)" when
+	// deployed and "tcp(<host:port>)" locally.
+	MasterHost       string `validate:"required"`
 	MasterInstanceID string
 
-	DBName       string
+	DBName       string `validate:"required"`
 	DebugMode    bool
-	MaxOpenConns int
-	MaxIdleConns int
+	MaxOpenConns int `validate:"gt=0"`
+	MaxIdleConns int `validate:"gte=0,ltefield=MaxOpenConns"`
 }
 
 func NewConfig(env env.Env) MySql {
+	instanceID := env.String("CLOUD_SQL_INSTANCE", "")
 	var masterHost string
-	switch env.ENV {
-	case "dev", "stg", "prd":
-		masterHost = fmt.Sprintf("unix(/cloudsql/%s)", os.Getenv("CLOUD_SQL_INSTANCE"))
-	default:
-		masterHost = fmt.Sprintf("tcp(%s)", os.Getenv("MYSQL_MASTER_HOST"))
+	if env.Deployed() {
+		if instanceID != "" {
+			masterHost = fmt.Sprintf("unix(/cloudsql/%s)", instanceID)
+		}
+	} else if host := env.String("MYSQL_MASTER_HOST", ""); host != "" {
+		masterHost = fmt.Sprintf("tcp(%s)", host)
 	}
 	return MySql{
-		MasterUsername:   env.MasterUsername,
-		MasterPassword:   env.MasterPassword,
+		MasterUsername:   env.String("MYSQL_MASTER_USERNAME", ""),
+		MasterPassword:   env.String("MYSQL_MASTER_PASSWORD", ""),
 		MasterHost:       masterHost,
-		MasterInstanceID: env.MasterInstanceID,
-		DBName:           env.DBName,
-		MaxOpenConns:     utils.ConvertInt(env.MaxOpenConns),
-		MaxIdleConns:     utils.ConvertInt(env.MaxIdleConns),
-		DebugMode:        utils.ConvertBool(env.DebugMode),
+		MasterInstanceID: instanceID,
+		DBName:           env.String("MYSQL_DB_NAME", ""),
+		MaxOpenConns:     env.Int("MYSQL_MAX_OPEN_CONNS", 10),
+		MaxIdleConns:     env.Int("MYSQL_MAX_IDLE_CONNS", 5),
+		DebugMode:        env.Bool("MYSQL_DEBUG_MODE", false),
 	}
 }
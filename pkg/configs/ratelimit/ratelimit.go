@@ -0,0 +1,93 @@
+package ratelimit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/genpsp/go-app/pkg/env"
+)
+
+const (
+	AlgorithmTokenBucket   = "token_bucket"
+	AlgorithmSlidingWindow = "sliding_window"
+
+	StoreMemory = "memory"
+	StoreMySQL  = "mysql"
+)
+
+type (
+	RateLimit struct {
+		Enabled bool
+		// Store is where counters are kept. The memory store is per process,
+		// multi-instance deployments should use the mysql store.
+		Store     string `validate:"oneof=memory mysql"`
+		Algorithm string `validate:"oneof=token_bucket sliding_window"`
+		// Default applies to every route and principal without an override below.
+		Default Limit
+		// BeforeAuth limits each API key or IP before authentication, so
+		// requests with invalid tokens are throttled before they reach Firebase.
+		BeforeAuth Limit
+		// Routes is keyed by method and route path, e.g. "POST /app/items".
+		Routes map[string]Limit `validate:"dive"`
+		// Principals is keyed by "user:<uid>", "ip:<address>" or "apikey:<hash>",
+		// the hash being the first 16 hex digits of the key's sha256.
+		// A principal override wins over the route limit.
+		Principals map[string]Limit `validate:"dive"`
+	}
+
+	Limit struct {
+		// Requests are allowed per Window.
+		Requests int           `validate:"gt=0"`
+		Window   time.Duration `validate:"gt=0"`
+		// Burst is the token bucket capacity. It defaults to Requests.
+		Burst int `validate:"gte=0"`
+	}
+)
+
+func NewConfig(env env.Env) RateLimit {
+	store := StoreMemory
+	if env.ENV == "stg" || env.ENV == "prd" {
+		store = StoreMySQL
+	}
+	return RateLimit{
+		Enabled:   env.Bool("RATE_LIMIT_ENABLED", env.Deployed()),
+		Store:     env.String("RATE_LIMIT_STORE", store),
+		Algorithm: env.String("RATE_LIMIT_ALGORITHM", AlgorithmTokenBucket),
+		Default: Limit{
+			Requests: env.Int("RATE_LIMIT_REQUESTS", 300),
+			Window:   env.Seconds("RATE_LIMIT_WINDOW_SEC", time.Minute),
+			Burst:    env.Int("RATE_LIMIT_BURST", 0),
+		},
+		BeforeAuth: Limit{
+			Requests: env.Int("RATE_LIMIT_BEFORE_AUTH_REQUESTS", 600),
+			Window:   env.Seconds("RATE_LIMIT_BEFORE_AUTH_WINDOW_SEC", time.Minute),
+			Burst:    env.Int("RATE_LIMIT_BEFORE_AUTH_BURST", 0),
+		},
+		Routes:     limits(env, "RATE_LIMIT_ROUTES"),
+		Principals: limits(env, "RATE_LIMIT_PRINCIPALS"),
+	}
+}
+
+// limits reads "<key>=<requests>/<window sec>[/<burst>]" pairs,
+// e.g. "POST /app/items=30/60,GET /app/items/search=60/60/120".
+func limits(env env.Env, key string) map[string]Limit {
+	limits := map[string]Limit{}
+	env.Pairs(key, func(k string, v string) error {
+		parts := strings.Split(v, "/")
+		if len(parts) < 2 || len(parts) > 3 {
+			return fmt.Errorf("want <requests>/<window sec>[/<burst>]")
+		}
+		var n [3]int
+		for i, p := range parts {
+			var err error
+			if n[i], err = strconv.Atoi(strings.TrimSpace(p)); err != nil {
+				return err
+			}
+		}
+		limits[k] = Limit{Requests: n[0], Window: time.Duration(n[1]) * time.Second, Burst: n[2]}
+		return nil
+	})
+	return limits
+}
@@ -0,0 +1,19 @@
+package secrets
+
+import (
+	"time"
+
+	"github.com/genpsp/go-app/pkg/env"
+)
+
+type Secrets struct {
+	// RefreshInterval is how often referenced secrets are resolved again, so
+	// a rotated database password reaches new connections. Zero disables it.
+	RefreshInterval time.Duration `validate:"gte=0"`
+}
+
+func NewConfig(env env.Env) Secrets {
+	return Secrets{
+		RefreshInterval: env.Seconds("SECRETS_REFRESH_INTERVAL_SEC", 5*time.Minute),
+	}
+}
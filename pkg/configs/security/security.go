@@ -0,0 +1,83 @@
+package security
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/genpsp/go-app/pkg/env"
+)
+
+type (
+	Security struct {
+		CORS    CORS
+		Headers Headers
+		// BodyLimit is the request body limit in bytes for routes not in RouteBodyLimits.
+		BodyLimit int64 `validate:"gt=0"`
+		// RouteBodyLimits is keyed by method and route path, e.g. "POST /app/items/import".
+		RouteBodyLimits map[string]int64 `validate:"dive,gt=0"`
+		// APIKeys are the comma separated keys accepted in X-API-Key. A caller
+		// sending one is rate limited per key instead of per IP.
+		APIKeys string `secret:"true"`
+	}
+
+	CORS struct {
+		// AllowOrigins empty disables CORS, so only same-origin browser requests work.
+		AllowOrigins     []string
+		AllowMethods     []string `validate:"required"`
+		AllowHeaders     []string
+		ExposeHeaders    []string
+		AllowCredentials bool
+		MaxAge           time.Duration `validate:"gte=0"`
+	}
+
+	Headers struct {
+		// HSTSMaxAge zero leaves Strict-Transport-Security out, as local runs plain HTTP.
+		HSTSMaxAge   time.Duration `validate:"gte=0"`
+		FrameOptions string        `validate:"oneof=DENY SAMEORIGIN"`
+		// ContentSecurityPolicy is sent with HTML responses only.
+		ContentSecurityPolicy string
+	}
+)
+
+func NewConfig(env env.Env) Security {
+	origins := []string{"*"}
+	hsts := time.Duration(0)
+	if env.Deployed() {
+		origins = nil
+		hsts = 365 * 24 * time.Hour
+	}
+
+	// "<method> <path>=<bytes>" over the defaults
+	routeBodyLimits := map[string]int64{
+		"POST /app/items/import":         32 << 20,
+		"POST /app/items/:itemId/images": 11 << 20,
+		"PUT /storage/*":                 11 << 20,
+	}
+	env.Pairs("HTTP_ROUTE_BODY_LIMITS", func(k string, v string) error {
+		l, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return err
+		}
+		routeBodyLimits[k] = l
+		return nil
+	})
+
+	return Security{
+		CORS: CORS{
+			AllowOrigins:     env.List("CORS_ALLOW_ORIGINS", origins),
+			AllowMethods:     env.List("CORS_ALLOW_METHODS", []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"}),
+			AllowHeaders:     env.List("CORS_ALLOW_HEADERS", []string{"Authorization", "Content-Type", "Idempotency-Key", "X-API-Key", "X-Request-ID", "traceparent"}),
+			ExposeHeaders:    env.List("CORS_EXPOSE_HEADERS", []string{"Content-Disposition", "Idempotent-Replayed", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After", "X-Request-ID"}),
+			AllowCredentials: env.Bool("CORS_ALLOW_CREDENTIALS", false),
+			MaxAge:           env.Seconds("CORS_MAX_AGE_SEC", 10*time.Minute),
+		},
+		Headers: Headers{
+			HSTSMaxAge:            env.Seconds("SECURITY_HSTS_MAX_AGE_SEC", hsts),
+			FrameOptions:          env.String("SECURITY_FRAME_OPTIONS", "DENY"),
+			ContentSecurityPolicy: env.String("SECURITY_CONTENT_SECURITY_POLICY", "default-src 'none'; frame-ancestors 'none'; base-uri 'none'"),
+		},
+		BodyLimit:       env.Int64("HTTP_BODY_LIMIT_BYTES", 1<<20),
+		RouteBodyLimits: routeBodyLimits,
+		APIKeys:         env.String("API_KEYS", ""),
+	}
+}
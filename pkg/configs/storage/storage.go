@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/genpsp/go-app/pkg/env"
+)
+
+const (
+	BackendGCS    = "gcs"
+	BackendLocal  = "local"
+	BackendMemory = "memory"
+)
+
+type Storage struct {
+	Backend         string `validate:"oneof=gcs local memory"`
+	LocalDir        string
+	LocalBaseURL    string
+	LocalSigningKey string        `secret:"true"`
+	SignedURLExpire time.Duration `validate:"gt=0"`
+}
+
+func NewConfig(env env.Env) Storage {
+	backend := BackendLocal
+	if env.Deployed() {
+		backend = BackendGCS
+	}
+	return Storage{
+		Backend:         env.String("STORAGE_BACKEND", backend),
+		LocalDir:        env.String("STORAGE_LOCAL_DIR", "./tmp/storage"),
+		LocalBaseURL:    env.String("STORAGE_LOCAL_BASE_URL", "/storage"),
+		LocalSigningKey: env.String("STORAGE_LOCAL_SIGNING_KEY", ""),
+		SignedURLExpire: env.Seconds("STORAGE_SIGNED_URL_EXPIRE_SEC", 15*time.Minute),
+	}
+}
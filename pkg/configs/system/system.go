@@ -0,0 +1,25 @@
+package system
+
+import (
+	"time"
+
+	"github.com/genpsp/go-app/pkg/env"
+)
+
+type System struct {
+	Env      string
+	HttpAddr string `validate:"required,numeric"`
+	// TimeZone is an IANA name such as "Asia/Tokyo".
+	TimeZone string `validate:"required,timezone"`
+	// HttpContextTimeout bounds the shutdown of the process.
+	HttpContextTimeout time.Duration `validate:"gt=0"`
+}
+
+func NewConfig(env env.Env) System {
+	return System{
+		Env:                env.ENV,
+		HttpAddr:           env.String("HTTP_ADDR", "8080"),
+		TimeZone:           env.String("TIME_ZONE", "Asia/Tokyo"),
+		HttpContextTimeout: env.Seconds("HTTP_CONTEXT_TIMEOUT_SEC", 30*time.Second),
+	}
+}
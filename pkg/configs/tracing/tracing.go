@@ -0,0 +1,40 @@
+package tracing
+
+import (
+	"strings"
+
+	"github.com/genpsp/go-app/pkg/env"
+)
+
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterNone   = "none"
+)
+
+type Tracing struct {
+	// Exporter is one of "otlp", "stdout" or "none". With "none" spans are
+	// still propagated but nothing is recorded.
+	Exporter    string `validate:"oneof=otlp stdout none"`
+	ServiceName string `validate:"required"`
+	// OTLPEndpoint is the host:port of the OTLP/HTTP collector.
+	OTLPEndpoint string `validate:"required"`
+	OTLPInsecure bool
+	// SampleRatio is the fraction of new traces recorded, between 0 and 1.
+	// A sampled parent is always followed.
+	SampleRatio float64 `validate:"gte=0,lte=1"`
+}
+
+func NewConfig(env env.Env) Tracing {
+	ratio := 1.0
+	if env.Deployed() {
+		ratio = 0.1
+	}
+	return Tracing{
+		Exporter:     strings.ToLower(env.String("TRACE_EXPORTER", ExporterNone)),
+		ServiceName:  env.String("TRACE_SERVICE_NAME", "go-app"),
+		OTLPEndpoint: env.String("TRACE_OTLP_ENDPOINT", "localhost:4318"),
+		OTLPInsecure: env.Bool("TRACE_OTLP_INSECURE", !env.Deployed()),
+		SampleRatio:  env.Float("TRACE_SAMPLE_RATIO", ratio),
+	}
+}
@@ -1,50 +1,111 @@
 package database
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	mysqlcfg "github.com/genpsp/go-app/pkg/configs/mysql"
 	"github.com/genpsp/go-app/pkg/logger"
+	"github.com/genpsp/go-app/pkg/metrics"
+	"github.com/genpsp/go-app/pkg/tracing"
+	gomysql "github.com/go-sql-driver/mysql"
+	"go.uber.org/zap"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
 	"time"
 )
 
+var log = logger.New("database")
+
+// NamingStrategy maps entities to the tables of the migrations, e.g. entities.Item to "item".
+var NamingStrategy = schema.NamingStrategy{SingularTable: true}
+
 type Database struct {
 	Master *gorm.DB
 }
 
-func (d *Database) Close() {
-	closedDB, _ := d.Master.DB()
+func (d *Database) Close() error {
+	closedDB, err := d.Master.DB()
+	if err != nil {
+		return err
+	}
 	if err := closedDB.Close(); err != nil {
-		logger.Logging.Error(fmt.Sprintf("masterDB connection close error. %v", err))
-	} else {
-		logger.Logging.Info("masterDB connection close success")
+		log.Error("masterDB connection close error", zap.Error(err))
+		return err
 	}
+	log.Info("masterDB connection close success")
+	return nil
+}
+
+// Ping checks that the master accepts connections.
+func (d *Database) Ping(ctx context.Context) error {
+	sqlDB, err := d.Master.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
 }
 
 func dataSource(userName string, password string, host string, dbName string) string {
 	return fmt.Sprintf("%s:%s@%s/%s?charset=utf8mb4&parseTime=True&loc=Local", userName, password, host, dbName)
 }
 
-func Open(cfg mysqlcfg.MySql) Database {
-	master, err := gorm.Open(mysql.Open(dataSource(cfg.MasterUsername, cfg.MasterPassword, cfg.MasterHost, cfg.DBName)), &gorm.Config{
-		NamingStrategy: schema.NamingStrategy{SingularTable: true},
+// connector dials with the password current at the time, so a rotated
+// password is used by new connections while open ones stay as they are.
+type connector struct {
+	cfg      *gomysql.Config
+	password func() string
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	cfg := c.cfg.Clone()
+	cfg.Passwd = c.password()
+	conn, err := gomysql.NewConnector(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return conn.Connect(ctx)
+}
+
+func (c *connector) Driver() driver.Driver {
+	return gomysql.MySQLDriver{}
+}
+
+// Open connects to the master. password is called for every new connection.
+func Open(cfg mysqlcfg.MySql, password func() string) Database {
+	dsn, err := gomysql.ParseDSN(dataSource(cfg.MasterUsername, "", cfg.MasterHost, cfg.DBName))
+	if err != nil {
+		log.Fatal("master data source invalid", zap.Error(err))
+	}
+	master, err := gorm.Open(mysql.New(mysql.Config{Conn: sql.OpenDB(&connector{cfg: dsn, password: password})}), &gorm.Config{
+		NamingStrategy: NamingStrategy,
 	})
 	if err != nil {
-		logger.Logging.Fatal(fmt.Sprintf("master connection failed. %v", err))
+		log.Fatal("master connection failed", zap.Error(err))
 	}
 	if cfg.DebugMode {
 		master = master.Debug()
 	}
 
+	if err := metrics.InstrumentGORM(master); err != nil {
+		log.Fatal("master instrumentation failed", zap.Error(err))
+	}
+	if err := tracing.InstrumentGORM(master); err != nil {
+		log.Fatal("master tracing failed", zap.Error(err))
+	}
+
 	dbConfig, _ := master.DB()
 
 	dbConfig.SetMaxOpenConns(cfg.MaxOpenConns)
 	dbConfig.SetMaxIdleConns(cfg.MaxIdleConns)
 	dbConfig.SetConnMaxLifetime(time.Hour)
+	if err := metrics.RegisterDBStats("master", dbConfig); err != nil {
+		log.Error("master pool metrics register failed", zap.Error(err))
+	}
 
-	logger.Logging.Info(fmt.Sprintf("master connection success. host: %s", cfg.MasterHost))
+	log.Info("master connection success", zap.String("host", cfg.MasterHost))
 
 	return Database{
 		Master: master,
@@ -1,15 +1,171 @@
 package env
 
 import (
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// Env reads settings by key, e.g. "MYSQL_DB_NAME". The typed getters return
+// the default when a key is unset and record an error when its value does
+// not parse, so every bad setting is reported at once by Err.
 type Env struct {
-	ENV string
+	// ENV names the environment: "dev", "stg" and "prd" are deployed,
+	// anything else runs locally.
+	ENV    string
+	lookup func(key string) (string, bool)
+	errs   *[]string
 }
 
+// NewEnv reads the process environment.
 func NewEnv() Env {
+	return New(os.Getenv("ENV"), os.LookupEnv)
+}
+
+// New reads settings of environment name through lookup.
+func New(name string, lookup func(key string) (string, bool)) Env {
 	return Env{
-		ENV: os.Getenv("ENV"),
+		ENV:    name,
+		lookup: lookup,
+		errs:   &[]string{},
+	}
+}
+
+// Deployed reports whether ENV is one of the deployed environments.
+func (e Env) Deployed() bool {
+	switch e.ENV {
+	case "dev", "stg", "prd":
+		return true
+	}
+	return false
+}
+
+// Lookup returns the value of key, if set and not blank.
+func (e Env) Lookup(key string) (string, bool) {
+	v, ok := e.lookup(key)
+	v = strings.TrimSpace(v)
+	return v, ok && v != ""
+}
+
+// Invalid records that the value of key is unusable.
+func (e Env) Invalid(key string, format string, args ...interface{}) {
+	*e.errs = append(*e.errs, fmt.Sprintf("%s: %s", key, fmt.Sprintf(format, args...)))
+}
+
+// Errs returns the problems recorded so far.
+func (e Env) Errs() []string {
+	return append([]string{}, *e.errs...)
+}
+
+func (e Env) String(key string, d string) string {
+	if v, ok := e.Lookup(key); ok {
+		return v
+	}
+	return d
+}
+
+func (e Env) Int(key string, d int) int {
+	v, ok := e.Lookup(key)
+	if !ok {
+		return d
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		e.Invalid(key, "%q is not an integer", v)
+		return d
+	}
+	return i
+}
+
+func (e Env) Int64(key string, d int64) int64 {
+	v, ok := e.Lookup(key)
+	if !ok {
+		return d
+	}
+	i, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		e.Invalid(key, "%q is not an integer", v)
+		return d
+	}
+	return i
+}
+
+func (e Env) Float(key string, d float64) float64 {
+	v, ok := e.Lookup(key)
+	if !ok {
+		return d
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		e.Invalid(key, "%q is not a number", v)
+		return d
+	}
+	return f
+}
+
+func (e Env) Bool(key string, d bool) bool {
+	v, ok := e.Lookup(key)
+	if !ok {
+		return d
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		e.Invalid(key, "%q is not a boolean", v)
+		return d
+	}
+	return b
+}
+
+// Seconds reads a whole number of seconds, the unit of every *_SEC key.
+func (e Env) Seconds(key string, d time.Duration) time.Duration {
+	v, ok := e.Lookup(key)
+	if !ok {
+		return d
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		e.Invalid(key, "%q is not a number of seconds", v)
+		return d
+	}
+	return time.Duration(i) * time.Second
+}
+
+// List reads a comma separated list. Blank entries are dropped.
+func (e Env) List(key string, d []string) []string {
+	v, ok := e.Lookup(key)
+	if !ok {
+		return d
+	}
+	var list []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+// Pairs reads a comma separated list of "<key>=<value>", e.g. route
+// overrides like "POST /app/items=30/60". parse stores each pair; entries
+// that are malformed or that parse rejects are recorded as invalid.
+func (e Env) Pairs(key string, parse func(k string, v string) error) {
+	v, ok := e.Lookup(key)
+	if !ok {
+		return
+	}
+	for _, entry := range strings.Split(v, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		i := strings.LastIndex(entry, "=")
+		if i <= 0 || strings.TrimSpace(entry[:i]) == "" {
+			e.Invalid(key, "%q is not <key>=<value>", strings.TrimSpace(entry))
+			continue
+		}
+		if err := parse(strings.TrimSpace(entry[:i]), strings.TrimSpace(entry[i+1:])); err != nil {
+			e.Invalid(key, "%q: %v", strings.TrimSpace(entry), err)
+		}
 	}
 }
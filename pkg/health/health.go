@@ -0,0 +1,132 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusOK       = "ok"
+	StatusFailing  = "failing"
+	StatusDraining = "draining"
+)
+
+type (
+	// Check returns nil when the dependency it checks is usable.
+	Check func(ctx context.Context) error
+
+	CheckResult struct {
+		Status    string  `json:"status"`
+		LatencyMs float64 `json:"latency_ms"`
+		Error     string  `json:"error,omitempty"`
+	}
+
+	Report struct {
+		Status string                 `json:"status"`
+		Checks map[string]CheckResult `json:"checks,omitempty"`
+	}
+
+	// Health runs the readiness checks of the process. Readiness fails from
+	// Drain on, whatever the checks say.
+	Health struct {
+		timeout  time.Duration
+		names    []string
+		checks   map[string]Check
+		draining int32
+	}
+)
+
+func New(timeout time.Duration) *Health {
+	return &Health{
+		timeout: timeout,
+		checks:  map[string]Check{},
+	}
+}
+
+// Register adds a readiness check. It is not safe to call once serving.
+func (h *Health) Register(name string, check Check) {
+	if _, ok := h.checks[name]; !ok {
+		h.names = append(h.names, name)
+	}
+	h.checks[name] = check
+}
+
+// Drain makes readiness fail from now on, so traffic moves away before shutdown.
+func (h *Health) Drain() {
+	atomic.StoreInt32(&h.draining, 1)
+}
+
+func (h *Health) Draining() bool {
+	return atomic.LoadInt32(&h.draining) == 1
+}
+
+// Live reports that the process is up. It checks no dependency: a database
+// outage must not get every instance restarted.
+func (h *Health) Live() Report {
+	return Report{Status: StatusOK}
+}
+
+// Ready runs every check concurrently, each bounded by the timeout.
+func (h *Health) Ready(ctx context.Context) (report Report, ready bool) {
+	results := make([]CheckResult, len(h.names))
+	var wg sync.WaitGroup
+	for i, name := range h.names {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = h.run(ctx, check)
+		}(i, h.checks[name])
+	}
+	wg.Wait()
+
+	report = Report{Status: StatusOK, Checks: map[string]CheckResult{}}
+	for i, name := range h.names {
+		report.Checks[name] = results[i]
+		if results[i].Status != StatusOK {
+			report.Status = StatusFailing
+		}
+	}
+	if h.Draining() {
+		report.Status = StatusDraining
+	}
+	return report, report.Status == StatusOK
+}
+
+func (h *Health) run(ctx context.Context, check Check) (result CheckResult) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+	start := time.Now()
+	err := check(ctx)
+	result = CheckResult{
+		Status:    StatusOK,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = StatusFailing
+		result.Error = err.Error()
+	}
+	return
+}
+
+// HTTPCheck checks that url answers without a server error.
+func HTTPCheck(client *http.Client, url string) Check {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+		if err != nil {
+			return err
+		}
+		res, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		res.Body.Close()
+		if res.StatusCode >= http.StatusInternalServerError {
+			return fmt.Errorf("%s responded %d", url, res.StatusCode)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,63 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_Ready(t *testing.T) {
+	Convey("依存先をチェックする", t, func() {
+		h := New(50 * time.Millisecond)
+		h.Register("mysql", func(ctx context.Context) error { return nil })
+
+		Convey("すべて成功すればready", func() {
+			report, ready := h.Ready(context.Background())
+			So(ready, ShouldBeTrue)
+			So(report.Status, ShouldEqual, StatusOK)
+			So(report.Checks["mysql"].Status, ShouldEqual, StatusOK)
+		})
+		Convey("失敗したチェックがあればfailing", func() {
+			h.Register("firebase", func(ctx context.Context) error { return errors.New("unreachable") })
+			report, ready := h.Ready(context.Background())
+			So(ready, ShouldBeFalse)
+			So(report.Status, ShouldEqual, StatusFailing)
+			So(report.Checks["mysql"].Status, ShouldEqual, StatusOK)
+			So(report.Checks["firebase"].Error, ShouldEqual, "unreachable")
+		})
+		Convey("タイムアウトしたチェックは失敗にする", func() {
+			h.Register("slow", func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			})
+			report, ready := h.Ready(context.Background())
+			So(ready, ShouldBeFalse)
+			So(report.Checks["slow"].Error, ShouldEqual, context.DeadlineExceeded.Error())
+		})
+		Convey("Drain以降はチェックが成功してもdraining", func() {
+			h.Drain()
+			report, ready := h.Ready(context.Background())
+			So(ready, ShouldBeFalse)
+			So(report.Status, ShouldEqual, StatusDraining)
+			So(h.Live().Status, ShouldEqual, StatusOK)
+		})
+	})
+}
+
+func Test_HTTPCheck(t *testing.T) {
+	Convey("URLの到達性をチェックする", t, func() {
+		status := http.StatusOK
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(status) }))
+		defer ts.Close()
+		check := HTTPCheck(ts.Client(), ts.URL)
+
+		So(check(context.Background()), ShouldBeNil)
+		status = http.StatusServiceUnavailable
+		So(check(context.Background()), ShouldNotBeNil)
+	})
+}
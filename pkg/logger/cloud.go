@@ -0,0 +1,115 @@
+package logger
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Special keys Cloud Logging lifts out of jsonPayload,
+// see https://cloud.google.com/logging/docs/structured-logging
+const (
+	keyTrace        = "logging.googleapis.com/trace"
+	keySpanID       = "logging.googleapis.com/spanId"
+	keyTraceSampled = "logging.googleapis.com/trace_sampled"
+	keyHTTPRequest  = "httpRequest"
+)
+
+// projectID qualifies trace IDs, Cloud Logging only links traces in the form
+// projects/<project>/traces/<trace id>.
+var projectID string
+
+var cloudEncoderConfig = zapcore.EncoderConfig{
+	TimeKey:        "time",
+	LevelKey:       "severity",
+	NameKey:        "logger",
+	CallerKey:      "caller",
+	MessageKey:     "message",
+	StacktraceKey:  "stack_trace",
+	LineEnding:     zapcore.DefaultLineEnding,
+	EncodeLevel:    encodeSeverity,
+	EncodeTime:     zapcore.RFC3339NanoTimeEncoder,
+	EncodeDuration: zapcore.StringDurationEncoder,
+	EncodeCaller:   zapcore.ShortCallerEncoder,
+	EncodeName:     zapcore.FullNameEncoder,
+}
+
+// HTTPRequest is the Cloud Logging HttpRequest entry.
+type HTTPRequest struct {
+	Method       string
+	URL          string
+	Status       int
+	RequestSize  int64
+	ResponseSize int64
+	UserAgent    string
+	RemoteIP     string
+	Referer      string
+	Protocol     string
+	Latency      time.Duration
+}
+
+func encodeSeverity(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	switch l {
+	case zapcore.DebugLevel:
+		enc.AppendString("DEBUG")
+	case zapcore.InfoLevel:
+		enc.AppendString("INFO")
+	case zapcore.WarnLevel:
+		enc.AppendString("WARNING")
+	case zapcore.ErrorLevel:
+		enc.AppendString("ERROR")
+	case zapcore.DPanicLevel:
+		enc.AppendString("CRITICAL")
+	case zapcore.PanicLevel:
+		enc.AppendString("ALERT")
+	case zapcore.FatalLevel:
+		enc.AppendString("EMERGENCY")
+	default:
+		enc.AppendString("DEFAULT")
+	}
+}
+
+// Trace returns the fields that attach a log line to its trace.
+func Trace(traceID string, spanID string, sampled bool) []zap.Field {
+	if traceID == "" {
+		return nil
+	}
+	trace := traceID
+	if projectID != "" {
+		trace = fmt.Sprintf("projects/%s/traces/%s", projectID, traceID)
+	}
+	fields := []zap.Field{zap.String(keyTrace, trace), zap.Bool(keyTraceSampled, sampled)}
+	if spanID != "" {
+		fields = append(fields, zap.String(keySpanID, spanID))
+	}
+	return fields
+}
+
+func HTTPRequestField(r HTTPRequest) zap.Field {
+	return zap.Object(keyHTTPRequest, r)
+}
+
+func (r HTTPRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("requestMethod", r.Method)
+	enc.AddString("requestUrl", r.URL)
+	enc.AddInt("status", r.Status)
+	// sizes are int64 strings in the LogEntry JSON
+	enc.AddString("requestSize", fmt.Sprintf("%d", r.RequestSize))
+	enc.AddString("responseSize", fmt.Sprintf("%d", r.ResponseSize))
+	if r.UserAgent != "" {
+		enc.AddString("userAgent", r.UserAgent)
+	}
+	if r.RemoteIP != "" {
+		enc.AddString("remoteIp", r.RemoteIP)
+	}
+	if r.Referer != "" {
+		enc.AddString("referer", r.Referer)
+	}
+	if r.Protocol != "" {
+		enc.AddString("protocol", r.Protocol)
+	}
+	enc.AddString("latency", fmt.Sprintf("%.9fs", r.Latency.Seconds()))
+	return nil
+}
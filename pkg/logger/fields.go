@@ -0,0 +1,31 @@
+package logger
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Field constructors for the keys shared across packages, so the same value
+// is always logged under the same key and type.
+
+func ItemID(id uint) zap.Field {
+	return zap.Uint("item_id", id)
+}
+
+func UID(uid string) zap.Field {
+	return zap.String("uid", uid)
+}
+
+func RequestID(id string) zap.Field {
+	return zap.String("request_id", id)
+}
+
+func Latency(d time.Duration) zap.Field {
+	return zap.Duration("latency", d)
+}
+
+// Body logs a request body. Sensitive keys in it are redacted.
+func Body(v interface{}) zap.Field {
+	return zap.Any("body", v)
+}
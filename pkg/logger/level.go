@@ -0,0 +1,133 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+var levels = &levelRegistry{
+	def:  zapcore.InfoLevel,
+	min:  zapcore.InfoLevel,
+	pkgs: map[string]zapcore.Level{},
+}
+
+type (
+	// LevelSnapshot is the default level and the package overrides, by level name.
+	LevelSnapshot struct {
+		Default  string
+		Packages map[string]string
+	}
+
+	levelRegistry struct {
+		mu   sync.RWMutex
+		def  zapcore.Level
+		min  zapcore.Level
+		pkgs map[string]zapcore.Level
+	}
+
+	// levelCore drops entries below the level of the logger's package.
+	levelCore struct {
+		zapcore.Core
+	}
+)
+
+// SetLevel sets the level of package pkg, or the default level when pkg is empty.
+func SetLevel(pkg string, level string) error {
+	lvl, err := parseLevel(level)
+	if err != nil {
+		return err
+	}
+	if pkg == "" {
+		levels.setDefault(lvl)
+		return nil
+	}
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+	levels.pkgs[pkg] = lvl
+	levels.updateMin()
+	return nil
+}
+
+// ResetLevel makes package pkg use the default level again.
+func ResetLevel(pkg string) {
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+	delete(levels.pkgs, pkg)
+	levels.updateMin()
+}
+
+func Levels() LevelSnapshot {
+	levels.mu.RLock()
+	defer levels.mu.RUnlock()
+	s := LevelSnapshot{Default: levels.def.String(), Packages: map[string]string{}}
+	for pkg, lvl := range levels.pkgs {
+		s.Packages[pkg] = lvl.String()
+	}
+	return s
+}
+
+func parseLevel(level string) (zapcore.Level, error) {
+	var lvl zapcore.Level
+	if err := lvl.UnmarshalText([]byte(strings.ToLower(level))); err != nil {
+		return lvl, fmt.Errorf("logger: unknown level %q", level)
+	}
+	return lvl, nil
+}
+
+func (r *levelRegistry) setDefault(lvl zapcore.Level) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.def = lvl
+	r.updateMin()
+}
+
+// updateMin must be called with mu held.
+func (r *levelRegistry) updateMin() {
+	r.min = r.def
+	for _, lvl := range r.pkgs {
+		if lvl < r.min {
+			r.min = lvl
+		}
+	}
+}
+
+// enabled looks the level up by the logger name, then its dotted parents.
+func (r *levelRegistry) enabled(name string, lvl zapcore.Level) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for name != "" {
+		if l, ok := r.pkgs[name]; ok {
+			return lvl >= l
+		}
+		i := strings.LastIndex(name, ".")
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return lvl >= r.def
+}
+
+func (r *levelRegistry) anyEnabled(lvl zapcore.Level) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return lvl >= r.min
+}
+
+func (c *levelCore) Enabled(lvl zapcore.Level) bool {
+	return levels.anyEnabled(lvl)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields)}
+}
+
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !levels.enabled(ent.LoggerName, ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"context"
+	"os"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Logging is the process logger. Prefer a package Logger from New, so the
+// package's level can be changed at runtime and request fields are added.
+var Logging = zap.NewNop()
+
+type (
+	// Logger logs for one package. Its zero value logs as the default package.
+	Logger struct {
+		name string
+	}
+)
+
+// LoadLogger builds Logging. The "console" encoding is for reading logs
+// locally, anything else writes the JSON Cloud Logging parses severity,
+// trace and httpRequest from. level is the default level of every package.
+func LoadLogger(env, level, encoding string) {
+	lvl, err := parseLevel(level)
+	if err != nil {
+		lvl = zapcore.InfoLevel
+	}
+	levels.setDefault(lvl)
+	projectID = os.Getenv("GOOGLE_CLOUD_PROJECT")
+
+	var enc zapcore.Encoder
+	if encoding == "console" {
+		enc = zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
+	} else {
+		enc = zapcore.NewJSONEncoder(cloudEncoderConfig)
+	}
+	core := zapcore.NewCore(enc, zapcore.Lock(os.Stdout), zapcore.DebugLevel)
+
+	opts := []zap.Option{zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel)}
+	if env != "dev" && env != "stg" && env != "prd" {
+		opts = append(opts, zap.Development())
+	}
+	Logging = zap.New(&levelCore{Core: &redactCore{Core: core}}, opts...)
+}
+
+// Sync flushes Logging before the process exits. Syncing stdout fails on
+// some platforms without anything being lost, so the error is dropped.
+func Sync() {
+	_ = Logging.Sync()
+}
+
+// New returns the Logger of package name. Dotted names nest, so a level set
+// for "services" also applies to "services.job".
+func New(name string) Logger {
+	return Logger{name: name}
+}
+
+// Ctx returns the package logger with the request fields in ctx.
+func (l Logger) Ctx(ctx context.Context) *zap.Logger {
+	return FromContext(ctx).Named(l.name)
+}
+
+func (l Logger) Debug(msg string, fields ...zap.Field) {
+	l.logger().Debug(msg, fields...)
+}
+
+func (l Logger) Info(msg string, fields ...zap.Field) {
+	l.logger().Info(msg, fields...)
+}
+
+func (l Logger) Warn(msg string, fields ...zap.Field) {
+	l.logger().Warn(msg, fields...)
+}
+
+func (l Logger) Error(msg string, fields ...zap.Field) {
+	l.logger().Error(msg, fields...)
+}
+
+func (l Logger) Fatal(msg string, fields ...zap.Field) {
+	l.logger().Fatal(msg, fields...)
+}
+
+func (l Logger) logger() *zap.Logger {
+	return Logging.Named(l.name).WithOptions(zap.AddCallerSkip(1))
+}
@@ -0,0 +1,107 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func observe() *observer.ObservedLogs {
+	core, logs := observer.New(zapcore.DebugLevel)
+	Logging = zap.New(&levelCore{Core: &redactCore{Core: core}})
+	levels.setDefault(zapcore.InfoLevel)
+	levels.pkgs = map[string]zapcore.Level{}
+	levels.updateMin()
+	return logs
+}
+
+func Test_Logger(t *testing.T) {
+	Convey("パッケージごとのレベルで出力する", t, func() {
+		logs := observe()
+		services := New("services")
+		job := New("services.job")
+		handler := New("handler")
+
+		Convey("既定のレベル未満は出力しない", func() {
+			services.Debug("debug")
+			services.Info("info")
+			So(logs.Len(), ShouldEqual, 1)
+			So(logs.All()[0].LoggerName, ShouldEqual, "services")
+		})
+		Convey("パッケージのレベルを実行中に変更できる", func() {
+			So(SetLevel("services", "debug"), ShouldBeNil)
+			job.Debug("job debug")
+			handler.Debug("handler debug")
+			So(logs.Len(), ShouldEqual, 1)
+			So(logs.All()[0].Message, ShouldEqual, "job debug")
+			So(Levels().Packages, ShouldResemble, map[string]string{"services": "debug"})
+
+			ResetLevel("services")
+			job.Debug("job debug")
+			So(logs.Len(), ShouldEqual, 1)
+		})
+		Convey("既定のレベルを変更できる", func() {
+			So(SetLevel("", "error"), ShouldBeNil)
+			handler.Warn("warn")
+			So(logs.Len(), ShouldEqual, 0)
+			So(Levels().Default, ShouldEqual, "error")
+		})
+		Convey("不明なレベルはエラーになる", func() {
+			So(SetLevel("services", "verbose"), ShouldNotBeNil)
+		})
+		Convey("contextのフィールドを付与する", func() {
+			ctx := NewContext(context.Background(), Logging.With(RequestID("r1")))
+			handler.Ctx(ctx).Error("failed", zap.Error(errors.New("boom")))
+			So(logs.All()[0].ContextMap()["request_id"], ShouldEqual, "r1")
+			So(logs.All()[0].ContextMap()["error"], ShouldEqual, "boom")
+		})
+	})
+}
+
+func Test_Redact(t *testing.T) {
+	Convey("機密情報をマスクする", t, func() {
+		logs := observe()
+		l := New("handler")
+
+		Convey("キー名で判定する", func() {
+			l.Info("login", zap.String("password", "p"), zap.String("refresh_token", "t"), UID("u1"))
+			fields := logs.All()[0].ContextMap()
+			So(fields["password"], ShouldEqual, redacted)
+			So(fields["refresh_token"], ShouldEqual, redacted)
+			So(fields["uid"], ShouldEqual, "u1")
+		})
+		Convey("構造体やmapの中も判定する", func() {
+			body := struct {
+				Name   string            `json:"name"`
+				Secret string            `json:"client_secret"`
+				Header map[string]string `json:"header"`
+			}{"a", "s", map[string]string{"Authorization": "Bearer x", "Accept": "*/*"}}
+			l.Info("request", Body(body))
+			So(logs.All()[0].ContextMap()["body"], ShouldResemble, map[string]interface{}{
+				"name":          "a",
+				"client_secret": redacted,
+				"header":        map[string]interface{}{"Authorization": redacted, "Accept": "*/*"},
+			})
+		})
+		Convey("Withで付与したフィールドもマスクする", func() {
+			Logging.With(zap.String("api_key", "k")).Info("with")
+			So(logs.All()[0].ContextMap()["api_key"], ShouldEqual, redacted)
+		})
+	})
+}
+
+func Test_Trace(t *testing.T) {
+	Convey("Cloud Loggingのトレースフィールドを作る", t, func() {
+		projectID = "p1"
+		defer func() { projectID = "" }()
+		So(Trace("", "", false), ShouldBeEmpty)
+		fields := Trace("t1", "s1", true)
+		So(fields, ShouldHaveLength, 3)
+		So(fields[0].String, ShouldEqual, "projects/p1/traces/t1")
+	})
+}
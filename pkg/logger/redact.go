@@ -0,0 +1,124 @@
+package logger
+
+import (
+	"encoding/json"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are matched as substrings of lower-cased field and map keys.
+var sensitiveKeys = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"authorization",
+	"api_key",
+	"apikey",
+	"cookie",
+	"credential",
+	"private_key",
+}
+
+// redactCore replaces the values of sensitive fields before they are encoded.
+// Structs and maps logged with zap.Any are redacted by key at any depth.
+type redactCore struct {
+	zapcore.Core
+}
+
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactCore{Core: c.Core.With(redactFields(fields))}
+}
+
+func (c *redactCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *redactCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, redactFields(fields))
+}
+
+func redactFields(fields []zapcore.Field) []zapcore.Field {
+	var out []zapcore.Field
+	for i, f := range fields {
+		r, changed := redactField(f)
+		if !changed {
+			continue
+		}
+		// copy on first change, the caller's slice may be reused
+		if out == nil {
+			out = append([]zapcore.Field{}, fields...)
+		}
+		out[i] = r
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+func redactField(f zapcore.Field) (zapcore.Field, bool) {
+	if isSensitive(f.Key) {
+		return zap.String(f.Key, redacted), true
+	}
+	if f.Type != zapcore.ReflectType || f.Interface == nil {
+		return f, false
+	}
+	// round trip through JSON, which is how the encoder would write it anyway
+	b, err := json.Marshal(f.Interface)
+	if err != nil {
+		return f, false
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return f, false
+	}
+	v, changed := redactValue(v)
+	if !changed {
+		return f, false
+	}
+	return zap.Any(f.Key, v), true
+}
+
+func redactValue(v interface{}) (interface{}, bool) {
+	changed := false
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, e := range t {
+			if isSensitive(k) {
+				t[k] = redacted
+				changed = true
+				continue
+			}
+			if r, ok := redactValue(e); ok {
+				t[k] = r
+				changed = true
+			}
+		}
+	case []interface{}:
+		for i, e := range t {
+			if r, ok := redactValue(e); ok {
+				t[i] = r
+				changed = true
+			}
+		}
+	}
+	return v, changed
+}
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"time"
+
+	"firebase.google.com/go/v4/auth"
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/pkg/firebase"
+)
+
+// authAdmin counts and times the calls of the AuthAdmin it wraps.
+type authAdmin struct {
+	firebase.AuthAdmin
+}
+
+func InstrumentAuthAdmin(a firebase.AuthAdmin) firebase.AuthAdmin {
+	return &authAdmin{AuthAdmin: a}
+}
+
+func (a *authAdmin) VerifyIDToken(idToken string) (token *auth.Token, err error) {
+	defer observeAuthCall("VerifyIDToken", time.Now(), &err)
+	return a.AuthAdmin.VerifyIDToken(idToken)
+}
+
+func (a *authAdmin) CreateUser(item *entities.Item, password string) (user *auth.UserRecord, err error) {
+	defer observeAuthCall("CreateUser", time.Now(), &err)
+	return a.AuthAdmin.CreateUser(item, password)
+}
+
+func (a *authAdmin) DeleteUser(uid string) (err error) {
+	defer observeAuthCall("DeleteUser", time.Now(), &err)
+	return a.AuthAdmin.DeleteUser(uid)
+}
+
+func (a *authAdmin) SetCustomClaims(uid string, claims map[string]interface{}) (err error) {
+	defer observeAuthCall("SetCustomClaims", time.Now(), &err)
+	return a.AuthAdmin.SetCustomClaims(uid, claims)
+}
+
+func observeAuthCall(method string, start time.Time, err *error) {
+	FirebaseAuthCallDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	result := "ok"
+	if *err != nil {
+		result = "error"
+	}
+	FirebaseAuthCalls.WithLabelValues(method, result).Inc()
+}
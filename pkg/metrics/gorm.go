@@ -0,0 +1,147 @@
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+const (
+	gormStartKey = "metrics:start"
+	// repositoryPackage prefixes the function names of repository methods.
+	repositoryPackage = "github.com/genpsp/go-app/domain/repository."
+	unknownMethod     = "other"
+)
+
+var closureSuffix = regexp.MustCompile(`(\.func\d+)+$`)
+
+// InstrumentGORM times every statement db runs. The method label is the
+// repository method on the call stack, e.g. "ItemRepositoryImpl.FindByID".
+func InstrumentGORM(db *gorm.DB) error {
+	cb := db.Callback()
+	type registration struct {
+		operation string
+		before    func(string, func(*gorm.DB)) error
+		after     func(string, func(*gorm.DB)) error
+	}
+	regs := []registration{
+		{"create", cb.Create().Before("gorm:create").Register, cb.Create().After("gorm:create").Register},
+		{"query", cb.Query().Before("gorm:query").Register, cb.Query().After("gorm:query").Register},
+		{"update", cb.Update().Before("gorm:update").Register, cb.Update().After("gorm:update").Register},
+		{"delete", cb.Delete().Before("gorm:delete").Register, cb.Delete().After("gorm:delete").Register},
+		{"row", cb.Row().Before("gorm:row").Register, cb.Row().After("gorm:row").Register},
+		{"raw", cb.Raw().Before("gorm:raw").Register, cb.Raw().After("gorm:raw").Register},
+	}
+	for _, r := range regs {
+		if err := r.before("metrics:before_"+r.operation, gormBefore); err != nil {
+			return err
+		}
+		if err := r.after("metrics:after_"+r.operation, gormAfter(r.operation)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func gormBefore(db *gorm.DB) {
+	db.InstanceSet(gormStartKey, time.Now())
+}
+
+func gormAfter(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		v, ok := db.InstanceGet(gormStartKey)
+		if !ok {
+			return
+		}
+		start := v.(time.Time)
+		labels := prometheus.Labels{
+			"method":    repositoryMethod(),
+			"operation": operation,
+			"table":     db.Statement.Table,
+		}
+		DBQueryDuration.With(labels).Observe(time.Since(start).Seconds())
+		if err := db.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			DBQueryErrors.With(labels).Inc()
+		}
+	}
+}
+
+// repositoryMethod finds the innermost repository method on the call stack.
+func repositoryMethod() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if strings.HasPrefix(frame.Function, repositoryPackage) {
+			name := strings.TrimPrefix(frame.Function, repositoryPackage)
+			name = closureSuffix.ReplaceAllString(name, "")
+			return strings.NewReplacer("(*", "", ")", "").Replace(name)
+		}
+		if !more {
+			return unknownMethod
+		}
+	}
+}
+
+// dbStatsCollector exports the pool statistics of a sql.DB.
+type dbStatsCollector struct {
+	db *sql.DB
+
+	maxOpen           *prometheus.Desc
+	open              *prometheus.Desc
+	inUse             *prometheus.Desc
+	idle              *prometheus.Desc
+	waitCount         *prometheus.Desc
+	waitDuration      *prometheus.Desc
+	maxIdleClosed     *prometheus.Desc
+	maxLifetimeClosed *prometheus.Desc
+}
+
+// RegisterDBStats exports the pool statistics of db labelled with name, e.g. "master".
+func RegisterDBStats(name string, db *sql.DB) error {
+	labels := prometheus.Labels{"db": name}
+	desc := func(metric, help string) *prometheus.Desc {
+		return prometheus.NewDesc("db_pool_"+metric, help, nil, labels)
+	}
+	return Registry.Register(&dbStatsCollector{
+		db:                db,
+		maxOpen:           desc("max_open_connections", "Maximum number of open connections."),
+		open:              desc("open_connections", "Established connections, in use and idle."),
+		inUse:             desc("in_use_connections", "Connections in use."),
+		idle:              desc("idle_connections", "Idle connections."),
+		waitCount:         desc("wait_count_total", "Connections waited for."),
+		waitDuration:      desc("wait_duration_seconds_total", "Time blocked waiting for a connection."),
+		maxIdleClosed:     desc("max_idle_closed_total", "Connections closed due to SetMaxIdleConns."),
+		maxLifetimeClosed: desc("max_lifetime_closed_total", "Connections closed due to SetConnMaxLifetime."),
+	})
+}
+
+func (c *dbStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.maxOpen
+	ch <- c.open
+	ch <- c.inUse
+	ch <- c.idle
+	ch <- c.waitCount
+	ch <- c.waitDuration
+	ch <- c.maxIdleClosed
+	ch <- c.maxLifetimeClosed
+}
+
+func (c *dbStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.db.Stats()
+	ch <- prometheus.MustNewConstMetric(c.maxOpen, prometheus.GaugeValue, float64(s.MaxOpenConnections))
+	ch <- prometheus.MustNewConstMetric(c.open, prometheus.GaugeValue, float64(s.OpenConnections))
+	ch <- prometheus.MustNewConstMetric(c.inUse, prometheus.GaugeValue, float64(s.InUse))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(s.Idle))
+	ch <- prometheus.MustNewConstMetric(c.waitCount, prometheus.CounterValue, float64(s.WaitCount))
+	ch <- prometheus.MustNewConstMetric(c.waitDuration, prometheus.CounterValue, s.WaitDuration.Seconds())
+	ch <- prometheus.MustNewConstMetric(c.maxIdleClosed, prometheus.CounterValue, float64(s.MaxIdleClosed))
+	ch <- prometheus.MustNewConstMetric(c.maxLifetimeClosed, prometheus.CounterValue, float64(s.MaxLifetimeClosed))
+}
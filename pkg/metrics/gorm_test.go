@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	repositories "github.com/genpsp/go-app/domain/repository"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	. "github.com/smartystreets/goconvey/convey"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+func Test_InstrumentGORM(t *testing.T) {
+	Convey("GORMのクエリをリポジトリのメソッドごとに計測する", t, func() {
+		sqlDB, mock, _ := sqlmock.New()
+		defer sqlDB.Close()
+		db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{
+			NamingStrategy: schema.NamingStrategy{SingularTable: true},
+		})
+		So(err, ShouldBeNil)
+		So(InstrumentGORM(db), ShouldBeNil)
+
+		mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "a"))
+		_, err = repositories.NewTagRepository().FindAll(db)
+		So(err, ShouldBeNil)
+		So(testutil.CollectAndCount(DBQueryDuration.MustCurryWith(map[string]string{
+			"method":    "TagRepositoryImpl.FindAll",
+			"operation": "query",
+			"table":     "tag",
+		})), ShouldEqual, 1)
+
+		Convey("リポジトリ以外からのクエリはotherにする", func() {
+			mock.ExpectExec("DELETE").WillReturnError(gorm.ErrInvalidData)
+			db.Exec("DELETE FROM tag")
+			So(testutil.ToFloat64(DBQueryErrors.WithLabelValues(unknownMethod, "raw", "")), ShouldEqual, 1)
+		})
+	})
+}
+
+func Test_RegisterDBStats(t *testing.T) {
+	Convey("コネクションプールの状態を出力する", t, func() {
+		sqlDB, _, _ := sqlmock.New()
+		defer sqlDB.Close()
+		sqlDB.SetMaxOpenConns(7)
+		So(RegisterDBStats("test", sqlDB), ShouldBeNil)
+
+		count, err := testutil.GatherAndCount(Registry, "db_pool_max_open_connections")
+		So(err, ShouldBeNil)
+		So(count, ShouldEqual, 1)
+	})
+}
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry holds every metric of the process. It is not the prometheus
+// default registry, so libraries can not add metrics behind our back.
+var Registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests by route and status code.",
+	}, []string{"method", "route", "code"})
+
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency by route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	HTTPRequestsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "http_requests_in_flight",
+		Help: "HTTP requests being served.",
+	})
+
+	DBQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Database statement latency by repository method.",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"method", "operation", "table"})
+
+	DBQueryErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_query_errors_total",
+		Help: "Failed database statements by repository method. Record not found is not an error.",
+	}, []string{"method", "operation", "table"})
+
+	FirebaseAuthCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "firebase_auth_calls_total",
+		Help: "Firebase Auth admin calls by method and result.",
+	}, []string{"method", "result"})
+
+	FirebaseAuthCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "firebase_auth_call_duration_seconds",
+		Help:    "Firebase Auth admin call latency by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+)
+
+func init() {
+	Registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPRequestDuration,
+		HTTPRequestsInFlight,
+		DBQueryDuration,
+		DBQueryErrors,
+		FirebaseAuthCalls,
+		FirebaseAuthCallDuration,
+	)
+}
+
+// Handler serves Registry in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
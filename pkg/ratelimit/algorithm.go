@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+// tokenBucket refills Requests tokens per Window up to the burst capacity and
+// spends one per request. A is the token count and Stamp the last refill.
+func tokenBucket(s *State, found bool, limit Limit, now time.Time) Result {
+	capacity := float64(limit.capacity())
+	rate := float64(limit.Requests) / float64(limit.Window)
+
+	tokens := capacity
+	if found {
+		elapsed := now.Sub(s.Stamp)
+		if elapsed < 0 {
+			elapsed = 0
+		}
+		tokens = math.Min(capacity, s.A+float64(elapsed)*rate)
+	}
+
+	res := Result{Limit: limit.capacity()}
+	if tokens >= 1 {
+		tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = ceilDuration((1 - tokens) / rate)
+	}
+	res.Remaining = int(math.Floor(tokens))
+	res.Reset = ceilDuration((capacity - tokens) / rate)
+
+	s.A = tokens
+	s.B = 0
+	s.Stamp = now
+	return res
+}
+
+// slidingWindow counts requests in fixed windows and weights the previous
+// window by how much of it still overlaps the sliding window ending now.
+// A is the current window count, B the previous one and Stamp the current window start.
+func slidingWindow(s *State, found bool, limit Limit, now time.Time) Result {
+	start := now.Truncate(limit.Window)
+	if !found || !s.Stamp.Equal(start) {
+		prev := 0.0
+		if found && s.Stamp.Equal(start.Add(-limit.Window)) {
+			prev = s.A
+		}
+		s.A, s.B, s.Stamp = 0, prev, start
+	}
+
+	requests := float64(limit.Requests)
+	elapsed := float64(now.Sub(start)) / float64(limit.Window)
+	estimate := s.B*(1-elapsed) + s.A
+
+	res := Result{Limit: limit.Requests, Reset: start.Add(limit.Window).Sub(now)}
+	if estimate+1 <= requests {
+		s.A++
+		estimate++
+		res.Allowed = true
+	} else if s.A+1 > requests || s.B == 0 {
+		// the current window alone is full, wait for the next one
+		res.RetryAfter = res.Reset
+	} else {
+		// wait until enough of the previous window has slid out
+		needed := 1 - (requests-1-s.A)/s.B
+		res.RetryAfter = start.Add(time.Duration(needed * float64(limit.Window))).Sub(now)
+	}
+	res.Remaining = int(math.Max(0, math.Floor(requests-estimate)))
+	return res
+}
+
+func ceilDuration(ns float64) time.Duration {
+	return time.Duration(math.Ceil(ns))
+}
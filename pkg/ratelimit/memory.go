@@ -0,0 +1,54 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepEvery is how many updates the memory store takes between expiry sweeps.
+const sweepEvery = 1024
+
+// memoryStore keeps state in process memory, so limits are per instance.
+type memoryStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+	updates int
+	now     func() time.Time
+}
+
+type memoryEntry struct {
+	state     State
+	expiresAt time.Time
+}
+
+func NewMemoryStore() Store {
+	return &memoryStore{
+		entries: map[string]memoryEntry{},
+		now:     time.Now,
+	}
+}
+
+func (s *memoryStore) Update(ctx context.Context, key string, ttl time.Duration, fn func(s *State, found bool)) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.updates++
+	if s.updates%sweepEvery == 0 {
+		for k, e := range s.entries {
+			if !now.Before(e.expiresAt) {
+				delete(s.entries, k)
+			}
+		}
+	}
+
+	e, found := s.entries[key]
+	if found && !now.Before(e.expiresAt) {
+		e, found = memoryEntry{}, false
+	}
+	fn(&e.state, found)
+	e.expiresAt = now.Add(ttl)
+	s.entries[key] = e
+	return nil
+}
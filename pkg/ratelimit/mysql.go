@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// mysqlStore shares state between instances through the rate_limit table.
+// Each update locks the key's row for the length of a short transaction.
+type mysqlStore struct {
+	db      *gorm.DB
+	updates int64
+	now     func() time.Time
+}
+
+type rateLimitRow struct {
+	Bucket    string `gorm:"primaryKey"`
+	A         float64
+	B         float64
+	Stamp     time.Time
+	ExpiresAt time.Time
+}
+
+func (rateLimitRow) TableName() string {
+	return "rate_limit"
+}
+
+func NewMySQLStore(db *gorm.DB) Store {
+	return &mysqlStore{
+		db:  db,
+		now: time.Now,
+	}
+}
+
+func (s *mysqlStore) Update(ctx context.Context, key string, ttl time.Duration, fn func(s *State, found bool)) (err error) {
+	now := s.now()
+	if atomic.AddInt64(&s.updates, 1)%sweepEvery == 0 {
+		if err := s.db.WithContext(ctx).Where("expires_at <= ?", now).Delete(&rateLimitRow{}).Error; err != nil {
+			return err
+		}
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// insert first so the locking read always finds a row instead of taking a gap lock
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rateLimitRow{Bucket: key, Stamp: now, ExpiresAt: now}).Error; err != nil {
+			return err
+		}
+		row := rateLimitRow{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("bucket = ?", key).Take(&row).Error; err != nil {
+			return err
+		}
+
+		state := State{}
+		found := now.Before(row.ExpiresAt)
+		if found {
+			state = State{A: row.A, B: row.B, Stamp: row.Stamp}
+		}
+		fn(&state, found)
+
+		return tx.Model(&rateLimitRow{}).Where("bucket = ?", key).Updates(map[string]interface{}{
+			"a":          state.A,
+			"b":          state.B,
+			"stamp":      state.Stamp,
+			"expires_at": now.Add(ttl),
+		}).Error
+	})
+}
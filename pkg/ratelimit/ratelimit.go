@@ -0,0 +1,107 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ratelimitcfg "github.com/genpsp/go-app/pkg/configs/ratelimit"
+	"gorm.io/gorm"
+)
+
+type (
+	// Limiter decides whether one more request under key fits in limit.
+	Limiter interface {
+		Allow(ctx context.Context, key string, limit Limit) (res Result, err error)
+	}
+
+	Limit struct {
+		Requests int
+		Window   time.Duration
+		// Burst is the token bucket capacity. Zero means Requests.
+		Burst int
+	}
+
+	Result struct {
+		Allowed   bool
+		Limit     int
+		Remaining int
+		// Reset is how long until the quota is fully available again.
+		Reset time.Duration
+		// RetryAfter is how long a rejected request should wait. It is zero when allowed.
+		RetryAfter time.Duration
+	}
+
+	// State is the per key counter an algorithm keeps in a Store.
+	// The meaning of A and B depends on the algorithm.
+	State struct {
+		A     float64
+		B     float64
+		Stamp time.Time
+	}
+
+	// Store keeps limiter state. Update must run fn atomically for key, across
+	// every process sharing the store, and save the state fn leaves behind.
+	// found is false when the key has no state or it has expired.
+	Store interface {
+		Update(ctx context.Context, key string, ttl time.Duration, fn func(s *State, found bool)) (err error)
+	}
+
+	// algorithm applies one request at now to s and reports the outcome.
+	algorithm func(s *State, found bool, limit Limit, now time.Time) Result
+
+	limiter struct {
+		store     Store
+		algorithm algorithm
+		now       func() time.Time
+	}
+)
+
+func New(cfg ratelimitcfg.RateLimit, db *gorm.DB) (Limiter, error) {
+	var store Store
+	switch cfg.Store {
+	case ratelimitcfg.StoreMemory:
+		store = NewMemoryStore()
+	case ratelimitcfg.StoreMySQL:
+		store = NewMySQLStore(db)
+	default:
+		return nil, fmt.Errorf("ratelimit: unknown store %q", cfg.Store)
+	}
+	return NewLimiter(cfg.Algorithm, store)
+}
+
+func NewLimiter(name string, store Store) (Limiter, error) {
+	var alg algorithm
+	switch name {
+	case ratelimitcfg.AlgorithmTokenBucket:
+		alg = tokenBucket
+	case ratelimitcfg.AlgorithmSlidingWindow:
+		alg = slidingWindow
+	default:
+		return nil, fmt.Errorf("ratelimit: unknown algorithm %q", name)
+	}
+	return &limiter{
+		store:     store,
+		algorithm: alg,
+		now:       time.Now,
+	}, nil
+}
+
+func (l *limiter) Allow(ctx context.Context, key string, limit Limit) (res Result, err error) {
+	if limit.Requests <= 0 || limit.Window <= 0 {
+		return Result{Allowed: true}, nil
+	}
+	now := l.now()
+	// state is kept for two windows so the sliding window still sees the previous one
+	err = l.store.Update(ctx, key, 2*limit.Window, func(s *State, found bool) {
+		res = l.algorithm(s, found, limit, now)
+	})
+	return res, err
+}
+
+func (l Limit) capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Requests
+}
@@ -0,0 +1,109 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ratelimitcfg "github.com/genpsp/go-app/pkg/configs/ratelimit"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func newTestLimiter(algorithm string, now *time.Time) Limiter {
+	store := NewMemoryStore().(*memoryStore)
+	store.now = func() time.Time { return *now }
+	l, _ := NewLimiter(algorithm, store)
+	l.(*limiter).now = func() time.Time { return *now }
+	return l
+}
+
+func Test_TokenBucket(t *testing.T) {
+	Convey("トークンバケットで制限する", t, func() {
+		ctx := context.Background()
+		now := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+		l := newTestLimiter(ratelimitcfg.AlgorithmTokenBucket, &now)
+		limit := Limit{Requests: 2, Window: time.Second, Burst: 3}
+
+		Convey("バースト分まで許可し、超えると拒否する", func() {
+			for i := 2; i >= 0; i-- {
+				res, err := l.Allow(ctx, "k", limit)
+				So(err, ShouldBeNil)
+				So(res.Allowed, ShouldBeTrue)
+				So(res.Remaining, ShouldEqual, i)
+				So(res.Limit, ShouldEqual, 3)
+			}
+			res, _ := l.Allow(ctx, "k", limit)
+			So(res.Allowed, ShouldBeFalse)
+			So(res.RetryAfter, ShouldEqual, 500*time.Millisecond)
+			So(res.Reset, ShouldEqual, 1500*time.Millisecond)
+		})
+		Convey("時間の経過でトークンが補充される", func() {
+			for i := 0; i < 3; i++ {
+				_, _ = l.Allow(ctx, "k", limit)
+			}
+			now = now.Add(500 * time.Millisecond)
+			res, _ := l.Allow(ctx, "k", limit)
+			So(res.Allowed, ShouldBeTrue)
+			So(res.Remaining, ShouldEqual, 0)
+		})
+		Convey("キーごとに独立して数える", func() {
+			for i := 0; i < 3; i++ {
+				_, _ = l.Allow(ctx, "a", limit)
+			}
+			res, _ := l.Allow(ctx, "b", limit)
+			So(res.Allowed, ShouldBeTrue)
+		})
+	})
+}
+
+func Test_SlidingWindow(t *testing.T) {
+	Convey("スライディングウィンドウで制限する", t, func() {
+		ctx := context.Background()
+		now := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+		l := newTestLimiter(ratelimitcfg.AlgorithmSlidingWindow, &now)
+		limit := Limit{Requests: 4, Window: time.Minute}
+
+		Convey("ウィンドウ内の上限を超えると次のウィンドウまで拒否する", func() {
+			for i := 3; i >= 0; i-- {
+				res, _ := l.Allow(ctx, "k", limit)
+				So(res.Allowed, ShouldBeTrue)
+				So(res.Remaining, ShouldEqual, i)
+			}
+			now = now.Add(15 * time.Second)
+			res, _ := l.Allow(ctx, "k", limit)
+			So(res.Allowed, ShouldBeFalse)
+			So(res.RetryAfter, ShouldEqual, 45*time.Second)
+			So(res.Reset, ShouldEqual, 45*time.Second)
+		})
+		Convey("前のウィンドウは重なっている割合で数える", func() {
+			for i := 0; i < 4; i++ {
+				_, _ = l.Allow(ctx, "k", limit)
+			}
+			// 3 of the previous window's 4 requests still count
+			now = now.Add(75 * time.Second)
+			res, _ := l.Allow(ctx, "k", limit)
+			So(res.Allowed, ShouldBeTrue)
+			So(res.Remaining, ShouldEqual, 0)
+
+			res, _ = l.Allow(ctx, "k", limit)
+			So(res.Allowed, ShouldBeFalse)
+			So(res.RetryAfter, ShouldEqual, 15*time.Second)
+		})
+		Convey("2ウィンドウ以上空くとリセットされる", func() {
+			for i := 0; i < 4; i++ {
+				_, _ = l.Allow(ctx, "k", limit)
+			}
+			now = now.Add(2 * time.Minute)
+			res, _ := l.Allow(ctx, "k", limit)
+			So(res.Allowed, ShouldBeTrue)
+			So(res.Remaining, ShouldEqual, 3)
+		})
+	})
+}
+
+func Test_NewLimiter(t *testing.T) {
+	Convey("未知のアルゴリズムはエラーになる", t, func() {
+		_, err := NewLimiter("leaky_bucket", NewMemoryStore())
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,94 @@
+package requestid
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	HeaderRequestID         = "X-Request-ID"
+	HeaderTraceparent       = "traceparent"
+	HeaderCloudTraceContext = "X-Cloud-Trace-Context"
+)
+
+var (
+	validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:\-]{1,128}$`)
+	// traceparent is version-trace id-parent id-flags, see https://www.w3.org/TR/trace-context/
+	traceparent = regexp.MustCompile(`^([0-9a-f]{2})-([0-9a-f]{32})-([0-9a-f]{16})-([0-9a-f]{2})$`)
+	// X-Cloud-Trace-Context is TRACE_ID/SPAN_ID;o=OPTIONS with a decimal span id
+	cloudTraceContext = regexp.MustCompile(`^([0-9a-fA-F]{32})(?:/([0-9]+))?(?:;o=([01]))?$`)
+)
+
+type (
+	// Correlation identifies a request across our logs, error bodies and the
+	// caller's tracing system. TraceID and SpanID are empty when the caller sent no trace.
+	Correlation struct {
+		RequestID string
+		TraceID   string
+		SpanID    string
+		Sampled   bool
+	}
+
+	contextKey struct{}
+)
+
+// FromRequest keeps a well formed X-Request-ID or generates one, and reads the
+// trace from traceparent, falling back to X-Cloud-Trace-Context.
+func FromRequest(r *http.Request) Correlation {
+	c := Correlation{RequestID: r.Header.Get(HeaderRequestID)}
+	if !validRequestID.MatchString(c.RequestID) {
+		c.RequestID = uuid.New().String()
+	}
+	if !c.parseTraceparent(r.Header.Get(HeaderTraceparent)) {
+		c.parseCloudTraceContext(r.Header.Get(HeaderCloudTraceContext))
+	}
+	return c
+}
+
+func (c *Correlation) parseTraceparent(v string) bool {
+	m := traceparent.FindStringSubmatch(strings.TrimSpace(v))
+	if m == nil || m[1] == "ff" || isZero(m[2]) || isZero(m[3]) {
+		return false
+	}
+	flags, _ := hex.DecodeString(m[4])
+	c.TraceID, c.SpanID, c.Sampled = m[2], m[3], flags[0]&1 == 1
+	return true
+}
+
+func (c *Correlation) parseCloudTraceContext(v string) bool {
+	m := cloudTraceContext.FindStringSubmatch(strings.TrimSpace(v))
+	if m == nil || isZero(m[1]) {
+		return false
+	}
+	c.TraceID, c.Sampled = strings.ToLower(m[1]), m[3] == "1"
+	if span, err := strconv.ParseUint(m[2], 10, 64); err == nil && span > 0 {
+		c.SpanID = fmt.Sprintf("%016x", span)
+	}
+	return true
+}
+
+func isZero(id string) bool {
+	return strings.Trim(id, "0") == ""
+}
+
+func NewContext(ctx context.Context, c Correlation) context.Context {
+	return context.WithValue(ctx, contextKey{}, c)
+}
+
+func FromContext(ctx context.Context) (Correlation, bool) {
+	c, ok := ctx.Value(contextKey{}).(Correlation)
+	return c, ok
+}
+
+// ID returns the request ID in ctx, or "" outside a request.
+func ID(ctx context.Context) string {
+	c, _ := FromContext(ctx)
+	return c.RequestID
+}
@@ -0,0 +1,53 @@
+package requestid
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_FromRequest(t *testing.T) {
+	Convey("リクエストからCorrelationを取得する", t, func() {
+		req := httptest.NewRequest("GET", "/", nil)
+
+		Convey("X-Request-IDがあればそのまま使う", func() {
+			req.Header.Set(HeaderRequestID, "client-id.1")
+			So(FromRequest(req).RequestID, ShouldEqual, "client-id.1")
+		})
+		Convey("X-Request-IDがない、または不正なら生成する", func() {
+			So(FromRequest(req).RequestID, ShouldHaveLength, 36)
+			req.Header.Set(HeaderRequestID, "bad id\n")
+			So(FromRequest(req).RequestID, ShouldNotEqual, "bad id\n")
+		})
+		Convey("traceparentを解析する", func() {
+			req.Header.Set(HeaderTraceparent, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+			c := FromRequest(req)
+			So(c.TraceID, ShouldEqual, "4bf92f3577b34da6a3ce929d0e0e4736")
+			So(c.SpanID, ShouldEqual, "00f067aa0ba902b7")
+			So(c.Sampled, ShouldBeTrue)
+		})
+		Convey("traceparentが不正ならX-Cloud-Trace-Contextを使う", func() {
+			req.Header.Set(HeaderTraceparent, "00-00000000000000000000000000000000-00f067aa0ba902b7-01")
+			req.Header.Set(HeaderCloudTraceContext, "105445AA7843BC8BF206B12000100000/255;o=1")
+			c := FromRequest(req)
+			So(c.TraceID, ShouldEqual, "105445aa7843bc8bf206b12000100000")
+			So(c.SpanID, ShouldEqual, "00000000000000ff")
+			So(c.Sampled, ShouldBeTrue)
+		})
+		Convey("トレースがなければ空にする", func() {
+			c := FromRequest(req)
+			So(c.TraceID, ShouldBeEmpty)
+			So(c.SpanID, ShouldBeEmpty)
+		})
+	})
+}
+
+func Test_Context(t *testing.T) {
+	Convey("contextにCorrelationを保持する", t, func() {
+		ctx := NewContext(context.Background(), Correlation{RequestID: "r1"})
+		So(ID(ctx), ShouldEqual, "r1")
+		So(ID(context.Background()), ShouldBeEmpty)
+	})
+}
@@ -0,0 +1,94 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	secretmanagerpb "google.golang.org/genproto/googleapis/cloud/secretmanager/v1"
+)
+
+// fileBackend reads "file:///run/secrets/db-password", as mounted by
+// Docker or Kubernetes. A trailing newline is dropped.
+type fileBackend struct{}
+
+func (fileBackend) Resolve(ctx context.Context, ref *url.URL) (string, error) {
+	if ref.Path == "" {
+		return "", errors.New("want file:///<path>")
+	}
+	b, err := ioutil.ReadFile(ref.Path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
+
+// envBackend reads "env://DB_PASSWORD", e.g. to keep one config file for
+// environments that inject the secret differently.
+type envBackend struct{}
+
+func (envBackend) Resolve(ctx context.Context, ref *url.URL) (string, error) {
+	v, ok := os.LookupEnv(ref.Host)
+	if !ok {
+		return "", fmt.Errorf("%s is not set", ref.Host)
+	}
+	return v, nil
+}
+
+// secretManagerBackend reads "sm://<project>/<secret>[/<version>]", the
+// version being "latest" when left out. The client is created on first use,
+// so no credentials are needed unless a reference uses Secret Manager.
+type secretManagerBackend struct {
+	mu     sync.Mutex
+	client *secretmanager.Client
+}
+
+func (b *secretManagerBackend) Resolve(ctx context.Context, ref *url.URL) (string, error) {
+	parts := strings.Split(strings.Trim(ref.Path, "/"), "/")
+	if ref.Host == "" || parts[0] == "" || len(parts) > 2 {
+		return "", errors.New("want sm://<project>/<secret>[/<version>]")
+	}
+	version := "latest"
+	if len(parts) == 2 {
+		version = parts[1]
+	}
+	client, err := b.getClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	res, err := client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{
+		Name: fmt.Sprintf("projects/%s/secrets/%s/versions/%s", ref.Host, parts[0], version),
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(res.Payload.Data), nil
+}
+
+func (b *secretManagerBackend) getClient(ctx context.Context) (*secretmanager.Client, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.client == nil {
+		client, err := secretmanager.NewClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		b.client = client
+	}
+	return b.client, nil
+}
+
+func (b *secretManagerBackend) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.client == nil {
+		return nil
+	}
+	return b.client.Close()
+}
@@ -0,0 +1,91 @@
+package secrets
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Refresher refreshes secrets at an interval, e.g. a database password
+// rotated in Secret Manager.
+type Refresher struct {
+	interval time.Duration
+	secrets  map[string]*Secret
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewRefresher refreshes secrets, keyed by a name for the logs, every interval.
+// Secrets that are no reference are left out.
+func NewRefresher(interval time.Duration, secrets map[string]*Secret) *Refresher {
+	r := &Refresher{
+		interval: interval,
+		secrets:  map[string]*Secret{},
+		stop:     make(chan struct{}),
+	}
+	for name, s := range secrets {
+		if s.Ref() != "" {
+			r.secrets[name] = s
+		}
+	}
+	return r
+}
+
+// Start does nothing when the interval is zero or there is nothing to refresh.
+func (r *Refresher) Start() error {
+	if r.interval <= 0 || len(r.secrets) == 0 {
+		return nil
+	}
+	r.wg.Add(1)
+	go r.loop()
+	return nil
+}
+
+func (r *Refresher) Stop(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+	r.stopOnce.Do(func() { close(r.stop) })
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *Refresher) loop() {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			r.Refresh()
+		}
+	}
+}
+
+// Refresh refreshes every secret once. A secret that fails to resolve keeps its value.
+func (r *Refresher) Refresh() {
+	for name, s := range r.secrets {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		changed, err := s.Refresh(ctx)
+		cancel()
+		if err != nil {
+			log.Error("secret refresh failed", zap.String("secret", name), zap.Error(err))
+			continue
+		}
+		if changed {
+			// never the value itself
+			log.Info("secret rotated", zap.String("secret", name), zap.String("ref", s.Ref()))
+		}
+	}
+}
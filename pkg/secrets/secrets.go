@@ -0,0 +1,161 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/genpsp/go-app/pkg/logger"
+)
+
+var log = logger.New("secrets")
+
+type (
+	// Backend resolves the references of one scheme.
+	Backend interface {
+		Resolve(ctx context.Context, ref *url.URL) (string, error)
+	}
+
+	// Resolver resolves config values that reference a secret, e.g.
+	// "sm://my-project/db-password/3" or "file:///run/secrets/db-password",
+	// through the backend registered for the scheme. Values with any other
+	// scheme or none are used as they are.
+	Resolver struct {
+		backends map[string]Backend
+	}
+
+	// Secret is the value of a reference, kept current by Refresh.
+	Secret struct {
+		ref      *url.URL
+		resolver *Resolver
+		mu       sync.RWMutex
+		value    string
+	}
+)
+
+// NewResolver returns a resolver with the "file", "env" and "sm" (Secret
+// Manager) backends.
+func NewResolver() *Resolver {
+	r := &Resolver{backends: map[string]Backend{}}
+	r.Register("file", fileBackend{})
+	r.Register("env", envBackend{})
+	r.Register("sm", &secretManagerBackend{})
+	return r
+}
+
+// Register makes b resolve the references of scheme, replacing any backend before it.
+func (r *Resolver) Register(scheme string, b Backend) {
+	r.backends[scheme] = b
+}
+
+// Close releases the clients of the backends.
+func (r *Resolver) Close() error {
+	for _, b := range r.backends {
+		if c, ok := b.(interface{ Close() error }); ok {
+			if err := c.Close(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Secret resolves value. A value that is no reference is returned as a
+// Secret that never changes.
+func (r *Resolver) Secret(ctx context.Context, value string) (*Secret, error) {
+	s := &Secret{resolver: r, value: value}
+	i := strings.Index(value, "://")
+	if i <= 0 {
+		return s, nil
+	}
+	if _, ok := r.backends[value[:i]]; !ok {
+		return s, nil
+	}
+	ref, err := url.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("secret reference: %w", err)
+	}
+	s.ref = ref
+	if _, err := s.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Resolve resolves value once.
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	s, err := r.Secret(ctx, value)
+	if err != nil {
+		return "", err
+	}
+	return s.Value(), nil
+}
+
+// ResolveStruct replaces the value of every string field of the struct v
+// points to that is tagged `secret:"true"`, in nested structs too, and
+// returns the secrets by field path, e.g. "MySQL.MasterPassword".
+func (r *Resolver) ResolveStruct(ctx context.Context, v interface{}) (map[string]*Secret, error) {
+	secrets := map[string]*Secret{}
+	if err := r.resolveStruct(ctx, reflect.ValueOf(v).Elem(), "", secrets); err != nil {
+		return nil, err
+	}
+	return secrets, nil
+}
+
+func (r *Resolver) resolveStruct(ctx context.Context, v reflect.Value, prefix string, secrets map[string]*Secret) error {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		path := prefix + f.Name
+		switch {
+		case f.Type.Kind() == reflect.Struct:
+			if err := r.resolveStruct(ctx, v.Field(i), path+".", secrets); err != nil {
+				return err
+			}
+		case f.Type.Kind() == reflect.String && f.Tag.Get("secret") == "true":
+			s, err := r.Secret(ctx, v.Field(i).String())
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			v.Field(i).SetString(s.Value())
+			secrets[path] = s
+		}
+	}
+	return nil
+}
+
+func (s *Secret) Value() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.value
+}
+
+// Refresh resolves the reference again and reports whether the value changed.
+// On error the previous value is kept.
+func (s *Secret) Refresh(ctx context.Context) (changed bool, err error) {
+	if s.ref == nil {
+		return false, nil
+	}
+	value, err := s.resolver.backends[s.ref.Scheme].Resolve(ctx, s.ref)
+	if err != nil {
+		return false, fmt.Errorf("resolve %s: %w", s.Ref(), err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	changed = value != s.value
+	s.value = value
+	return changed, nil
+}
+
+// Ref returns the reference the secret is resolved from, empty for a plain value.
+func (s *Secret) Ref() string {
+	if s.ref == nil {
+		return ""
+	}
+	return s.ref.String()
+}
@@ -0,0 +1,105 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type fakeBackend struct {
+	values map[string]string
+	err    error
+}
+
+func (b *fakeBackend) Resolve(ctx context.Context, ref *url.URL) (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+	return b.values[ref.Host], nil
+}
+
+func Test_Resolver(t *testing.T) {
+	Convey("参照を解決する", t, func() {
+		ctx := context.Background()
+		r := NewResolver()
+
+		Convey("file://はファイルの内容を末尾の改行を除いて返す", func() {
+			path := filepath.Join(t.TempDir(), "db-password")
+			So(ioutil.WriteFile(path, []byte("p@ss\n"), 0o600), ShouldBeNil)
+			v, err := r.Resolve(ctx, "file://"+path)
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, "p@ss")
+		})
+		Convey("env://は環境変数の値を返す", func() {
+			os.Setenv("SECRETS_TEST_PASSWORD", "from-env")
+			Reset(func() { os.Unsetenv("SECRETS_TEST_PASSWORD") })
+			v, err := r.Resolve(ctx, "env://SECRETS_TEST_PASSWORD")
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, "from-env")
+
+			_, err = r.Resolve(ctx, "env://SECRETS_TEST_MISSING")
+			So(err, ShouldNotBeNil)
+		})
+		Convey("参照でない値はそのまま返す", func() {
+			for _, value := range []string{"", "p@ss", "https://example.com"} {
+				v, err := r.Resolve(ctx, value)
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, value)
+			}
+		})
+		Convey("形式が誤ったsm://はエラー", func() {
+			_, err := r.Resolve(ctx, "sm://my-project")
+			So(err, ShouldNotBeNil)
+		})
+		Convey("secretタグのフィールドを入れ子の構造体まで解決する", func() {
+			r.Register("fake", &fakeBackend{values: map[string]string{"db": "resolved"}})
+			cfg := struct {
+				MySQL struct {
+					MasterUsername string
+					MasterPassword string `secret:"true"`
+				}
+				Token string `secret:"true"`
+			}{Token: "plain"}
+			cfg.MySQL.MasterUsername = "fake://db"
+			cfg.MySQL.MasterPassword = "fake://db"
+
+			resolved, err := r.ResolveStruct(ctx, &cfg)
+			So(err, ShouldBeNil)
+			So(cfg.MySQL.MasterPassword, ShouldEqual, "resolved")
+			So(cfg.MySQL.MasterUsername, ShouldEqual, "fake://db")
+			So(cfg.Token, ShouldEqual, "plain")
+			So(resolved["MySQL.MasterPassword"].Ref(), ShouldEqual, "fake://db")
+			So(resolved["Token"].Ref(), ShouldEqual, "")
+		})
+	})
+}
+
+func Test_Refresh(t *testing.T) {
+	Convey("ローテーションされた値に更新する", t, func() {
+		ctx := context.Background()
+		backend := &fakeBackend{values: map[string]string{"db": "v1"}}
+		r := NewResolver()
+		r.Register("fake", backend)
+		s, err := r.Secret(ctx, "fake://db")
+		So(err, ShouldBeNil)
+		So(s.Value(), ShouldEqual, "v1")
+
+		backend.values["db"] = "v2"
+		changed, err := s.Refresh(ctx)
+		So(err, ShouldBeNil)
+		So(changed, ShouldBeTrue)
+		So(s.Value(), ShouldEqual, "v2")
+
+		Convey("解決に失敗したら前の値を保つ", func() {
+			backend.err = errors.New("unavailable")
+			NewRefresher(0, map[string]*Secret{"db": s}).Refresh()
+			So(s.Value(), ShouldEqual, "v2")
+		})
+	})
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/genpsp/go-app/pkg/configs/accesslog"
+	"github.com/genpsp/go-app/pkg/logger"
+	"github.com/genpsp/go-app/pkg/requestid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// accessLogger is its own package so access logs can be turned down on their own.
+var accessLogger = logger.New("server.access")
+
+// accessLog writes one line per request with a Cloud Logging httpRequest.
+// Successful requests are sampled by route; 4xx, 5xx and requests whose trace
+// is sampled are always logged. It renders handler errors itself so the
+// logged status is the one sent.
+func accessLog(cfg accesslog.AccessLog) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !cfg.Enabled {
+				return next(c)
+			}
+			start := time.Now()
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+			latency := time.Since(start)
+
+			req := c.Request()
+			res := c.Response()
+			route := req.Method + " " + c.Path()
+			if res.Status < http.StatusBadRequest && !traceSampled(req) {
+				rate, ok := cfg.RouteSampleRates[route]
+				if !ok {
+					rate = cfg.SampleRate
+				}
+				if rand.Float64() >= rate {
+					return nil
+				}
+			}
+
+			requestSize := req.ContentLength
+			if requestSize < 0 {
+				requestSize = 0
+			}
+			fields := []zap.Field{
+				logger.HTTPRequestField(logger.HTTPRequest{
+					Method:       req.Method,
+					URL:          req.URL.RequestURI(),
+					Status:       res.Status,
+					RequestSize:  requestSize,
+					ResponseSize: res.Size,
+					UserAgent:    req.UserAgent(),
+					RemoteIP:     c.RealIP(),
+					Referer:      req.Referer(),
+					Protocol:     req.Proto,
+					Latency:      latency,
+				}),
+				zap.String("route", route),
+				logger.Latency(latency),
+			}
+
+			// the request context carries request_id, trace and, after auth, uid.
+			// a stack trace of this middleware says nothing about a 5xx
+			l := accessLogger.Ctx(req.Context()).WithOptions(zap.AddStacktrace(zapcore.FatalLevel))
+			switch {
+			case res.Status >= http.StatusInternalServerError:
+				l.Error("access log", fields...)
+			case res.Status >= http.StatusBadRequest:
+				l.Warn("access log", fields...)
+			default:
+				l.Info("access log", fields...)
+			}
+			return nil
+		}
+	}
+}
+
+func traceSampled(req *http.Request) bool {
+	corr, ok := requestid.FromContext(req.Context())
+	return ok && corr.Sampled
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/genpsp/go-app/pkg/configs/accesslog"
+	"github.com/genpsp/go-app/pkg/logger"
+	"github.com/genpsp/go-app/pkg/requestid"
+	"github.com/labstack/echo/v4"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func Test_AccessLog(t *testing.T) {
+	Convey("アクセスログを出力する", t, func() {
+		core, logs := observer.New(zapcore.DebugLevel)
+		logger.Logging = zap.New(core)
+		defer func() { logger.Logging = zap.NewNop() }()
+
+		e := echo.New()
+		e.Pre(requestID())
+		e.Use(accessLog(accesslog.AccessLog{
+			Enabled:          true,
+			SampleRate:       1,
+			RouteSampleRates: map[string]float64{"GET /search": 0},
+		}))
+		e.GET("/items/:id", func(c echo.Context) error { return c.String(http.StatusOK, "ok") })
+		e.GET("/search", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+		e.GET("/fail", func(c echo.Context) error { return echo.NewHTTPError(http.StatusInternalServerError) })
+
+		do := func(path string, header map[string]string) {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			for k, v := range header {
+				req.Header.Set(k, v)
+			}
+			e.ServeHTTP(httptest.NewRecorder(), req)
+		}
+
+		Convey("リクエストごとに1行出力する", func() {
+			do("/items/1", map[string]string{requestid.HeaderRequestID: "r1"})
+			So(logs.Len(), ShouldEqual, 1)
+			entry := logs.All()[0]
+			So(entry.Level, ShouldEqual, zapcore.InfoLevel)
+			fields := entry.ContextMap()
+			So(fields["route"], ShouldEqual, "GET /items/:id")
+			So(fields["request_id"], ShouldEqual, "r1")
+			So(fields["httpRequest"], ShouldResemble, map[string]interface{}{
+				"requestMethod": "GET",
+				"requestUrl":    "/items/1",
+				"status":        200,
+				"requestSize":   "0",
+				"responseSize":  "2",
+				"remoteIp":      "192.0.2.1",
+				"protocol":      "HTTP/1.1",
+				"latency":       fields["httpRequest"].(map[string]interface{})["latency"],
+			})
+		})
+		Convey("サンプリング対象外のルートは出力しない", func() {
+			do("/search", nil)
+			So(logs.Len(), ShouldEqual, 0)
+		})
+		Convey("トレースがサンプリングされていれば出力する", func() {
+			do("/search", map[string]string{requestid.HeaderTraceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"})
+			So(logs.Len(), ShouldEqual, 1)
+		})
+		Convey("5xxは必ずERRORで出力する", func() {
+			do("/fail", nil)
+			So(logs.Len(), ShouldEqual, 1)
+			So(logs.All()[0].Level, ShouldEqual, zapcore.ErrorLevel)
+			So(logs.All()[0].ContextMap()["httpRequest"].(map[string]interface{})["status"], ShouldEqual, 500)
+		})
+	})
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/genpsp/go-app/pkg/configs"
+	"github.com/genpsp/go-app/pkg/metrics"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+// AdminServer serves operational endpoints such as /metrics on their own
+// port, which is kept off the public load balancer. /metrics is only mounted
+// when the metrics are enabled.
+type AdminServer struct {
+	server *http.Server
+	mux    *http.ServeMux
+	// Handler, when set, registers the admin routes served besides /metrics.
+	Handler func(server *echo.Echo)
+}
+
+func NewAdminServer() AdminServer {
+	config := configs.GetConfig()
+	mux := http.NewServeMux()
+	if config.Metrics.Enabled {
+		mux.Handle(config.Metrics.Path, metrics.Handler())
+	}
+
+	return AdminServer{
+		server: &http.Server{
+			Addr:    fmt.Sprintf(":%s", config.Admin.Addr),
+			Handler: mux,
+		},
+		mux: mux,
+	}
+}
+
+// Start binds the listener, so a port in use fails here, and serves in the background.
+func (srv *AdminServer) Start() error {
+	if srv.Handler != nil {
+		e := echo.New()
+		e.Validator = &CustomValidator{validator: validator.New()}
+		e.Pre(requestID())
+		e.Use(accessLog(configs.GetConfig().AccessLog))
+		e.HTTPErrorHandler = withRequestID(appErr.JSONErrorHandler)
+		srv.Handler(e)
+		srv.mux.Handle("/", e)
+	}
+
+	ln, err := net.Listen("tcp", srv.server.Addr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		log.Info("start admin server", zap.String("addr", srv.server.Addr))
+		if err := srv.server.Serve(ln); err != nil && err != http.ErrServerClosed {
+			log.Error("admin serve error", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+func (srv *AdminServer) Stop(ctx context.Context) error {
+	return srv.server.Shutdown(ctx)
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/genpsp/go-app/pkg/configs"
+	"github.com/labstack/echo/v4"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_AdminServer(t *testing.T) {
+	Convey("メトリクスと管理用のルートを提供する", t, func() {
+		configs.TestLoadConfig()
+		srv := NewAdminServer()
+		srv.server.Addr = "127.0.0.1:0"
+		srv.Handler = func(e *echo.Echo) {
+			e.GET("/admin-test", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+		}
+		So(srv.Start(), ShouldBeNil)
+		defer srv.Stop(context.Background())
+
+		rec := httptest.NewRecorder()
+		srv.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin-test", nil))
+		So(rec.Code, ShouldEqual, http.StatusOK)
+
+		rec = httptest.NewRecorder()
+		srv.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, configs.GetConfig().Metrics.Path, nil))
+		So(rec.Code, ShouldEqual, http.StatusOK)
+	})
+}
+
+func Test_AdminServerWithoutMetrics(t *testing.T) {
+	Convey("メトリクスが無効な場合は/metricsを提供しない", t, func() {
+		configs.TestLoadConfig()
+		cfg := configs.GetConfig()
+		enabled := cfg.Metrics.Enabled
+		cfg.Metrics.Enabled = false
+		defer func() { cfg.Metrics.Enabled = enabled }()
+
+		srv := NewAdminServer()
+		rec := httptest.NewRecorder()
+		srv.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, cfg.Metrics.Path, nil))
+		So(rec.Code, ShouldEqual, http.StatusNotFound)
+	})
+}
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/genpsp/go-app/pkg/health"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	livenessPath  = "/healthz"
+	readinessPath = "/readyz"
+
+	// echo v4.2 has no constant for it
+	headerCacheControl = "Cache-Control"
+)
+
+// liveness answers 200 while the process can serve at all.
+func liveness(h *health.Health) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		c.Response().Header().Set(headerCacheControl, "no-store")
+		return c.JSON(http.StatusOK, h.Live())
+	}
+}
+
+// readiness answers 503 while a dependency check fails or the server drains,
+// with the status and latency of every check.
+func readiness(h *health.Health) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		report, ready := h.Ready(c.Request().Context())
+		status := http.StatusOK
+		if !ready {
+			status = http.StatusServiceUnavailable
+		}
+		c.Response().Header().Set(headerCacheControl, "no-store")
+		return c.JSON(status, report)
+	}
+}
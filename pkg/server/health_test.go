@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/genpsp/go-app/pkg/health"
+	"github.com/labstack/echo/v4"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_Health(t *testing.T) {
+	Convey("ヘルスチェックのエンドポイント", t, func() {
+		var dbErr error
+		h := health.New(time.Second)
+		h.Register("mysql", func(ctx context.Context) error { return dbErr })
+
+		e := echo.New()
+		e.GET(livenessPath, liveness(h))
+		e.GET(readinessPath, readiness(h))
+		do := func(path string) *httptest.ResponseRecorder {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+			return rec
+		}
+
+		Convey("依存先が正常なら200", func() {
+			So(do(readinessPath).Code, ShouldEqual, http.StatusOK)
+			So(do(livenessPath).Code, ShouldEqual, http.StatusOK)
+		})
+		Convey("MySQLに接続できなければreadinessだけ503", func() {
+			dbErr = errors.New("connection refused")
+			rec := do(readinessPath)
+			So(rec.Code, ShouldEqual, http.StatusServiceUnavailable)
+			So(rec.Body.String(), ShouldContainSubstring, `"mysql":{"status":"failing"`)
+			So(do(livenessPath).Code, ShouldEqual, http.StatusOK)
+		})
+		Convey("ドレイン中はreadinessが503", func() {
+			h.Drain()
+			So(do(readinessPath).Code, ShouldEqual, http.StatusServiceUnavailable)
+		})
+	})
+}
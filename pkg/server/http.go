@@ -3,24 +3,28 @@ package server
 import (
 	"context"
 	"fmt"
-	"github.com/labstack/echo/v4/middleware"
-	"os"
+	"net"
+	"net/http"
 	"time"
 
 	appErr "github.com/genpsp/go-app/pkg/server/error"
 
-	"github.com/genpsp/go-app/pkg/channel"
 	"github.com/genpsp/go-app/pkg/configs"
+	"github.com/genpsp/go-app/pkg/health"
 	"github.com/genpsp/go-app/pkg/logger"
 	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
 	"gopkg.in/go-playground/validator.v9"
 )
 
+var log = logger.New("server")
+
 type HttpServer struct {
 	echo    *echo.Echo
 	Handler func(server *echo.Echo)
-	Addr    string
-	Timeout time.Duration
+	// Health, when set, is served on /healthz and /readyz outside every route group.
+	Health *health.Health
+	Addr   string
 }
 
 type Context struct {
@@ -41,33 +45,51 @@ func NewHttpServer() HttpServer {
 	e.Server.Addr = fmt.Sprintf(":%s", config.System.HttpAddr)
 	e.HideBanner = true
 	e.Validator = &CustomValidator{validator: validator.New()}
-	e.Use(middleware.CORS())
-	e.HTTPErrorHandler = appErr.JSONErrorHandler
+	e.Pre(traceRequests())
+	e.Pre(requestID())
+	if config.Metrics.Enabled {
+		e.Use(requestMetrics())
+	}
+	e.Use(accessLog(config.AccessLog))
+	if cors := corsMiddleware(config.Security.CORS); cors != nil {
+		e.Use(cors)
+	}
+	e.Use(secureHeaders(config.Security.Headers))
+	e.Use(bodyLimit(config.Security.BodyLimit, config.Security.RouteBodyLimits))
+	e.HTTPErrorHandler = withRequestID(appErr.JSONErrorHandler)
 	loc, _ := time.LoadLocation(config.System.TimeZone)
-	logger.Logging.Info(fmt.Sprintf("current timezone: %s", loc))
+	log.Info("current timezone", zap.Stringer("timezone", loc))
 
 	return HttpServer{
-		echo:    e,
-		Addr:    fmt.Sprintf(":%s", config.System.HttpAddr),
-		Timeout: config.System.HttpContextTimeoutSec,
+		echo: e,
+		Addr: fmt.Sprintf(":%s", config.System.HttpAddr),
 	}
 }
 
-func (srv *HttpServer) Start() {
+// Start binds the listener, so a port in use fails here, and serves in the background.
+func (srv *HttpServer) Start() error {
+	if srv.Health != nil {
+		srv.echo.GET(livenessPath, liveness(srv.Health))
+		srv.echo.GET(readinessPath, readiness(srv.Health))
+	}
 	srv.Handler(srv.echo)
+
+	ln, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		return err
+	}
+	srv.echo.Listener = ln
 	go func() {
-		logger.Logging.Info(fmt.Sprintf("start http server addr: %s", srv.Addr))
-		if err := srv.echo.StartServer(srv.echo.Server); err != nil {
-			logger.Logging.Error(fmt.Sprintf("http serve error: %s", err.Error()))
+		log.Info("start http server", zap.String("addr", srv.Addr))
+		if err := srv.echo.StartServer(srv.echo.Server); err != nil && err != http.ErrServerClosed {
+			log.Error("http serve error", zap.Error(err))
 		}
 	}()
+	return nil
 }
 
-func (srv *HttpServer) Stop(signal os.Signal) {
-	ctx, cancel := context.WithTimeout(context.Background(), srv.Timeout*time.Second)
-	defer cancel()
-	if err := srv.echo.Shutdown(ctx); err != nil {
-		srv.echo.Logger.Fatal(err)
-	}
-	logger.Logging.Info(fmt.Sprintf("stopping http server... ExitCode: %d, Signal: %s", channel.GetExitCode(signal), signal.String()))
+// Stop stops accepting connections and waits for the requests in flight until ctx is done.
+func (srv *HttpServer) Stop(ctx context.Context) error {
+	log.Info("stopping http server...")
+	return srv.echo.Shutdown(ctx)
 }
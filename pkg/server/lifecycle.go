@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type (
+	// Component is a part of the process with its own start and stop, e.g.
+	// the HTTP server or the job runner. Either hook may be nil.
+	Component struct {
+		Name string
+		// Start returns once the component runs; an error aborts startup.
+		// Long running work belongs in goroutines the component stops in Stop.
+		Start func() error
+		// Stop returns once the component finished or gave up its work and
+		// released its resources, at the latest when ctx is done.
+		Stop func(ctx context.Context) error
+	}
+
+	// Lifecycle starts components in the order they were added, so each one
+	// comes after what it depends on, and stops them in reverse.
+	Lifecycle struct {
+		Timeout    time.Duration
+		components []Component
+		started    int
+	}
+)
+
+func NewLifecycle(timeout time.Duration) *Lifecycle {
+	return &Lifecycle{Timeout: timeout}
+}
+
+// Add registers c after the components it depends on. It must be called before Start.
+func (l *Lifecycle) Add(c Component) {
+	l.components = append(l.components, c)
+}
+
+// Start starts every component. When one fails, those already started are
+// stopped again and the error is returned.
+func (l *Lifecycle) Start() error {
+	for _, c := range l.components[l.started:] {
+		if c.Start != nil {
+			log.Info("starting component", zap.String("component", c.Name))
+			if err := c.Start(); err != nil {
+				l.Stop()
+				return fmt.Errorf("start %s: %w", c.Name, err)
+			}
+		}
+		l.started++
+	}
+	return nil
+}
+
+// Stop stops the started components in reverse order, all within Timeout.
+// A component failing to stop does not keep the others from stopping.
+func (l *Lifecycle) Stop() (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), l.Timeout)
+	defer cancel()
+	for ; l.started > 0; l.started-- {
+		c := l.components[l.started-1]
+		if c.Stop == nil {
+			continue
+		}
+		start := time.Now()
+		if stopErr := c.Stop(ctx); stopErr != nil {
+			log.Error("component stop error", zap.String("component", c.Name), zap.Error(stopErr))
+			if err == nil {
+				err = fmt.Errorf("stop %s: %w", c.Name, stopErr)
+			}
+			continue
+		}
+		log.Info("stopped component", zap.String("component", c.Name), zap.Duration("elapsed", time.Since(start)))
+	}
+	return err
+}
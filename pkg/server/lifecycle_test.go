@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_Lifecycle(t *testing.T) {
+	Convey("コンポーネントを順に起動し逆順に停止する", t, func() {
+		var calls []string
+		component := func(name string, startErr error, stopErr error) Component {
+			return Component{
+				Name: name,
+				Start: func() error {
+					calls = append(calls, "start "+name)
+					return startErr
+				},
+				Stop: func(ctx context.Context) error {
+					_, ok := ctx.Deadline()
+					So(ok, ShouldBeTrue)
+					calls = append(calls, "stop "+name)
+					return stopErr
+				},
+			}
+		}
+		l := NewLifecycle(time.Second)
+		l.Add(component("db", nil, nil))
+		l.Add(Component{Name: "logger"})
+
+		Convey("起動した順の逆に停止する", func() {
+			l.Add(component("http", nil, nil))
+			So(l.Start(), ShouldBeNil)
+			So(l.Stop(), ShouldBeNil)
+			So(calls, ShouldResemble, []string{"start db", "start http", "stop http", "stop db"})
+		})
+		Convey("起動に失敗したら起動済みのものを停止してエラーを返す", func() {
+			l.Add(component("http", errors.New("address already in use"), nil))
+			l.Add(component("worker", nil, nil))
+			err := l.Start()
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, "start http: address already in use")
+			So(calls, ShouldResemble, []string{"start db", "start http", "stop db"})
+		})
+		Convey("停止に失敗しても残りを停止する", func() {
+			l.Add(component("http", nil, errors.New("timeout")))
+			So(l.Start(), ShouldBeNil)
+			So(l.Stop(), ShouldNotBeNil)
+			So(calls, ShouldResemble, []string{"start db", "start http", "stop http", "stop db"})
+		})
+	})
+}
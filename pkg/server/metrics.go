@@ -0,0 +1,55 @@
+package server
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/genpsp/go-app/pkg/metrics"
+	"github.com/labstack/echo/v4"
+)
+
+// unmatchedRoute labels requests no route matched, so unknown paths do not
+// each get their own series.
+const unmatchedRoute = "unmatched"
+
+// routeOf returns a func giving the route pattern a request matched, or
+// unmatchedRoute. echo reports the request path as the route when none
+// matched, so the path is only used when it is a registered route.
+func routeOf() func(c echo.Context) string {
+	var once sync.Once
+	routes := map[string]bool{}
+	return func(c echo.Context) string {
+		once.Do(func() {
+			for _, r := range c.Echo().Routes() {
+				routes[r.Path] = true
+			}
+		})
+		if route := c.Path(); routes[route] {
+			return route
+		}
+		return unmatchedRoute
+	}
+}
+
+// requestMetrics records the rate, errors and duration of every route.
+func requestMetrics() echo.MiddlewareFunc {
+	route := routeOf()
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			metrics.HTTPRequestsInFlight.Inc()
+			defer metrics.HTTPRequestsInFlight.Dec()
+
+			start := time.Now()
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+
+			method := c.Request().Method
+			route := route(c)
+			metrics.HTTPRequests.WithLabelValues(method, route, strconv.Itoa(c.Response().Status)).Inc()
+			metrics.HTTPRequestDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+			return nil
+		}
+	}
+}
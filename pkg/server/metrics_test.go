@@ -0,0 +1,31 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/genpsp/go-app/pkg/metrics"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_RequestMetrics(t *testing.T) {
+	Convey("ルートごとにリクエストを計測する", t, func() {
+		e := echo.New()
+		e.Use(requestMetrics())
+		e.GET("/metrics-test/:id", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+		e.POST("/metrics-test", func(c echo.Context) error { return echo.NewHTTPError(http.StatusInternalServerError) })
+
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/metrics-test/1", nil))
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/metrics-test/2", nil))
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/metrics-test", nil))
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown/path", nil))
+
+		So(testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues("GET", "/metrics-test/:id", "200")), ShouldEqual, 2)
+		So(testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues("POST", "/metrics-test", "500")), ShouldEqual, 1)
+		So(testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues("GET", unmatchedRoute, "404")), ShouldEqual, 1)
+		So(testutil.ToFloat64(metrics.HTTPRequestsInFlight), ShouldEqual, 0)
+	})
+}
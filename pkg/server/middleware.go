@@ -0,0 +1,114 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/genpsp/go-app/pkg/configs/security"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+var errBodyTooLarge = errors.New("request body too large")
+
+// limitedBody fails reads once more than n bytes were read, so bodies sent
+// without Content-Length are limited too.
+type limitedBody struct {
+	io.ReadCloser
+	n        int64
+	exceeded bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.exceeded {
+		return 0, errBodyTooLarge
+	}
+	if int64(len(p)) > b.n+1 {
+		p = p[:b.n+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) > b.n {
+		b.exceeded = true
+		return int(b.n), errBodyTooLarge
+	}
+	b.n -= int64(n)
+	return n, err
+}
+
+// corsMiddleware returns nil when no origin is allowed.
+func corsMiddleware(cfg security.CORS) echo.MiddlewareFunc {
+	if len(cfg.AllowOrigins) == 0 {
+		return nil
+	}
+	return middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOrigins:     cfg.AllowOrigins,
+		AllowMethods:     cfg.AllowMethods,
+		AllowHeaders:     cfg.AllowHeaders,
+		ExposeHeaders:    cfg.ExposeHeaders,
+		AllowCredentials: cfg.AllowCredentials,
+		MaxAge:           int(cfg.MaxAge.Seconds()),
+	})
+}
+
+// secureHeaders sets the response headers every route should send.
+// The Content-Security-Policy is only added to HTML responses, the JSON API
+// has nothing a browser would apply it to.
+func secureHeaders(cfg security.Headers) echo.MiddlewareFunc {
+	hsts := ""
+	if cfg.HSTSMaxAge > 0 {
+		hsts = fmt.Sprintf("max-age=%d; includeSubDomains", int(cfg.HSTSMaxAge.Seconds()))
+	}
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			res := c.Response()
+			h := res.Header()
+			h.Set(echo.HeaderXContentTypeOptions, "nosniff")
+			if cfg.FrameOptions != "" {
+				h.Set(echo.HeaderXFrameOptions, cfg.FrameOptions)
+			}
+			if hsts != "" {
+				h.Set(echo.HeaderStrictTransportSecurity, hsts)
+			}
+			if cfg.ContentSecurityPolicy != "" {
+				res.Before(func() {
+					if strings.HasPrefix(h.Get(echo.HeaderContentType), echo.MIMETextHTML) {
+						h.Set(echo.HeaderContentSecurityPolicy, cfg.ContentSecurityPolicy)
+					}
+				})
+			}
+			return next(c)
+		}
+	}
+}
+
+// bodyLimit rejects request bodies over the route's limit with 413.
+// Routes are matched by method and route path, others get the default limit.
+func bodyLimit(limit int64, routes map[string]int64) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			n := limit
+			if l, ok := routes[req.Method+" "+c.Path()]; ok {
+				n = l
+			}
+			if n <= 0 || req.Body == nil || req.Body == http.NoBody {
+				return next(c)
+			}
+			if req.ContentLength > n {
+				return echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("request body must not exceed %d bytes", n))
+			}
+
+			body := &limitedBody{ReadCloser: req.Body, n: n}
+			req.Body = body
+			err := next(c)
+			// the handler only sees a read error, which it reports as a bad request
+			if body.exceeded && !c.Response().Committed {
+				return echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("request body must not exceed %d bytes", n))
+			}
+			return err
+		}
+	}
+}
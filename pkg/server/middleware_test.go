@@ -0,0 +1,83 @@
+package server
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/genpsp/go-app/pkg/configs/security"
+	"github.com/labstack/echo/v4"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_SecureHeaders(t *testing.T) {
+	Convey("セキュリティヘッダーを付与する", t, func() {
+		e := echo.New()
+		e.Use(secureHeaders(security.Headers{
+			HSTSMaxAge:            time.Hour,
+			FrameOptions:          "DENY",
+			ContentSecurityPolicy: "default-src 'none'",
+		}))
+		e.GET("/json", func(c echo.Context) error { return c.JSON(http.StatusOK, map[string]string{}) })
+		e.GET("/html", func(c echo.Context) error { return c.HTML(http.StatusOK, "<p></p>") })
+
+		Convey("全てのレスポンスに付与する", func() {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/json", nil))
+			So(rec.Header().Get(echo.HeaderXContentTypeOptions), ShouldEqual, "nosniff")
+			So(rec.Header().Get(echo.HeaderXFrameOptions), ShouldEqual, "DENY")
+			So(rec.Header().Get(echo.HeaderStrictTransportSecurity), ShouldEqual, "max-age=3600; includeSubDomains")
+			So(rec.Header().Get(echo.HeaderContentSecurityPolicy), ShouldBeEmpty)
+		})
+		Convey("HTMLにはCSPを付与する", func() {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/html", nil))
+			So(rec.Header().Get(echo.HeaderContentSecurityPolicy), ShouldEqual, "default-src 'none'")
+		})
+	})
+}
+
+func Test_BodyLimit(t *testing.T) {
+	Convey("リクエストボディのサイズを制限する", t, func() {
+		e := echo.New()
+		e.Use(bodyLimit(4, map[string]int64{"POST /upload": 8}))
+		handler := func(c echo.Context) error {
+			body, err := ioutil.ReadAll(c.Request().Body)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return c.String(http.StatusOK, string(body))
+		}
+		e.POST("/items", handler)
+		e.POST("/upload", handler)
+
+		do := func(path, body string, chunked bool) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+			if chunked {
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			return rec
+		}
+
+		Convey("上限以内なら処理する", func() {
+			rec := do("/items", "abcd", false)
+			So(rec.Code, ShouldEqual, http.StatusOK)
+			So(rec.Body.String(), ShouldEqual, "abcd")
+		})
+		Convey("Content-Lengthが上限を超えると413を返す", func() {
+			So(do("/items", "abcde", false).Code, ShouldEqual, http.StatusRequestEntityTooLarge)
+		})
+		Convey("Content-Lengthがなくても読み込み中に超えると413を返す", func() {
+			So(do("/items", "abcde", true).Code, ShouldEqual, http.StatusRequestEntityTooLarge)
+		})
+		Convey("ルートごとの上限を使う", func() {
+			So(do("/upload", "abcdefgh", true).Code, ShouldEqual, http.StatusOK)
+			So(do("/upload", "abcdefghi", false).Code, ShouldEqual, http.StatusRequestEntityTooLarge)
+		})
+	})
+}
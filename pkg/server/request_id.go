@@ -0,0 +1,90 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/genpsp/go-app/pkg/logger"
+	"github.com/genpsp/go-app/pkg/requestid"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// errorBodyRecorder holds the error response back so the request ID can be added to it.
+type errorBodyRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *errorBodyRecorder) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *errorBodyRecorder) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+// requestID puts the request's Correlation and a logger carrying its IDs in
+// the request context, and echoes the request ID back in X-Request-ID.
+// The trace IDs are those of the server span when traceRequests started one.
+// It runs before everything that logs so all of it logs with the request ID.
+func requestID() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			corr := requestid.FromRequest(req)
+			if sc := trace.SpanContextFromContext(req.Context()); sc.IsValid() {
+				corr.TraceID = sc.TraceID().String()
+				corr.SpanID = sc.SpanID().String()
+				corr.Sampled = sc.IsSampled()
+			}
+			c.Response().Header().Set(requestid.HeaderRequestID, corr.RequestID)
+
+			fields := append([]zap.Field{logger.RequestID(corr.RequestID)}, logger.Trace(corr.TraceID, corr.SpanID, corr.Sampled)...)
+			ctx := requestid.NewContext(req.Context(), corr)
+			ctx = logger.NewContext(ctx, logger.FromContext(ctx).With(fields...))
+			c.SetRequest(req.WithContext(ctx))
+			return next(c)
+		}
+	}
+}
+
+// withRequestID adds "request_id" to the JSON object error bodies h writes,
+// so a client can quote it when reporting the error.
+func withRequestID(h echo.HTTPErrorHandler) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		id := requestid.ID(c.Request().Context())
+		res := c.Response()
+		if id == "" || res.Committed {
+			h(err, c)
+			return
+		}
+
+		w := res.Writer
+		rec := &errorBodyRecorder{ResponseWriter: w, status: http.StatusOK}
+		res.Writer = rec
+		h(err, c)
+		res.Writer = w
+
+		body := bytes.TrimSpace(rec.body.Bytes())
+		if len(body) >= 2 && body[0] == '{' && body[len(body)-1] == '}' {
+			field, _ := json.Marshal(id)
+			patched := append([]byte{}, body[:len(body)-1]...)
+			if len(bytes.TrimSpace(body[1:len(body)-1])) > 0 {
+				patched = append(patched, ',')
+			}
+			patched = append(patched, `"request_id":`...)
+			patched = append(patched, field...)
+			patched = append(patched, '}', '\n')
+			body = patched
+		} else {
+			body = rec.body.Bytes()
+		}
+		w.Header().Del(echo.HeaderContentLength)
+		w.WriteHeader(rec.status)
+		_, _ = w.Write(body)
+	}
+}
@@ -0,0 +1,46 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/genpsp/go-app/pkg/requestid"
+	"github.com/labstack/echo/v4"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_RequestID(t *testing.T) {
+	Convey("リクエストIDを付与する", t, func() {
+		e := echo.New()
+		e.Pre(requestID())
+		e.HTTPErrorHandler = withRequestID(func(err error, c echo.Context) {
+			_ = c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+		})
+		var seen string
+		e.GET("/ok", func(c echo.Context) error {
+			seen = requestid.ID(c.Request().Context())
+			return c.NoContent(http.StatusOK)
+		})
+		e.GET("/ng", func(c echo.Context) error {
+			return echo.NewHTTPError(http.StatusBadRequest)
+		})
+
+		Convey("受け取ったIDをcontextとレスポンスヘッダーに設定する", func() {
+			req := httptest.NewRequest(http.MethodGet, "/ok", nil)
+			req.Header.Set(requestid.HeaderRequestID, "abc")
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			So(seen, ShouldEqual, "abc")
+			So(rec.Header().Get(requestid.HeaderRequestID), ShouldEqual, "abc")
+		})
+		Convey("エラーレスポンスにIDを含める", func() {
+			req := httptest.NewRequest(http.MethodGet, "/ng", nil)
+			req.Header.Set(requestid.HeaderRequestID, "abc")
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			So(rec.Code, ShouldEqual, http.StatusBadRequest)
+			So(rec.Body.String(), ShouldEqual, "{\"message\":\"code=400, message=Bad Request\",\"request_id\":\"abc\"}\n")
+		})
+	})
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"github.com/genpsp/go-app/pkg/tracing"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceRequests starts the server span of every request, continuing the
+// trace of an incoming traceparent header. It runs before requestID so the
+// request's logs carry the IDs of its span.
+func traceRequests() echo.MiddlewareFunc {
+	routeOf := routeOf()
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+			ctx, span := tracing.Start(ctx, "HTTP "+req.Method,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(semconv.NetAttributesFromHTTPRequest("tcp", req)...),
+				trace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest("", "", req)...),
+			)
+			defer span.End()
+			c.SetRequest(req.WithContext(ctx))
+
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+
+			// the route is known only once the router ran
+			route := routeOf(c)
+			status := c.Response().Status
+			span.SetName(req.Method + " " + route)
+			span.SetAttributes(semconv.HTTPRouteKey.String(route))
+			span.SetAttributes(semconv.HTTPAttributesFromHTTPStatusCode(status)...)
+			span.SetStatus(semconv.SpanStatusFromHTTPStatusCode(status))
+			return nil
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/genpsp/go-app/pkg/requestid"
+	"github.com/labstack/echo/v4"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func Test_TraceRequests(t *testing.T) {
+	Convey("リクエストごとにサーバースパンを作成する", t, func() {
+		sr := tracetest.NewSpanRecorder()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+		defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+		var corr requestid.Correlation
+		e := echo.New()
+		e.Pre(traceRequests())
+		e.Pre(requestID())
+		e.GET("/items/:id", func(c echo.Context) error {
+			corr, _ = requestid.FromContext(c.Request().Context())
+			return c.NoContent(http.StatusOK)
+		})
+		e.GET("/fail", func(c echo.Context) error { return echo.NewHTTPError(http.StatusInternalServerError) })
+
+		Convey("スパン名はルートにする", func() {
+			req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+			req.Header.Set(requestid.HeaderTraceparent, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+			e.ServeHTTP(httptest.NewRecorder(), req)
+
+			spans := sr.Ended()
+			So(len(spans), ShouldEqual, 1)
+			So(spans[0].Name(), ShouldEqual, "GET /items/:id")
+			So(spans[0].SpanKind(), ShouldEqual, trace.SpanKindServer)
+			So(spans[0].SpanContext().TraceID().String(), ShouldEqual, "4bf92f3577b34da6a3ce929d0e0e4736")
+			So(spans[0].Parent().SpanID().String(), ShouldEqual, "00f067aa0ba902b7")
+
+			Convey("ログのトレースIDはサーバースパンのものにする", func() {
+				So(corr.TraceID, ShouldEqual, "4bf92f3577b34da6a3ce929d0e0e4736")
+				So(corr.SpanID, ShouldEqual, spans[0].SpanContext().SpanID().String())
+				So(corr.Sampled, ShouldBeTrue)
+			})
+		})
+		Convey("5xxはエラーにする", func() {
+			e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fail", nil))
+			So(sr.Ended()[0].Status().Code, ShouldEqual, codes.Error)
+		})
+		Convey("マッチしないパスはunmatchedにする", func() {
+			e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown/path", nil))
+			So(sr.Ended()[0].Name(), ShouldEqual, "GET "+unmatchedRoute)
+		})
+	})
+}
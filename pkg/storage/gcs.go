@@ -0,0 +1,191 @@
+package storage
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"cloud.google.com/go/compute/metadata"
+	cloudstorage "cloud.google.com/go/storage"
+	gcscfg "github.com/genpsp/go-app/pkg/configs/gcs"
+	"google.golang.org/api/iamcredentials/v1"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+type gcsStorage struct {
+	client         *cloudstorage.Client
+	bucket         string
+	googleAccessID string
+	privateKey     []byte
+	// iam signs URLs for googleAccessID when there is no privateKey.
+	iam *iamcredentials.Service
+}
+
+// serviceAccountKey holds the fields of a service account JSON key needed to sign URLs.
+type serviceAccountKey struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+}
+
+func NewGCSStorage(ctx context.Context, cfg gcscfg.GCS) (Storage, error) {
+	if cfg.BucketName == "" {
+		return nil, errors.New("storage: gcs bucket name is empty")
+	}
+	var opts []option.ClientOption
+	s := &gcsStorage{bucket: cfg.BucketName}
+	if cfg.CredentialsFile != "" {
+		b, err := ioutil.ReadFile(cfg.CredentialsFile)
+		if err != nil {
+			return nil, err
+		}
+		var key serviceAccountKey
+		if err := json.Unmarshal(b, &key); err != nil {
+			return nil, err
+		}
+		s.googleAccessID = key.ClientEmail
+		s.privateKey = []byte(key.PrivateKey)
+		opts = append(opts, option.WithCredentialsJSON(b))
+	}
+	client, err := cloudstorage.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	s.client = client
+
+	if len(s.privateKey) == 0 {
+		s.googleAccessID = cfg.SignerEmail
+		if s.googleAccessID == "" && metadata.OnGCE() {
+			if s.googleAccessID, err = metadata.Email("default"); err != nil {
+				return nil, err
+			}
+		}
+		if s.iam, err = iamcredentials.NewService(ctx, opts...); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *gcsStorage) Put(ctx context.Context, key string, r io.Reader, contentType string) (err error) {
+	return put(ctx, s, key, r, contentType)
+}
+
+func (s *gcsStorage) Get(ctx context.Context, key string) (data []byte, attrs *ObjectAttrs, err error) {
+	return get(ctx, s, key)
+}
+
+func (s *gcsStorage) Attrs(ctx context.Context, key string) (attrs *ObjectAttrs, err error) {
+	a, err := s.client.Bucket(s.bucket).Object(key).Attrs(ctx)
+	if errors.Is(err, cloudstorage.ErrObjectNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return
+	}
+	return convertGCSAttrs(a), nil
+}
+
+func (s *gcsStorage) Delete(ctx context.Context, key string) (err error) {
+	err = s.client.Bucket(s.bucket).Object(key).Delete(ctx)
+	if errors.Is(err, cloudstorage.ErrObjectNotExist) {
+		return ErrNotFound
+	}
+	return
+}
+
+func (s *gcsStorage) List(ctx context.Context, prefix string) (objects []ObjectAttrs, err error) {
+	it := s.client.Bucket(s.bucket).Objects(ctx, &cloudstorage.Query{Prefix: prefix})
+	for {
+		a, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, *convertGCSAttrs(a))
+	}
+	return
+}
+
+func (s *gcsStorage) NewReader(ctx context.Context, key string) (r io.ReadCloser, err error) {
+	r, err = s.client.Bucket(s.bucket).Object(key).NewReader(ctx)
+	if errors.Is(err, cloudstorage.ErrObjectNotExist) {
+		return nil, ErrNotFound
+	}
+	return
+}
+
+func (s *gcsStorage) NewWriter(ctx context.Context, key string, contentType string) (w io.WriteCloser, err error) {
+	// cancelling the context aborts the upload instead of committing a partial object
+	ctx, cancel := context.WithCancel(ctx)
+	gw := s.client.Bucket(s.bucket).Object(key).NewWriter(ctx)
+	gw.ContentType = contentType
+	return &gcsWriter{Writer: gw, cancel: cancel}, nil
+}
+
+// SignedURL signs with the private key of the credentials file, else through
+// the IAM signBlob API as the service account the application runs as.
+func (s *gcsStorage) SignedURL(ctx context.Context, key string, opts SignedURLOptions) (url string, err error) {
+	if s.googleAccessID == "" {
+		return "", errors.New("storage: gcs signed url requires a service account, set GCS_SIGNER_EMAIL")
+	}
+	signOpts := &cloudstorage.SignedURLOptions{
+		GoogleAccessID: s.googleAccessID,
+		Method:         opts.Method,
+		ContentType:    opts.ContentType,
+		Expires:        opts.Expires,
+		Scheme:         cloudstorage.SigningSchemeV4,
+	}
+	if opts.MaxSize > 0 {
+		signOpts.Headers = []string{fmt.Sprintf("%s:0,%d", HeaderContentLengthRange, opts.MaxSize)}
+	}
+	if len(s.privateKey) > 0 {
+		signOpts.PrivateKey = s.privateKey
+	} else {
+		signOpts.SignBytes = func(b []byte) ([]byte, error) {
+			return s.signBlob(ctx, b)
+		}
+	}
+	return cloudstorage.SignedURL(s.bucket, key, signOpts)
+}
+
+func (s *gcsStorage) signBlob(ctx context.Context, b []byte) ([]byte, error) {
+	name := "projects/-/serviceAccounts/" + s.googleAccessID
+	resp, err := s.iam.Projects.ServiceAccounts.SignBlob(name, &iamcredentials.SignBlobRequest{
+		Payload: base64.StdEncoding.EncodeToString(b),
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(resp.SignedBlob)
+}
+
+func convertGCSAttrs(a *cloudstorage.ObjectAttrs) *ObjectAttrs {
+	return &ObjectAttrs{
+		Key:         a.Name,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Updated:     a.Updated,
+	}
+}
+
+type gcsWriter struct {
+	*cloudstorage.Writer
+	cancel context.CancelFunc
+}
+
+func (w *gcsWriter) Abort() {
+	w.cancel()
+	w.Writer.Close()
+}
+
+func (w *gcsWriter) Close() error {
+	defer w.cancel()
+	return w.Writer.Close()
+}
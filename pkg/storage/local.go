@@ -0,0 +1,211 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const localMetaDir = ".meta"
+
+// localStorage keeps objects on the local filesystem for development and tests.
+// Object metadata is kept next to the data under the ".meta" directory.
+// Signed URLs point at the storage itself, which serves them as an http.Handler.
+type localStorage struct {
+	urlSigner
+	dir string
+}
+
+type localMeta struct {
+	ContentType string `json:"contentType"`
+}
+
+func NewLocalStorage(dir string, baseURL string, secret []byte) (Storage, error) {
+	if dir == "" {
+		return nil, errors.New("storage: local directory is empty")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	signer, err := newURLSigner(baseURL, secret)
+	if err != nil {
+		return nil, err
+	}
+	return &localStorage{
+		urlSigner: signer,
+		dir:       dir,
+	}, nil
+}
+
+func (s *localStorage) path(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	if key == localMetaDir || strings.HasPrefix(key, localMetaDir+"/") {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+func (s *localStorage) metaPath(key string) string {
+	return filepath.Join(s.dir, localMetaDir, filepath.FromSlash(key)+".json")
+}
+
+func (s *localStorage) Put(ctx context.Context, key string, r io.Reader, contentType string) (err error) {
+	return put(ctx, s, key, r, contentType)
+}
+
+func (s *localStorage) Get(ctx context.Context, key string) (data []byte, attrs *ObjectAttrs, err error) {
+	return get(ctx, s, key)
+}
+
+func (s *localStorage) Attrs(ctx context.Context, key string) (attrs *ObjectAttrs, err error) {
+	p, err := s.path(key)
+	if err != nil {
+		return
+	}
+	stat, err := os.Stat(p)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return
+	}
+	attrs = &ObjectAttrs{
+		Key:     key,
+		Size:    stat.Size(),
+		Updated: stat.ModTime(),
+	}
+	if b, err := ioutil.ReadFile(s.metaPath(key)); err == nil {
+		var meta localMeta
+		if json.Unmarshal(b, &meta) == nil {
+			attrs.ContentType = meta.ContentType
+		}
+	}
+	return attrs, nil
+}
+
+func (s *localStorage) Delete(ctx context.Context, key string) (err error) {
+	p, err := s.path(key)
+	if err != nil {
+		return
+	}
+	err = os.Remove(p)
+	if os.IsNotExist(err) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return
+	}
+	if err = os.Remove(s.metaPath(key)); os.IsNotExist(err) {
+		err = nil
+	}
+	return
+}
+
+func (s *localStorage) List(ctx context.Context, prefix string) (objects []ObjectAttrs, err error) {
+	err = filepath.Walk(s.dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(s.dir, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if info.IsDir() {
+			if key == localMetaDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasPrefix(key, prefix) || strings.HasPrefix(info.Name(), ".") {
+			return nil
+		}
+		attrs, err := s.Attrs(ctx, key)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, *attrs)
+		return nil
+	})
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return
+}
+
+func (s *localStorage) SignedURL(ctx context.Context, key string, opts SignedURLOptions) (url string, err error) {
+	if _, err = s.path(key); err != nil {
+		return
+	}
+	return s.signedURL(key, opts)
+}
+
+func (s *localStorage) NewReader(ctx context.Context, key string) (r io.ReadCloser, err error) {
+	p, err := s.path(key)
+	if err != nil {
+		return
+	}
+	f, err := os.Open(p)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+func (s *localStorage) NewWriter(ctx context.Context, key string, contentType string) (w io.WriteCloser, err error) {
+	p, err := s.path(key)
+	if err != nil {
+		return
+	}
+	if err = os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return
+	}
+	// write to a temporary file so readers never observe a partial object
+	f, err := ioutil.TempFile(filepath.Dir(p), "."+filepath.Base(p)+".tmp")
+	if err != nil {
+		return
+	}
+	return &localWriter{File: f, storage: s, key: key, path: p, contentType: contentType}, nil
+}
+
+func (s *localStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.serveSigned(s, w, r)
+}
+
+type localWriter struct {
+	*os.File
+	storage     *localStorage
+	key         string
+	path        string
+	contentType string
+}
+
+func (w *localWriter) Abort() {
+	w.File.Close()
+	os.Remove(w.File.Name())
+}
+
+func (w *localWriter) Close() error {
+	if err := w.File.Close(); err != nil {
+		os.Remove(w.File.Name())
+		return err
+	}
+	meta, _ := json.Marshal(localMeta{ContentType: w.contentType})
+	metaPath := w.storage.metaPath(w.key)
+	if err := os.MkdirAll(filepath.Dir(metaPath), 0755); err != nil {
+		os.Remove(w.File.Name())
+		return err
+	}
+	if err := ioutil.WriteFile(metaPath, meta, 0644); err != nil {
+		os.Remove(w.File.Name())
+		return err
+	}
+	return os.Rename(w.File.Name(), w.path)
+}
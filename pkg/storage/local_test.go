@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_LocalStorage(t *testing.T) {
+	Convey("LocalStorageを初期化", t, func() {
+		dir, _ := ioutil.TempDir("", "storage")
+		s, err := NewLocalStorage(dir, "/storage", []byte("secret"))
+		So(err, ShouldBeNil)
+		ctx := context.Background()
+		const key = "items/1/images/test.png"
+
+		serve := func(method string, signedURL string, body string, contentType string) *httptest.ResponseRecorder {
+			u, _ := url.Parse(signedURL)
+			u.Path = strings.TrimPrefix(u.Path, "/storage")
+			req := httptest.NewRequest(method, u.String(), strings.NewReader(body))
+			if contentType != "" {
+				req.Header.Set("Content-Type", contentType)
+			}
+			rec := httptest.NewRecorder()
+			s.(http.Handler).ServeHTTP(rec, req)
+			return rec
+		}
+
+		Convey("署名付きURLでアップロード・ダウンロードできる", func() {
+			putURL, err := s.SignedURL(ctx, key, SignedURLOptions{Method: http.MethodPut, ContentType: "image/png", Expires: time.Now().Add(time.Minute)})
+			So(err, ShouldBeNil)
+			So(serve(http.MethodPut, putURL, "png", "image/png").Code, ShouldEqual, http.StatusOK)
+
+			getURL, err := s.SignedURL(ctx, key, SignedURLOptions{Method: http.MethodGet, Expires: time.Now().Add(time.Minute)})
+			So(err, ShouldBeNil)
+			rec := serve(http.MethodGet, getURL, "", "")
+			So(rec.Code, ShouldEqual, http.StatusOK)
+			So(rec.Body.String(), ShouldEqual, "png")
+		})
+		Convey("Content-Typeが署名と異なる場合アップロードできない", func() {
+			putURL, _ := s.SignedURL(ctx, key, SignedURLOptions{Method: http.MethodPut, ContentType: "image/png", Expires: time.Now().Add(time.Minute)})
+			So(serve(http.MethodPut, putURL, "png", "image/gif").Code, ShouldEqual, http.StatusForbidden)
+		})
+		Convey("署名したサイズを超えるアップロードは保存しない", func() {
+			opts := SignedURLOptions{Method: http.MethodPut, ContentType: "image/png", Expires: time.Now().Add(time.Minute), MaxSize: 3}
+			putURL, _ := s.SignedURL(ctx, key, opts)
+			So(serve(http.MethodPut, putURL, "large", "image/png").Code, ShouldEqual, http.StatusRequestEntityTooLarge)
+			_, err := s.Attrs(ctx, key)
+			So(err, ShouldEqual, ErrNotFound)
+			So(UploadHeaders(opts)[HeaderContentLengthRange], ShouldEqual, "0,3")
+
+			u, _ := url.Parse(putURL)
+			q := u.Query()
+			q.Set("max_size", "10")
+			u.RawQuery = q.Encode()
+			So(serve(http.MethodPut, u.String(), "large", "image/png").Code, ShouldEqual, http.StatusForbidden)
+		})
+		Convey("期限切れの署名付きURLではダウンロードできない", func() {
+			_ = s.Put(ctx, key, strings.NewReader("png"), "image/png")
+			getURL, _ := s.SignedURL(ctx, key, SignedURLOptions{Method: http.MethodGet, Expires: time.Now().Add(-time.Minute)})
+			So(serve(http.MethodGet, getURL, "", "").Code, ShouldEqual, http.StatusForbidden)
+		})
+		Convey("ディレクトリ外を指すキーはエラーを返す", func() {
+			err := s.Put(ctx, "../escape", strings.NewReader("x"), "text/plain")
+			So(err, ShouldEqual, ErrInvalidKey)
+		})
+		Convey("存在しないオブジェクトの削除はErrNotFoundを返す", func() {
+			So(s.Delete(ctx, "items/none.png"), ShouldEqual, ErrNotFound)
+		})
+	})
+}
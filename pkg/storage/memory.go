@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// memoryStorage keeps objects in process memory. It is meant for tests.
+type memoryStorage struct {
+	urlSigner
+	mu      sync.RWMutex
+	objects map[string]memoryObject
+}
+
+type memoryObject struct {
+	data        []byte
+	contentType string
+	updated     time.Time
+}
+
+func NewMemoryStorage(baseURL string, secret []byte) (Storage, error) {
+	signer, err := newURLSigner(baseURL, secret)
+	if err != nil {
+		return nil, err
+	}
+	return &memoryStorage{
+		urlSigner: signer,
+		objects:   map[string]memoryObject{},
+	}, nil
+}
+
+func (s *memoryStorage) Put(ctx context.Context, key string, r io.Reader, contentType string) (err error) {
+	return put(ctx, s, key, r, contentType)
+}
+
+func (s *memoryStorage) Get(ctx context.Context, key string) (data []byte, attrs *ObjectAttrs, err error) {
+	return get(ctx, s, key)
+}
+
+func (s *memoryStorage) Attrs(ctx context.Context, key string) (attrs *ObjectAttrs, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	o, ok := s.objects[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &ObjectAttrs{
+		Key:         key,
+		ContentType: o.contentType,
+		Size:        int64(len(o.data)),
+		Updated:     o.updated,
+	}, nil
+}
+
+func (s *memoryStorage) Delete(ctx context.Context, key string) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.objects[key]; !ok {
+		return ErrNotFound
+	}
+	delete(s.objects, key)
+	return nil
+}
+
+func (s *memoryStorage) List(ctx context.Context, prefix string) (objects []ObjectAttrs, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for key, o := range s.objects {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		objects = append(objects, ObjectAttrs{
+			Key:         key,
+			ContentType: o.contentType,
+			Size:        int64(len(o.data)),
+			Updated:     o.updated,
+		})
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return
+}
+
+func (s *memoryStorage) SignedURL(ctx context.Context, key string, opts SignedURLOptions) (url string, err error) {
+	return s.signedURL(key, opts)
+}
+
+func (s *memoryStorage) NewReader(ctx context.Context, key string) (r io.ReadCloser, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	o, ok := s.objects[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return ioutil.NopCloser(bytes.NewReader(o.data)), nil
+}
+
+func (s *memoryStorage) NewWriter(ctx context.Context, key string, contentType string) (w io.WriteCloser, err error) {
+	if err = validateKey(key); err != nil {
+		return
+	}
+	return &memoryWriter{storage: s, key: key, contentType: contentType}, nil
+}
+
+func (s *memoryStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.serveSigned(s, w, r)
+}
+
+type memoryWriter struct {
+	bytes.Buffer
+	storage     *memoryStorage
+	key         string
+	contentType string
+}
+
+func (w *memoryWriter) Abort() {
+	w.Reset()
+}
+
+func (w *memoryWriter) Close() error {
+	w.storage.mu.Lock()
+	defer w.storage.mu.Unlock()
+	w.storage.objects[w.key] = memoryObject{
+		data:        append([]byte(nil), w.Bytes()...),
+		contentType: w.contentType,
+		updated:     time.Now(),
+	}
+	return nil
+}
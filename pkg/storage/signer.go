@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errTooLarge aborts an upload larger than the size its URL was signed for.
+var errTooLarge = errors.New("storage: upload exceeds the signed size")
+
+// urlSigner issues and verifies HMAC signed URLs for the backends
+// that serve their own objects over HTTP.
+type urlSigner struct {
+	baseURL string
+	secret  []byte
+}
+
+func newURLSigner(baseURL string, secret []byte) (urlSigner, error) {
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return urlSigner{}, err
+		}
+	}
+	return urlSigner{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		secret:  secret,
+	}, nil
+}
+
+func validateKey(key string) error {
+	cleaned := path.Clean("/" + key)
+	if key == "" || cleaned == "/" || cleaned[1:] != key {
+		return ErrInvalidKey
+	}
+	return nil
+}
+
+func (s urlSigner) signedURL(key string, opts SignedURLOptions) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	expires := strconv.FormatInt(opts.Expires.Unix(), 10)
+	q := url.Values{}
+	q.Set("expires", expires)
+	maxSize := ""
+	if opts.MaxSize > 0 {
+		maxSize = strconv.FormatInt(opts.MaxSize, 10)
+		q.Set("max_size", maxSize)
+	}
+	q.Set("signature", s.sign(opts.Method, key, opts.ContentType, maxSize, expires))
+	return fmt.Sprintf("%s/%s?%s", s.baseURL, (&url.URL{Path: key}).EscapedPath(), q.Encode()), nil
+}
+
+func (s urlSigner) sign(method string, key string, contentType string, maxSize string, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(strings.Join([]string{method, key, contentType, maxSize, expires}, "\n")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s urlSigner) verify(r *http.Request, method string, key string, contentType string) error {
+	expires := r.URL.Query().Get("expires")
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > unix {
+		return ErrInvalidSignature
+	}
+	expected := s.sign(method, key, contentType, r.URL.Query().Get("max_size"), expires)
+	if !hmac.Equal([]byte(expected), []byte(r.URL.Query().Get("signature"))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// serveSigned serves signed download (GET) and upload (PUT) requests against st.
+// The request path, relative to the mount point, is the object key.
+func (s urlSigner) serveSigned(st Storage, w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	if err := validateKey(key); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		if err := s.verify(r, http.MethodGet, key, ""); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		attrs, err := st.Attrs(r.Context(), key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		rc, err := st.NewReader(r.Context(), key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		defer rc.Close()
+		content, ok := rc.(io.ReadSeeker)
+		if !ok {
+			data, err := ioutil.ReadAll(rc)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			content = bytes.NewReader(data)
+		}
+		if attrs.ContentType != "" {
+			w.Header().Set("Content-Type", attrs.ContentType)
+		}
+		http.ServeContent(w, r, key, attrs.Updated, content)
+	case http.MethodPut:
+		contentType := r.Header.Get("Content-Type")
+		if err := s.verify(r, http.MethodPut, key, contentType); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		var body io.Reader = r.Body
+		if maxSize := r.URL.Query().Get("max_size"); maxSize != "" {
+			// verify checked the signature covers max_size
+			limit, _ := strconv.ParseInt(maxSize, 10, 64)
+			if r.ContentLength > limit {
+				http.Error(w, errTooLarge.Error(), http.StatusRequestEntityTooLarge)
+				return
+			}
+			body = &sizeLimitedReader{r: r.Body, n: limit}
+		}
+		if err := st.Put(r.Context(), key, body, contentType); err != nil {
+			if errors.Is(err, errTooLarge) {
+				http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+// sizeLimitedReader fails with errTooLarge once more than n bytes were read,
+// so put aborts the object instead of storing a truncated one.
+type sizeLimitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *sizeLimitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		return n, errTooLarge
+	}
+	return n, err
+}
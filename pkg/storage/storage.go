@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+
+	gcscfg "github.com/genpsp/go-app/pkg/configs/gcs"
+	storagecfg "github.com/genpsp/go-app/pkg/configs/storage"
+)
+
+// HeaderContentLengthRange carries the size limit of a signed upload, e.g. "0,1024".
+const HeaderContentLengthRange = "X-Goog-Content-Length-Range"
+
+var (
+	ErrNotFound         = errors.New("storage: object not found")
+	ErrInvalidKey       = errors.New("storage: invalid object key")
+	ErrInvalidSignature = errors.New("storage: invalid signature")
+)
+
+type (
+	// Storage is a flat, key addressed blob store.
+	// Keys are slash separated paths such as "items/1/images/xxx.png".
+	Storage interface {
+		Put(ctx context.Context, key string, r io.Reader, contentType string) (err error)
+		Get(ctx context.Context, key string) (data []byte, attrs *ObjectAttrs, err error)
+		Attrs(ctx context.Context, key string) (attrs *ObjectAttrs, err error)
+		Delete(ctx context.Context, key string) (err error)
+		List(ctx context.Context, prefix string) (objects []ObjectAttrs, err error)
+		SignedURL(ctx context.Context, key string, opts SignedURLOptions) (url string, err error)
+
+		// NewReader streams the object. The caller must close the reader.
+		NewReader(ctx context.Context, key string) (r io.ReadCloser, err error)
+		// NewWriter streams a new object. It is not visible until Close returns nil.
+		NewWriter(ctx context.Context, key string, contentType string) (w io.WriteCloser, err error)
+	}
+
+	ObjectAttrs struct {
+		Key         string
+		ContentType string
+		Size        int64
+		Updated     time.Time
+	}
+
+	// SignedURLOptions describes the request a signed URL authorizes.
+	// Method is http.MethodGet for downloads and http.MethodPut for uploads,
+	// in which case the client must send the headers of UploadHeaders.
+	SignedURLOptions struct {
+		Method      string
+		ContentType string
+		Expires     time.Time
+		// MaxSize, when positive, rejects uploads larger than MaxSize bytes.
+		MaxSize int64
+	}
+)
+
+func New(ctx context.Context, cfg storagecfg.Storage, gcsCfg gcscfg.GCS) (Storage, error) {
+	switch cfg.Backend {
+	case storagecfg.BackendGCS:
+		return NewGCSStorage(ctx, gcsCfg)
+	case storagecfg.BackendLocal:
+		return NewLocalStorage(cfg.LocalDir, cfg.LocalBaseURL, []byte(cfg.LocalSigningKey))
+	case storagecfg.BackendMemory:
+		return NewMemoryStorage(cfg.LocalBaseURL, []byte(cfg.LocalSigningKey))
+	default:
+		return nil, fmt.Errorf("storage: unknown backend %q", cfg.Backend)
+	}
+}
+
+// UploadHeaders returns the headers the client must send with the upload opts authorizes.
+func UploadHeaders(opts SignedURLOptions) map[string]string {
+	headers := map[string]string{}
+	if opts.ContentType != "" {
+		headers["Content-Type"] = opts.ContentType
+	}
+	if opts.MaxSize > 0 {
+		headers[HeaderContentLengthRange] = fmt.Sprintf("0,%d", opts.MaxSize)
+	}
+	return headers
+}
+
+// put and get implement Put and Get on top of the streaming methods.
+func put(ctx context.Context, s Storage, key string, r io.Reader, contentType string) error {
+	w, err := s.NewWriter(ctx, key, contentType)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, r); err != nil {
+		if a, ok := w.(interface{ Abort() }); ok {
+			a.Abort()
+		}
+		return err
+	}
+	return w.Close()
+}
+
+func get(ctx context.Context, s Storage, key string) ([]byte, *ObjectAttrs, error) {
+	attrs, err := s.Attrs(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := s.NewReader(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, attrs, nil
+}
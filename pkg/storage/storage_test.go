@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_Storage(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "storage")
+	local, _ := NewLocalStorage(dir, "/storage", nil)
+	memory, _ := NewMemoryStorage("/storage", nil)
+
+	for name, s := range map[string]Storage{"local": local, "memory": memory} {
+		Convey(name+"で共通の操作ができる", t, func() {
+			ctx := context.Background()
+
+			Convey("Putしたオブジェクトを取得できる", func() {
+				So(s.Put(ctx, "exports/a.csv", strings.NewReader("a,b"), "text/csv"), ShouldBeNil)
+				data, attrs, err := s.Get(ctx, "exports/a.csv")
+				So(err, ShouldBeNil)
+				So(string(data), ShouldEqual, "a,b")
+				So(attrs.ContentType, ShouldEqual, "text/csv")
+				So(attrs.Size, ShouldEqual, 3)
+			})
+			Convey("Writerでストリーミング書き込みできる", func() {
+				w, err := s.NewWriter(ctx, "exports/b.ndjson", "application/x-ndjson")
+				So(err, ShouldBeNil)
+				_, _ = w.Write([]byte("{}\n"))
+				_, err = s.Attrs(ctx, "exports/b.ndjson")
+				So(err, ShouldEqual, ErrNotFound)
+				So(w.Close(), ShouldBeNil)
+
+				r, err := s.NewReader(ctx, "exports/b.ndjson")
+				So(err, ShouldBeNil)
+				defer r.Close()
+				data, _ := ioutil.ReadAll(r)
+				So(string(data), ShouldEqual, "{}\n")
+			})
+			Convey("prefixで一覧を取得できる", func() {
+				_ = s.Put(ctx, "list/1.txt", strings.NewReader("1"), "text/plain")
+				_ = s.Put(ctx, "list/2.txt", strings.NewReader("2"), "text/plain")
+				_ = s.Put(ctx, "other/3.txt", strings.NewReader("3"), "text/plain")
+				objects, err := s.List(ctx, "list/")
+				So(err, ShouldBeNil)
+				So(len(objects), ShouldEqual, 2)
+				So(objects[0].Key, ShouldEqual, "list/1.txt")
+				So(objects[1].Key, ShouldEqual, "list/2.txt")
+			})
+			Convey("削除したオブジェクトは取得できない", func() {
+				_ = s.Put(ctx, "delete/1.txt", strings.NewReader("1"), "text/plain")
+				So(s.Delete(ctx, "delete/1.txt"), ShouldBeNil)
+				_, _, err := s.Get(ctx, "delete/1.txt")
+				So(err, ShouldEqual, ErrNotFound)
+			})
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package tracing
+
+import (
+	"context"
+
+	"firebase.google.com/go/v4/auth"
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/pkg/firebase"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// authAdmin traces the Firebase Auth calls that sit on the request path.
+type authAdmin struct {
+	firebase.AuthAdmin
+	ctx context.Context
+}
+
+// AuthAdmin returns a whose VerifyIDToken and CreateUser calls are traced as
+// children of the span in ctx. AuthAdmin takes no context itself, so it is
+// wrapped per call: tracing.AuthAdmin(ctx, s.auth).CreateUser(...).
+func AuthAdmin(ctx context.Context, a firebase.AuthAdmin) firebase.AuthAdmin {
+	return &authAdmin{AuthAdmin: a, ctx: ctx}
+}
+
+func (a *authAdmin) VerifyIDToken(idToken string) (token *auth.Token, err error) {
+	_, span := Start(a.ctx, "firebase.auth VerifyIDToken", trace.WithSpanKind(trace.SpanKindClient))
+	defer func() { End(span, err) }()
+	return a.AuthAdmin.VerifyIDToken(idToken)
+}
+
+func (a *authAdmin) CreateUser(item *entities.Item, password string) (user *auth.UserRecord, err error) {
+	_, span := Start(a.ctx, "firebase.auth CreateUser", trace.WithSpanKind(trace.SpanKindClient))
+	defer func() { End(span, err) }()
+	return a.AuthAdmin.CreateUser(item, password)
+}
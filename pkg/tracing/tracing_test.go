@@ -0,0 +1,92 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"firebase.google.com/go/v4/auth"
+	"github.com/DATA-DOG/go-sqlmock"
+	entities "github.com/genpsp/go-app/domain/entities"
+	repositories "github.com/genpsp/go-app/domain/repository"
+	"github.com/genpsp/go-app/pkg/firebase"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+func recordSpans() *tracetest.SpanRecorder {
+	sr := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+	return sr
+}
+
+func Test_InstrumentGORM(t *testing.T) {
+	Convey("GORMのクエリごとにスパンを作成する", t, func() {
+		sr := recordSpans()
+		defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+		sqlDB, mock, _ := sqlmock.New()
+		defer sqlDB.Close()
+		db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{
+			NamingStrategy: schema.NamingStrategy{SingularTable: true},
+		})
+		So(err, ShouldBeNil)
+		So(InstrumentGORM(db), ShouldBeNil)
+
+		ctx, parent := Start(context.Background(), "parent")
+		mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "a"))
+		_, err = repositories.NewTagRepository().FindAll(db.WithContext(ctx))
+		So(err, ShouldBeNil)
+		parent.End()
+
+		spans := sr.Ended()
+		So(len(spans), ShouldEqual, 2)
+		So(spans[0].Name(), ShouldEqual, "gorm.query tag")
+		So(spans[0].Parent().SpanID(), ShouldEqual, parent.SpanContext().SpanID())
+		So(spans[0].SpanKind(), ShouldEqual, trace.SpanKindClient)
+
+		Convey("失敗したクエリはエラーを記録する", func() {
+			mock.ExpectExec("DELETE").WillReturnError(gorm.ErrInvalidData)
+			db.WithContext(ctx).Exec("DELETE FROM tag")
+			So(sr.Ended()[2].Status().Code, ShouldEqual, codes.Error)
+		})
+		Convey("レコードが見つからないことはエラーにしない", func() {
+			mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+			db.WithContext(ctx).First(&entities.Tag{})
+			So(sr.Ended()[2].Status().Code, ShouldEqual, codes.Unset)
+		})
+	})
+}
+
+type authAdminStub struct {
+	firebase.AuthAdmin
+	err error
+}
+
+func (a *authAdminStub) CreateUser(item *entities.Item, password string) (*auth.UserRecord, error) {
+	return nil, a.err
+}
+
+func Test_AuthAdmin(t *testing.T) {
+	Convey("Firebase Authの呼び出しにスパンを作成する", t, func() {
+		sr := recordSpans()
+		defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+		ctx, parent := Start(context.Background(), "parent")
+		_, err := AuthAdmin(ctx, &authAdminStub{err: errors.New("email exists")}).CreateUser(&entities.Item{}, "password")
+		So(err, ShouldNotBeNil)
+
+		spans := sr.Ended()
+		So(len(spans), ShouldEqual, 1)
+		So(spans[0].Name(), ShouldEqual, "firebase.auth CreateUser")
+		So(spans[0].Parent().SpanID(), ShouldEqual, parent.SpanContext().SpanID())
+		So(spans[0].Status().Code, ShouldEqual, codes.Error)
+	})
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	admin_response "github.com/genpsp/go-app/services/src/handler/response"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+
+	"github.com/genpsp/go-app/pkg/logger"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"github.com/genpsp/go-app/pkg/utils"
+	"github.com/genpsp/go-app/services/src/handler/request"
+	"github.com/genpsp/go-app/services/src/services"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	Category interface {
+		Find(c echo.Context) (err error)
+		Create(c echo.Context) (err error)
+		Update(c echo.Context) (err error)
+		Delete(c echo.Context) (err error)
+		AssignItem(c echo.Context) (err error)
+	}
+	categoryImpl struct {
+		cs services.CategoryService
+	}
+)
+
+func NewCategory(s services.CategoryService) Category {
+	return &categoryImpl{
+		cs: s,
+	}
+}
+
+// Find returns the category tree with the item counts of every node.
+func (s *categoryImpl) Find(c echo.Context) (err error) {
+	result, err := s.cs.FindTree(c.Request().Context())
+	if err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusOK, admin_response.ConvertCategoriesResponse(result))
+	return nil
+}
+
+func (s *categoryImpl) Create(c echo.Context) (err error) {
+	ccr := new(request.CreateCategoryRequest)
+	if _, err := utils.RequestValidate(c, ccr); err != "" {
+		log.Ctx(c.Request().Context()).Error("parse in CreateCategoryRequest errors", zap.String("validation", err), logger.Body(ccr))
+		return appErr.AppStatusBadRequestError400
+	}
+
+	entity := &entities.Category{
+		ParentID: ccr.ParentID,
+		Name:     ccr.Name,
+	}
+	if err = s.cs.Create(c.Request().Context(), entity); err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusCreated, admin_response.ConvertCategoryResponse(&services.CategoryNode{Category: *entity}))
+	return nil
+}
+
+func (s *categoryImpl) Update(c echo.Context) (err error) {
+	id, _ := strconv.Atoi(c.Param("categoryId"))
+	ccr := new(request.CreateCategoryRequest)
+	if _, err := utils.RequestValidate(c, ccr); err != "" {
+		log.Ctx(c.Request().Context()).Error("parse in CreateCategoryRequest errors", zap.String("validation", err), logger.Body(ccr))
+		return appErr.AppStatusBadRequestError400
+	}
+
+	entity := &entities.Category{
+		ParentID: ccr.ParentID,
+		Name:     ccr.Name,
+	}
+	if err = s.cs.Update(c.Request().Context(), id, entity); err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusCreated, nil)
+	return nil
+}
+
+func (s *categoryImpl) Delete(c echo.Context) (err error) {
+	id, _ := strconv.Atoi(c.Param("categoryId"))
+	if err = s.cs.Delete(c.Request().Context(), id); err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusNoContent, nil)
+	return nil
+}
+
+// AssignItem replaces the categories of the item.
+func (s *categoryImpl) AssignItem(c echo.Context) (err error) {
+	id, _ := strconv.Atoi(c.Param("itemId"))
+	acr := new(request.AssignItemCategoriesRequest)
+	if _, err := utils.RequestValidate(c, acr); err != "" {
+		log.Ctx(c.Request().Context()).Error("parse in AssignItemCategoriesRequest errors", zap.String("validation", err), logger.Body(acr))
+		return appErr.AppStatusBadRequestError400
+	}
+	if err = s.cs.AssignItem(c.Request().Context(), id, acr.CategoryIDs); err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusNoContent, nil)
+	return nil
+}
@@ -2,27 +2,85 @@ package handler
 
 import (
 	repositories "github.com/genpsp/go-app/domain/repository"
+	"github.com/genpsp/go-app/pkg/configs"
 	"github.com/genpsp/go-app/pkg/configs/cloudfunctions"
 	"github.com/genpsp/go-app/pkg/configs/gcs"
 	"github.com/genpsp/go-app/pkg/firebase"
+	"github.com/genpsp/go-app/pkg/logger"
+	"github.com/genpsp/go-app/pkg/server/jwt"
+	"github.com/genpsp/go-app/pkg/storage"
 	"github.com/genpsp/go-app/services/src/services"
+	"github.com/labstack/echo/v4"
 	"gorm.io/gorm"
 )
 
+var log = logger.New("handler")
+
 type (
 	Handler struct {
-		Item Item
+		Item        Item
+		Category    Category
+		Tag         Tag
+		ItemImage   ItemImage
+		ItemVariant ItemVariant
+		ItemPrice   ItemPrice
+		ItemExport  ItemExport
+		ItemImport  ItemImport
+		ItemSearch  ItemSearch
+		Stock       Stock
+		Job         Job
+		Storage     storage.Storage
 	}
 )
 
-func NewHandler(m *gorm.DB, f firebase.AuthAdmin) Handler {
+func NewHandler(m *gorm.DB, f firebase.AuthAdmin, st storage.Storage) Handler {
+	cfg := configs.GetConfig()
+
 	// repository
 	itemRepo := repositories.NewItemRepository()
+	itemImageRepo := repositories.NewItemImageRepository()
+	itemSearchRepo := repositories.NewItemSearchRepository()
+	jobRepo := repositories.NewJobRepository()
+	categoryRepo := repositories.NewCategoryRepository()
+	tagRepo := repositories.NewTagRepository()
+	itemVariantRepo := repositories.NewItemVariantRepository()
+	stockRepo := repositories.NewStockRepository()
+	itemPriceRepo := repositories.NewItemPriceRepository()
+	itemRevisionRepo := repositories.NewItemRevisionRepository()
 
 	// service
-	itemService := services.NewItemService(itemRepo, m, f)
+	itemService := services.NewItemService(itemRepo, itemRevisionRepo, m, f)
+	itemImageService := services.NewItemImageService(itemImageRepo, itemRepo, m, st, cfg.Storage.SignedURLExpire)
+	itemPriceService := services.NewItemPriceService(itemPriceRepo, itemRepo, m)
+	jobService := services.NewJobService(jobRepo, m, cfg.Job.MaxAttempts)
+	itemExportService := services.NewItemExportService(itemRepo, jobService, itemPriceService, m, st, cfg.Storage.SignedURLExpire)
+	itemImportService := services.NewItemImportService(itemRepo, itemRevisionRepo, itemPriceRepo, m, st, cfg.Storage.SignedURLExpire)
+	itemSearchService := services.NewItemSearchService(itemSearchRepo, itemImageService, itemPriceService, m)
+	categoryService := services.NewCategoryService(categoryRepo, itemRepo, m)
+	tagService := services.NewTagService(tagRepo, itemRepo, m)
+	itemVariantService := services.NewItemVariantService(itemVariantRepo, itemRepo, m)
+	stockService := services.NewStockService(stockRepo, itemVariantRepo, m)
 
 	return Handler{
-		Item: NewItem(itemService, f),
+		Item:        NewItem(itemService, itemImageService, itemVariantService, itemPriceService, f),
+		Category:    NewCategory(categoryService),
+		Tag:         NewTag(tagService),
+		ItemImage:   NewItemImage(itemImageService),
+		ItemVariant: NewItemVariant(itemVariantService),
+		ItemPrice:   NewItemPrice(itemPriceService),
+		ItemExport:  NewItemExport(itemExportService),
+		ItemImport:  NewItemImport(itemImportService),
+		ItemSearch:  NewItemSearch(itemSearchService),
+		Stock:       NewStock(stockService),
+		Job:         NewJob(jobService),
+		Storage:     st,
+	}
+}
+
+// tokenUID returns the UID of the user the auth middleware verified.
+func tokenUID(c echo.Context) string {
+	if token, ok := c.Get("token").(*jwt.Token); ok && token != nil {
+		return token.UID
 	}
+	return ""
 }
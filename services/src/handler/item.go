@@ -1,22 +1,26 @@
 package handler
 
 import (
-	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	admin_response "github.com/genpsp/go-app/services/src/handler/response"
 
 	entities "github.com/genpsp/go-app/domain/entities"
+	repositories "github.com/genpsp/go-app/domain/repository"
 
 	"github.com/genpsp/go-app/pkg/firebase"
 
 	"github.com/genpsp/go-app/pkg/logger"
 	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"github.com/genpsp/go-app/pkg/tracing"
 	"github.com/genpsp/go-app/pkg/utils"
 	"github.com/genpsp/go-app/services/src/handler/request"
 	"github.com/genpsp/go-app/services/src/services"
 	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
 )
 
 type (
@@ -26,16 +30,24 @@ type (
 		Create(c echo.Context) (err error)
 		Update(c echo.Context) (err error)
 		Delete(c echo.Context) (err error)
+		Revisions(c echo.Context) (err error)
+		Revert(c echo.Context) (err error)
 	}
 	itemImpl struct {
 		aus  services.ItemService
+		iis  services.ItemImageService
+		ivs  services.ItemVariantService
+		ips  services.ItemPriceService
 		auth firebase.AuthAdmin
 	}
 )
 
-func NewItem(s services.ItemService, f firebase.AuthAdmin) Item {
+func NewItem(s services.ItemService, is services.ItemImageService, vs services.ItemVariantService, ps services.ItemPriceService, f firebase.AuthAdmin) Item {
 	return &itemImpl{
 		aus:  s,
+		iis:  is,
+		ivs:  vs,
+		ips:  ps,
 		auth: f,
 	}
 }
@@ -43,11 +55,21 @@ func NewItem(s services.ItemService, f firebase.AuthAdmin) Item {
 func (s *itemImpl) Find(c echo.Context) (err error) {
 	gar := new(request.GetItemRequest)
 	if _, err := utils.RequestValidate(c, gar); err != "" {
-		logger.Logging.Error(fmt.Sprintf("parse in GetItem erros: %s,  body: %s", err, utils.ToJson(gar)))
+		log.Ctx(c.Request().Context()).Error("parse in GetItem errors", zap.String("validation", err), logger.Body(gar))
+		return appErr.AppStatusBadRequestError400
+	}
+	asOf, err := parseAsOf(gar.AsOf)
+	if err != nil {
+		log.Ctx(c.Request().Context()).Info("parse in GetItem as_of erros", zap.Error(err))
 		return appErr.AppStatusBadRequestError400
 	}
 	var result *[]entities.Item
-	result, err = s.aus.FindAll()
+	filter := convertItemFilter(gar)
+	if !filter.IsZero() {
+		result, err = s.aus.FindByFilter(c.Request().Context(), filter)
+	} else {
+		result, err = s.aus.FindAll(c.Request().Context())
+	}
 	if err != nil {
 		return appErr.BindAppErrorWithServiceError(err)
 	}
@@ -55,14 +77,60 @@ func (s *itemImpl) Find(c echo.Context) (err error) {
 		c.JSON(http.StatusNoContent, nil)
 		return nil
 	}
+	for i := range *result {
+		if err = s.iis.SignURLs(c.Request().Context(), (*result)[i].Images); err != nil {
+			return appErr.BindAppErrorWithServiceError(err)
+		}
+	}
+	if err = s.ips.ApplyEffective(c.Request().Context(), *result, asOf); err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	if expandsVariants(gar.Expand) {
+		if err = s.ivs.Expand(c.Request().Context(), *result); err != nil {
+			return appErr.BindAppErrorWithServiceError(err)
+		}
+	}
 	items := admin_response.ConvertItemsResponse(result)
 	c.JSON(http.StatusOK, items)
 	return nil
 }
 
+func convertItemFilter(gar *request.GetItemRequest) repositories.ItemFilter {
+	filter := repositories.ItemFilter{
+		Name:       gar.Name,
+		Price:      gar.Price,
+		CategoryID: gar.CategoryID,
+	}
+	if gar.Tags != "" {
+		filter.Tags = services.NormalizeTagNames(strings.Split(gar.Tags, ","))
+	}
+	return filter
+}
+
+// parseAsOf parses the RFC 3339 as_of parameter, defaulting to the current time.
+func parseAsOf(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now(), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// expandsVariants reports whether the comma separated expand parameter asks for variants.
+func expandsVariants(expand string) bool {
+	for _, e := range strings.Split(expand, ",") {
+		if strings.TrimSpace(e) == "variants" {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *itemImpl) FindByID(c echo.Context) (err error) {
 	id, _ := strconv.Atoi(c.Param("itemId"))
-	result, err := s.aus.FindByID(id)
+	if c.QueryParam("as_of") != "" {
+		return s.findAsOf(c, id)
+	}
+	result, err := s.aus.FindByID(c.Request().Context(), id)
 	if err != nil {
 		return appErr.BindAppErrorWithServiceError(err)
 	}
@@ -70,15 +138,52 @@ func (s *itemImpl) FindByID(c echo.Context) (err error) {
 		c.JSON(http.StatusNoContent, nil)
 		return nil
 	}
+	if err = s.iis.SignURLs(c.Request().Context(), result.Images); err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	items := []entities.Item{*result}
+	if err = s.ips.ApplyEffective(c.Request().Context(), items, time.Now()); err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	if expandsVariants(c.QueryParam("expand")) {
+		if err = s.ivs.Expand(c.Request().Context(), items); err != nil {
+			return appErr.BindAppErrorWithServiceError(err)
+		}
+	}
+	result = &items[0]
 	itemResponse := admin_response.ConvertItemResponse(*result)
 	c.JSON(http.StatusOK, itemResponse)
 	return nil
 }
 
+// findAsOf answers the item as it was at as_of, without the associations
+// that are not versioned.
+func (s *itemImpl) findAsOf(c echo.Context, id int) (err error) {
+	asOf, err := parseAsOf(c.QueryParam("as_of"))
+	if err != nil {
+		log.Ctx(c.Request().Context()).Info("parse in GetItem as_of erros", zap.Error(err))
+		return appErr.AppStatusBadRequestError400
+	}
+	result, err := s.aus.FindAsOf(c.Request().Context(), id, asOf)
+	if err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	if result == nil {
+		c.JSON(http.StatusNoContent, nil)
+		return nil
+	}
+	items := []entities.Item{*result}
+	if err = s.ips.ApplyEffective(c.Request().Context(), items, asOf); err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusOK, admin_response.ConvertItemAsOfResponse(items[0]))
+	return nil
+}
+
 func (s *itemImpl) Create(c echo.Context) (err error) {
 	car := new(request.CreateItemRequest)
 	if _, err := utils.RequestValidate(c, car); err != "" {
-		logger.Logging.Error(fmt.Sprintf("parse in CreateItemRequest erros: %s,  body: %s", err, utils.ToJson(car)))
+		log.Ctx(c.Request().Context()).Error("parse in CreateItemRequest errors", zap.String("validation", err), logger.Body(car))
 		return appErr.AppStatusBadRequestError400
 	}
 
@@ -88,7 +193,7 @@ func (s *itemImpl) Create(c echo.Context) (err error) {
 		return
 	}
 
-	_, err = s.auth.VerifyIDToken(firebaseJwt)
+	_, err = tracing.AuthAdmin(c.Request().Context(), s.auth).VerifyIDToken(firebaseJwt)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, appErr.BindAppErrorWithServiceError(err))
 		return
@@ -98,7 +203,7 @@ func (s *itemImpl) Create(c echo.Context) (err error) {
 		Name: car.Name,
 	}
 	password := utils.RandomString(8)
-	if err = s.aus.Create(entity, password); err != nil {
+	if err = s.aus.Create(c.Request().Context(), entity, password); err != nil {
 		return appErr.AppStatusBadRequestError400
 	}
 
@@ -110,14 +215,14 @@ func (s *itemImpl) Update(c echo.Context) (err error) {
 	id, _ := strconv.Atoi(c.Param("itemId"))
 	car := new(request.CreateItemRequest)
 	if _, err := utils.RequestValidate(c, car); err != "" {
-		logger.Logging.Error(fmt.Sprintf("parse in CreateItemRequest erros: %s,  body: %s", err, utils.ToJson(car)))
+		log.Ctx(c.Request().Context()).Error("parse in CreateItemRequest errors", zap.String("validation", err), logger.Body(car))
 		return appErr.AppStatusBadRequestError400
 	}
 
 	entity := &entities.Item{
 		Name: car.Name,
 	}
-	err = s.aus.Update(id, entity)
+	err = s.aus.Update(c.Request().Context(), id, entity)
 	if err != nil {
 		return appErr.BindAppErrorWithServiceError(err)
 	}
@@ -127,10 +232,35 @@ func (s *itemImpl) Update(c echo.Context) (err error) {
 
 func (s *itemImpl) Delete(c echo.Context) (err error) {
 	id, _ := strconv.Atoi(c.Param("itemId"))
-	err = s.aus.Delete(id)
+	err = s.aus.Delete(c.Request().Context(), id)
 	if err != nil {
 		return appErr.BindAppErrorWithServiceError(err)
 	}
 	c.JSON(http.StatusNoContent, nil)
 	return
 }
+
+// Revisions returns the revisions of the item, newest first.
+func (s *itemImpl) Revisions(c echo.Context) (err error) {
+	id, _ := strconv.Atoi(c.Param("itemId"))
+	result, err := s.aus.Revisions(c.Request().Context(), id)
+	if err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	if result == nil {
+		c.JSON(http.StatusNoContent, nil)
+		return nil
+	}
+	c.JSON(http.StatusOK, admin_response.ConvertItemRevisionsResponse(*result))
+	return nil
+}
+
+func (s *itemImpl) Revert(c echo.Context) (err error) {
+	id, _ := strconv.Atoi(c.Param("itemId"))
+	revision, _ := strconv.Atoi(c.Param("revision"))
+	if err = s.aus.Revert(c.Request().Context(), id, revision); err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusCreated, nil)
+	return nil
+}
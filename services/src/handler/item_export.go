@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	admin_response "github.com/genpsp/go-app/services/src/handler/response"
+
+	"github.com/genpsp/go-app/pkg/logger"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"github.com/genpsp/go-app/pkg/utils"
+	"github.com/genpsp/go-app/services/src/handler/request"
+	"github.com/genpsp/go-app/services/src/services"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	ItemExport interface {
+		Export(c echo.Context) (err error)
+		Download(c echo.Context) (err error)
+	}
+	itemExportImpl struct {
+		ies services.ItemExportService
+	}
+)
+
+func NewItemExport(s services.ItemExportService) ItemExport {
+	return &itemExportImpl{
+		ies: s,
+	}
+}
+
+func convertItemExportOptions(ier *request.ExportItemRequest) services.ItemExportOptions {
+	opts := services.ItemExportOptions{
+		Format:  ier.Format,
+		Columns: services.ItemExportDefaultColumns,
+		Header:  ier.Header == nil || *ier.Header,
+		Filter:  convertItemFilter(&ier.GetItemRequest),
+	}
+	if opts.Format == "" {
+		opts.Format = services.ItemExportFormatCSV
+	}
+	if ier.Columns != "" {
+		opts.Columns = strings.Split(ier.Columns, ",")
+	}
+	return opts
+}
+
+// Export streams the items as CSV or NDJSON, or with async=true
+// runs the export as a job and returns it with 202.
+func (s *itemExportImpl) Export(c echo.Context) (err error) {
+	ier := new(request.ExportItemRequest)
+	if _, err := utils.RequestValidate(c, ier); err != "" {
+		log.Ctx(c.Request().Context()).Error("parse in ExportItemRequest errors", zap.String("validation", err), logger.Body(ier))
+		return appErr.AppStatusBadRequestError400
+	}
+	opts := convertItemExportOptions(ier)
+
+	if ier.Async {
+		job, err := s.ies.Enqueue(c.Request().Context(), tokenUID(c), opts)
+		if err != nil {
+			return appErr.BindAppErrorWithServiceError(err)
+		}
+		c.JSON(http.StatusAccepted, admin_response.ConvertJobResponse(*job))
+		return nil
+	}
+
+	if err = opts.Validate(); err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, opts.ContentType())
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=items.%s", opts.Format))
+	res.WriteHeader(http.StatusOK)
+	if _, err = s.ies.Export(c.Request().Context(), res, opts); err != nil {
+		// the status line is already sent, so the client sees a truncated body
+		log.Ctx(c.Request().Context()).Error("occurred error when ItemExport with Export stream", zap.Error(err))
+	}
+	return nil
+}
+
+func (s *itemExportImpl) Download(c echo.Context) (err error) {
+	id, _ := strconv.Atoi(c.Param("jobId"))
+	url, err := s.ies.DownloadURL(c.Request().Context(), tokenUID(c), id)
+	if err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	if url == "" {
+		c.JSON(http.StatusNoContent, nil)
+		return nil
+	}
+	return c.Redirect(http.StatusFound, url)
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	admin_response "github.com/genpsp/go-app/services/src/handler/response"
+
+	"github.com/genpsp/go-app/pkg/logger"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"github.com/genpsp/go-app/pkg/utils"
+	"github.com/genpsp/go-app/services/src/handler/request"
+	"github.com/genpsp/go-app/services/src/services"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	ItemImage interface {
+		Create(c echo.Context) (err error)
+		Confirm(c echo.Context) (err error)
+	}
+	itemImageImpl struct {
+		iis services.ItemImageService
+	}
+)
+
+func NewItemImage(s services.ItemImageService) ItemImage {
+	return &itemImageImpl{
+		iis: s,
+	}
+}
+
+// Create uploads the image directly when the request is multipart/form-data,
+// otherwise it issues a signed URL the client uploads the image to.
+func (s *itemImageImpl) Create(c echo.Context) (err error) {
+	id, _ := strconv.Atoi(c.Param("itemId"))
+
+	if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		file, err := c.FormFile("image")
+		if err != nil {
+			log.Ctx(c.Request().Context()).Error("parse in CreateItemImage errors", zap.Error(err))
+			return appErr.AppStatusBadRequestError400
+		}
+		image, err := s.iis.Upload(c.Request().Context(), id, file)
+		if err != nil {
+			return appErr.BindAppErrorWithServiceError(err)
+		}
+		c.JSON(http.StatusCreated, admin_response.ConvertItemImageResponse(*image))
+		return nil
+	}
+
+	cur := new(request.CreateItemImageUploadURLRequest)
+	if _, err := utils.RequestValidate(c, cur); err != "" {
+		log.Ctx(c.Request().Context()).Error("parse in CreateItemImageUploadURLRequest errors", zap.String("validation", err), logger.Body(cur))
+		return appErr.AppStatusBadRequestError400
+	}
+	image, upload, err := s.iis.IssueUploadURL(c.Request().Context(), id, cur.ContentType, cur.Size)
+	if err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusCreated, admin_response.ItemImageUploadURLResponse{
+		Image:         admin_response.ConvertItemImageResponse(*image),
+		UploadURL:     upload.URL,
+		UploadHeaders: upload.Headers,
+		ExpiresAt:     upload.ExpiresAt,
+	})
+	return
+}
+
+// Confirm attaches an image uploaded to a signed URL once the object exists.
+func (s *itemImageImpl) Confirm(c echo.Context) (err error) {
+	id, _ := strconv.Atoi(c.Param("itemId"))
+	imageID, _ := strconv.Atoi(c.Param("imageId"))
+
+	image, err := s.iis.Confirm(c.Request().Context(), id, imageID)
+	if err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusOK, admin_response.ConvertItemImageResponse(*image))
+	return
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+
+	admin_response "github.com/genpsp/go-app/services/src/handler/response"
+
+	"github.com/genpsp/go-app/pkg/logger"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"github.com/genpsp/go-app/pkg/utils"
+	"github.com/genpsp/go-app/services/src/handler/request"
+	"github.com/genpsp/go-app/services/src/services"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	ItemImport interface {
+		Import(c echo.Context) (err error)
+	}
+	itemImportImpl struct {
+		iis services.ItemImportService
+	}
+)
+
+func NewItemImport(s services.ItemImportService) ItemImport {
+	return &itemImportImpl{
+		iis: s,
+	}
+}
+
+// Import accepts a CSV as the "file" field of a multipart form.
+// With dryRun=true the rows are only validated and nothing is written.
+func (s *itemImportImpl) Import(c echo.Context) (err error) {
+	iir := new(request.ImportItemRequest)
+	if _, err := utils.RequestValidate(c, iir); err != "" {
+		log.Ctx(c.Request().Context()).Error("parse in ImportItemRequest errors", zap.String("validation", err), logger.Body(iir))
+		return appErr.AppStatusBadRequestError400
+	}
+	fh, err := c.FormFile("file")
+	if err != nil {
+		log.Ctx(c.Request().Context()).Error("parse in ImportItem errors", zap.Error(err))
+		return appErr.AppStatusBadRequestError400
+	}
+	file, err := fh.Open()
+	if err != nil {
+		log.Ctx(c.Request().Context()).Error("parse in ImportItem errors", zap.Error(err))
+		return appErr.AppStatusBadRequestError400
+	}
+	defer file.Close()
+
+	result, err := s.iis.Import(c.Request().Context(), file, iir.DryRun, c.Echo().Validator)
+	if err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	status := http.StatusOK
+	if !result.DryRun && result.ValidRows > 0 {
+		status = http.StatusCreated
+	}
+	c.JSON(status, admin_response.ConvertItemImportResponse(*result))
+	return nil
+}
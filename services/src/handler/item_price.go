@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	admin_response "github.com/genpsp/go-app/services/src/handler/response"
+
+	"github.com/genpsp/go-app/pkg/logger"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"github.com/genpsp/go-app/pkg/utils"
+	"github.com/genpsp/go-app/services/src/handler/request"
+	"github.com/genpsp/go-app/services/src/services"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	ItemPrice interface {
+		Find(c echo.Context) (err error)
+		Schedule(c echo.Context) (err error)
+	}
+	itemPriceImpl struct {
+		ips services.ItemPriceService
+	}
+)
+
+func NewItemPrice(s services.ItemPriceService) ItemPrice {
+	return &itemPriceImpl{
+		ips: s,
+	}
+}
+
+// Find returns the price history of the item, past and scheduled.
+func (s *itemPriceImpl) Find(c echo.Context) (err error) {
+	itemID, _ := strconv.Atoi(c.Param("itemId"))
+	result, err := s.ips.History(c.Request().Context(), itemID)
+	if err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusOK, admin_response.ConvertItemPricesResponse(*result))
+	return nil
+}
+
+// Schedule sets the price for a period, starting now when effectiveFrom is omitted.
+func (s *itemPriceImpl) Schedule(c echo.Context) (err error) {
+	itemID, _ := strconv.Atoi(c.Param("itemId"))
+	spr := new(request.ScheduleItemPriceRequest)
+	if _, err := utils.RequestValidate(c, spr); err != "" {
+		log.Ctx(c.Request().Context()).Error("parse in ScheduleItemPriceRequest errors", zap.String("validation", err), logger.Body(spr))
+		return appErr.AppStatusBadRequestError400
+	}
+
+	var effectiveFrom time.Time
+	if spr.EffectiveFrom != nil {
+		effectiveFrom = *spr.EffectiveFrom
+	}
+	result, err := s.ips.Schedule(c.Request().Context(), itemID, spr.Price, effectiveFrom, spr.EffectiveTo)
+	if err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusCreated, admin_response.ConvertItemPriceResponse(*result))
+	return nil
+}
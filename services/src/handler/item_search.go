@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+
+	admin_response "github.com/genpsp/go-app/services/src/handler/response"
+
+	"github.com/genpsp/go-app/pkg/logger"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"github.com/genpsp/go-app/pkg/utils"
+	"github.com/genpsp/go-app/services/src/handler/request"
+	"github.com/genpsp/go-app/services/src/services"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	ItemSearch interface {
+		Search(c echo.Context) (err error)
+	}
+	itemSearchImpl struct {
+		iss services.ItemSearchService
+	}
+)
+
+func NewItemSearch(s services.ItemSearchService) ItemSearch {
+	return &itemSearchImpl{
+		iss: s,
+	}
+}
+
+func (s *itemSearchImpl) Search(c echo.Context) (err error) {
+	sir := new(request.SearchItemRequest)
+	if _, err := utils.RequestValidate(c, sir); err != "" {
+		log.Ctx(c.Request().Context()).Error("parse in SearchItemRequest errors", zap.String("validation", err), logger.Body(sir))
+		return appErr.AppStatusBadRequestError400
+	}
+	result, err := s.iss.Search(c.Request().Context(), sir.Q, sir.Limit, sir.Offset)
+	if err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusOK, admin_response.ConvertItemSearchResponse(*result))
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	admin_response "github.com/genpsp/go-app/services/src/handler/response"
 
 	entities "github.com/genpsp/go-app/domain/entities"
+	repositories "github.com/genpsp/go-app/domain/repository"
 
 	"github.com/genpsp/go-app/pkg/utils"
 	"github.com/genpsp/go-app/services/src/handler/request"
@@ -26,7 +27,11 @@ func Test_NewItemHandler(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		as := mock_services.NewMockItemService(ctrl)
-		ah := NewItem(as, nil)
+		is := mock_services.NewMockItemImageService(ctrl)
+		vs := mock_services.NewMockItemVariantService(ctrl)
+		ps := mock_services.NewMockItemPriceService(ctrl)
+		ps.EXPECT().ApplyEffective(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		ah := NewItem(as, is, vs, ps, nil)
 		So(ah, ShouldNotBeNil)
 	})
 }
@@ -42,7 +47,12 @@ func Test_ItemHandler(t *testing.T) {
 		const role = 0
 
 		as := mock_services.NewMockItemService(ctrl)
-		ah := NewItem(as, nil)
+		is := mock_services.NewMockItemImageService(ctrl)
+		is.EXPECT().SignURLs(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		vs := mock_services.NewMockItemVariantService(ctrl)
+		ps := mock_services.NewMockItemPriceService(ctrl)
+		ps.EXPECT().ApplyEffective(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		ah := NewItem(as, is, vs, ps, nil)
 		So(ah, ShouldNotBeNil)
 
 		Convey("FindAll", func() {
@@ -55,7 +65,7 @@ func Test_ItemHandler(t *testing.T) {
 			mockEntities := []entities.Item{
 				{Name: name, EmailAddress: emailAddress, Role: role},
 			}
-			as.EXPECT().FindAll().Return(&mockEntities, nil)
+			as.EXPECT().FindAll(gomock.Any()).Return(&mockEntities, nil)
 
 			Convey("正常にレスポンスを変換できる", func() {
 				response := admin_response.ConvertItemsResponse(&mockEntities)
@@ -83,7 +93,7 @@ func Test_ItemHandler(t *testing.T) {
 			mockRequest := request.GetItemRequest{
 				Name: name, EmailAddress: emailAddress,
 			}
-			as.EXPECT().Find(&mockRequest).Return(&mockEntities, nil)
+			as.EXPECT().Find(gomock.Any(), &mockRequest).Return(&mockEntities, nil)
 
 			Convey("正常にレスポンスを変換できる", func() {
 				response := admin_response.ConvertItemsResponse(&mockEntities)
@@ -108,7 +118,7 @@ func Test_ItemHandler(t *testing.T) {
 			mockEntity := entities.Item{
 				Name: name, EmailAddress: emailAddress, Role: role,
 			}
-			as.EXPECT().FindByID(gomock.Any()).Return(&mockEntity, nil)
+			as.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(&mockEntity, nil)
 
 			Convey("正常にレスポンスを変換できる", func() {
 				response := admin_response.ConvertItemResponse(mockEntity)
@@ -143,7 +153,7 @@ func Test_ItemHandler(t *testing.T) {
 				Name: name, EmailAddress: emailAddress, Role: role,
 			}
 
-			as.EXPECT().Update(gomock.Any(), mockEntity).Return(nil)
+			as.EXPECT().Update(gomock.Any(), gomock.Any(), mockEntity).Return(nil)
 
 			Convey("正常に更新できる", func() {
 				err := ah.Update(c)
@@ -157,7 +167,7 @@ func Test_ItemHandler(t *testing.T) {
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 
-			as.EXPECT().Delete(gomock.Any()).Return(nil)
+			as.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
 
 			Convey("正常に削除できる", func() {
 				err := ah.Delete(c)
@@ -172,7 +182,7 @@ func Test_ItemHandler(t *testing.T) {
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 
-			as.EXPECT().Find(gomock.Any()).Return(nil, appErr.ServiceClientError)
+			as.EXPECT().Find(gomock.Any(), gomock.Any()).Return(nil, appErr.ServiceClientError)
 
 			err := ah.Find(c)
 			So(err, ShouldEqual, appErr.AppStatusInternalServerError500)
@@ -184,7 +194,7 @@ func Test_ItemHandler(t *testing.T) {
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 
-			as.EXPECT().FindByID(gomock.Any()).Return(nil, appErr.ServiceClientError)
+			as.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, appErr.ServiceClientError)
 
 			err := ah.FindByID(c)
 			So(err, ShouldEqual, appErr.AppStatusInternalServerError500)
@@ -205,7 +215,7 @@ func Test_ItemHandler(t *testing.T) {
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 
-			as.EXPECT().Update(gomock.Any(), gomock.Any()).Return(appErr.ServiceClientError)
+			as.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(appErr.ServiceClientError)
 
 			err := ah.Update(c)
 			So(err, ShouldEqual, appErr.AppStatusInternalServerError500)
@@ -216,10 +226,40 @@ func Test_ItemHandler(t *testing.T) {
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 
-			as.EXPECT().Delete(gomock.Any()).Return(appErr.ServiceClientError)
+			as.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(appErr.ServiceClientError)
 
 			err := ah.Delete(c)
 			So(err, ShouldEqual, appErr.AppStatusInternalServerError500)
 		})
 	})
 }
+
+func Test_ItemHandler_FindByQuery(t *testing.T) {
+	Convey("ItemHandlerを初期化", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		as := mock_services.NewMockItemService(ctrl)
+		is := mock_services.NewMockItemImageService(ctrl)
+		is.EXPECT().SignURLs(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		vs := mock_services.NewMockItemVariantService(ctrl)
+		ps := mock_services.NewMockItemPriceService(ctrl)
+		ps.EXPECT().ApplyEffective(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		ah := NewItem(as, is, vs, ps, nil)
+
+		Convey("クエリパラメータの名前と価格で絞り込める", func() {
+			e := echo.New()
+			e.Validator = utils.NewAppValidator()
+			req := httptest.NewRequest(http.MethodGet, "/app/items?name=%E3%82%B7%E3%83%A3%E3%83%84&price=1000", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			mockEntities := []entities.Item{{Name: "シャツ", Price: 1000}}
+			as.EXPECT().FindByFilter(gomock.Any(), repositories.ItemFilter{Name: "シャツ", Price: 1000}).Return(&mockEntities, nil)
+
+			err := ah.Find(c)
+			So(err, ShouldBeNil)
+			So(rec.Code, ShouldEqual, http.StatusOK)
+		})
+	})
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	admin_response "github.com/genpsp/go-app/services/src/handler/response"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+
+	"github.com/genpsp/go-app/pkg/logger"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"github.com/genpsp/go-app/pkg/utils"
+	"github.com/genpsp/go-app/services/src/handler/request"
+	"github.com/genpsp/go-app/services/src/services"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	ItemVariant interface {
+		Find(c echo.Context) (err error)
+		FindByID(c echo.Context) (err error)
+		Create(c echo.Context) (err error)
+		Update(c echo.Context) (err error)
+		Delete(c echo.Context) (err error)
+	}
+	itemVariantImpl struct {
+		ivs services.ItemVariantService
+	}
+)
+
+func NewItemVariant(s services.ItemVariantService) ItemVariant {
+	return &itemVariantImpl{
+		ivs: s,
+	}
+}
+
+func convertItemVariantEntity(cvr *request.CreateItemVariantRequest) *entities.ItemVariant {
+	return &entities.ItemVariant{
+		SKU:     cvr.SKU,
+		Options: cvr.Options,
+		Price:   cvr.Price,
+		Barcode: cvr.Barcode,
+	}
+}
+
+func (s *itemVariantImpl) Find(c echo.Context) (err error) {
+	itemID, _ := strconv.Atoi(c.Param("itemId"))
+	result, err := s.ivs.FindByItemID(c.Request().Context(), itemID)
+	if err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusOK, admin_response.ConvertItemVariantsResponse(*result))
+	return nil
+}
+
+func (s *itemVariantImpl) FindByID(c echo.Context) (err error) {
+	itemID, _ := strconv.Atoi(c.Param("itemId"))
+	variantID, _ := strconv.Atoi(c.Param("variantId"))
+	result, err := s.ivs.FindByID(c.Request().Context(), itemID, variantID)
+	if err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	if result == nil {
+		c.JSON(http.StatusNoContent, nil)
+		return nil
+	}
+	c.JSON(http.StatusOK, admin_response.ConvertItemVariantResponse(*result))
+	return nil
+}
+
+func (s *itemVariantImpl) Create(c echo.Context) (err error) {
+	itemID, _ := strconv.Atoi(c.Param("itemId"))
+	cvr := new(request.CreateItemVariantRequest)
+	if _, err := utils.RequestValidate(c, cvr); err != "" {
+		log.Ctx(c.Request().Context()).Error("parse in CreateItemVariantRequest errors", zap.String("validation", err), logger.Body(cvr))
+		return appErr.AppStatusBadRequestError400
+	}
+
+	entity := convertItemVariantEntity(cvr)
+	if err = s.ivs.Create(c.Request().Context(), itemID, entity); err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusCreated, admin_response.ConvertItemVariantResponse(*entity))
+	return nil
+}
+
+func (s *itemVariantImpl) Update(c echo.Context) (err error) {
+	itemID, _ := strconv.Atoi(c.Param("itemId"))
+	variantID, _ := strconv.Atoi(c.Param("variantId"))
+	cvr := new(request.CreateItemVariantRequest)
+	if _, err := utils.RequestValidate(c, cvr); err != "" {
+		log.Ctx(c.Request().Context()).Error("parse in CreateItemVariantRequest errors", zap.String("validation", err), logger.Body(cvr))
+		return appErr.AppStatusBadRequestError400
+	}
+
+	if err = s.ivs.Update(c.Request().Context(), itemID, variantID, convertItemVariantEntity(cvr)); err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusCreated, nil)
+	return nil
+}
+
+func (s *itemVariantImpl) Delete(c echo.Context) (err error) {
+	itemID, _ := strconv.Atoi(c.Param("itemId"))
+	variantID, _ := strconv.Atoi(c.Param("variantId"))
+	if err = s.ivs.Delete(c.Request().Context(), itemID, variantID); err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusNoContent, nil)
+	return nil
+}
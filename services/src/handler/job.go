@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	admin_response "github.com/genpsp/go-app/services/src/handler/response"
+
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"github.com/genpsp/go-app/services/src/services"
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	Job interface {
+		FindByID(c echo.Context) (err error)
+	}
+	jobImpl struct {
+		js services.JobService
+	}
+)
+
+func NewJob(s services.JobService) Job {
+	return &jobImpl{
+		js: s,
+	}
+}
+
+// FindByID answers 204 for jobs other users enqueued, as for missing ones.
+func (s *jobImpl) FindByID(c echo.Context) (err error) {
+	id, _ := strconv.Atoi(c.Param("jobId"))
+	result, err := s.js.FindByID(c.Request().Context(), tokenUID(c), id)
+	if err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	if result == nil {
+		c.JSON(http.StatusNoContent, nil)
+		return nil
+	}
+	c.JSON(http.StatusOK, admin_response.ConvertJobResponse(*result))
+	return nil
+}
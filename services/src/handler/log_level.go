@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/genpsp/go-app/pkg/logger"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"github.com/genpsp/go-app/pkg/utils"
+	"github.com/genpsp/go-app/services/src/handler/request"
+	admin_response "github.com/genpsp/go-app/services/src/handler/response"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// defaultLogLevelPackage addresses the default level in the :package path parameter.
+const defaultLogLevelPackage = "default"
+
+type (
+	// LogLevel changes the log level of the running process. Levels are not
+	// shared between instances and are lost on restart.
+	LogLevel interface {
+		Find(c echo.Context) (err error)
+		Update(c echo.Context) (err error)
+		Delete(c echo.Context) (err error)
+	}
+	logLevelImpl struct{}
+)
+
+func NewLogLevel() LogLevel {
+	return &logLevelImpl{}
+}
+
+func (s *logLevelImpl) Find(c echo.Context) (err error) {
+	c.JSON(http.StatusOK, admin_response.ConvertLogLevelsResponse(logger.Levels()))
+	return nil
+}
+
+func (s *logLevelImpl) Update(c echo.Context) (err error) {
+	ulr := new(request.UpdateLogLevelRequest)
+	if _, err := utils.RequestValidate(c, ulr); err != "" {
+		log.Ctx(c.Request().Context()).Error("parse in UpdateLogLevelRequest errors", zap.String("validation", err), logger.Body(ulr))
+		return appErr.AppStatusBadRequestError400
+	}
+	pkg := c.Param("package")
+	if pkg == defaultLogLevelPackage {
+		pkg = ""
+	}
+	if err = logger.SetLevel(pkg, ulr.Level); err != nil {
+		return appErr.AppStatusBadRequestError400
+	}
+	log.Ctx(c.Request().Context()).Info("log level changed", zap.String("package", c.Param("package")), zap.String("level", ulr.Level))
+	c.JSON(http.StatusOK, admin_response.ConvertLogLevelsResponse(logger.Levels()))
+	return nil
+}
+
+func (s *logLevelImpl) Delete(c echo.Context) (err error) {
+	logger.ResetLevel(c.Param("package"))
+	c.JSON(http.StatusOK, admin_response.ConvertLogLevelsResponse(logger.Levels()))
+	return nil
+}
@@ -0,0 +1,6 @@
+package request
+
+type CreateCategoryRequest struct {
+	ParentID *uint  `json:"parentId"`
+	Name     string `json:"name" validate:"required,max=255"`
+}
@@ -0,0 +1,6 @@
+package request
+
+type CreateItemImageUploadURLRequest struct {
+	ContentType string `json:"contentType" validate:"required"`
+	Size        int64  `json:"size" validate:"required,min=1"`
+}
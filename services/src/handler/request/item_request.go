@@ -1,11 +1,51 @@
 package request
 
+import (
+	"time"
+)
+
 type CreateItemRequest struct {
 	Name  string `json:"name"`
 	Price int    `json:"price"`
 }
 
 type GetItemRequest struct {
-	Name  string `json:"name"`
-	Price int    `json:"price"`
+	Name       string `json:"name" query:"name"`
+	Price      int    `json:"price" query:"price" validate:"omitempty,min=0"`
+	CategoryID int    `query:"categoryId" validate:"omitempty,min=1"`
+	Tags       string `query:"tags"`
+	Expand     string `query:"expand"`
+	AsOf       string `query:"as_of"`
+}
+
+type ExportItemRequest struct {
+	GetItemRequest
+	Format  string `query:"format"`
+	Columns string `query:"columns"`
+	Header  *bool  `query:"header"`
+	Async   bool   `query:"async"`
+}
+
+type ImportItemRequest struct {
+	DryRun bool `query:"dryRun"`
+}
+
+type SearchItemRequest struct {
+	Q      string `query:"q" validate:"required"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Offset int    `query:"offset" validate:"omitempty,min=0"`
+}
+
+type AssignItemCategoriesRequest struct {
+	CategoryIDs []uint `json:"categoryIds"`
+}
+
+type AssignItemTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+type ScheduleItemPriceRequest struct {
+	Price         int        `json:"price" validate:"min=0"`
+	EffectiveFrom *time.Time `json:"effectiveFrom"`
+	EffectiveTo   *time.Time `json:"effectiveTo"`
 }
@@ -0,0 +1,8 @@
+package request
+
+type CreateItemVariantRequest struct {
+	SKU     string            `json:"sku" validate:"required,max=64"`
+	Options map[string]string `json:"options"`
+	Price   *int              `json:"price" validate:"omitempty,min=0"`
+	Barcode string            `json:"barcode" validate:"max=64"`
+}
@@ -0,0 +1,5 @@
+package request
+
+type UpdateLogLevelRequest struct {
+	Level string `json:"level" validate:"required,oneof=debug info warn error"`
+}
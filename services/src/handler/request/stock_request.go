@@ -0,0 +1,26 @@
+package request
+
+// VariantID selects the stock of a variant. Zero means the stock of the item itself.
+
+type GetStockRequest struct {
+	VariantID uint `query:"variantId"`
+}
+
+type GetStockMovementsRequest struct {
+	VariantID uint `query:"variantId"`
+	Limit     int  `query:"limit" validate:"omitempty,min=1,max=500"`
+	Offset    int  `query:"offset" validate:"omitempty,min=0"`
+}
+
+type CreateStockMovementRequest struct {
+	VariantID uint   `json:"variantId"`
+	Type      string `json:"type" validate:"required,oneof=receipt adjustment sale"`
+	Quantity  int    `json:"quantity" validate:"required"`
+	Note      string `json:"note" validate:"max=255"`
+}
+
+type CreateStockReservationRequest struct {
+	VariantID  uint `json:"variantId"`
+	Quantity   int  `json:"quantity" validate:"required,min=1"`
+	TTLSeconds int  `json:"ttlSeconds" validate:"omitempty,min=1,max=86400"`
+}
@@ -0,0 +1,5 @@
+package request
+
+type CreateTagRequest struct {
+	Name string `json:"name" validate:"required,max=64"`
+}
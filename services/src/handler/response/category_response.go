@@ -0,0 +1,33 @@
+package admin_response
+
+import (
+	"github.com/genpsp/go-app/services/src/services"
+)
+
+type CategoryResponse struct {
+	ID             uint                `json:"id"`
+	ParentID       *uint               `json:"parentId"`
+	Name           string              `json:"name"`
+	ItemCount      int64               `json:"itemCount"`
+	TotalItemCount int64               `json:"totalItemCount"`
+	Children       []*CategoryResponse `json:"children"`
+}
+
+func ConvertCategoryResponse(node *services.CategoryNode) *CategoryResponse {
+	return &CategoryResponse{
+		ID:             node.ID,
+		ParentID:       node.ParentID,
+		Name:           node.Name,
+		ItemCount:      node.ItemCount,
+		TotalItemCount: node.TotalItemCount,
+		Children:       ConvertCategoriesResponse(node.Children),
+	}
+}
+
+func ConvertCategoriesResponse(nodes []*services.CategoryNode) []*CategoryResponse {
+	list := make([]*CategoryResponse, len(nodes), len(nodes))
+	for i, node := range nodes {
+		list[i] = ConvertCategoryResponse(node)
+	}
+	return list
+}
@@ -0,0 +1,41 @@
+package admin_response
+
+import (
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+)
+
+type ItemImageResponse struct {
+	ID          uint   `json:"id"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+	Status      string `json:"status"`
+	URL         string `json:"url"`
+}
+
+type ItemImageUploadURLResponse struct {
+	Image     *ItemImageResponse `json:"image"`
+	UploadURL string             `json:"uploadUrl"`
+	// UploadHeaders must be sent with the PUT to UploadURL.
+	UploadHeaders map[string]string `json:"uploadHeaders"`
+	ExpiresAt     time.Time         `json:"expiresAt"`
+}
+
+func ConvertItemImageResponse(entity entities.ItemImage) *ItemImageResponse {
+	return &ItemImageResponse{
+		ID:          entity.ID,
+		ContentType: entity.ContentType,
+		Size:        entity.Size,
+		Status:      string(entity.Status),
+		URL:         entity.URL,
+	}
+}
+
+func ConvertItemImagesResponse(entities []entities.ItemImage) []*ItemImageResponse {
+	list := make([]*ItemImageResponse, len(entities), len(entities))
+	for i, entity := range entities {
+		list[i] = ConvertItemImageResponse(entity)
+	}
+	return list
+}
@@ -0,0 +1,43 @@
+package admin_response
+
+import (
+	"github.com/genpsp/go-app/services/src/services"
+)
+
+type ItemImportErrorResponse struct {
+	Row      int      `json:"row"`
+	Code     string   `json:"code,omitempty"`
+	Messages []string `json:"messages"`
+}
+
+type ItemImportResponse struct {
+	DryRun         bool                       `json:"dryRun"`
+	TotalRows      int                        `json:"totalRows"`
+	ValidRows      int                        `json:"validRows"`
+	RejectedRows   int                        `json:"rejectedRows"`
+	Created        int                        `json:"created"`
+	Updated        int                        `json:"updated"`
+	Errors         []*ItemImportErrorResponse `json:"errors"`
+	ErrorReportURL string                     `json:"errorReportUrl,omitempty"`
+}
+
+func ConvertItemImportResponse(result services.ItemImportResult) *ItemImportResponse {
+	errors := make([]*ItemImportErrorResponse, len(result.Errors), len(result.Errors))
+	for i, e := range result.Errors {
+		errors[i] = &ItemImportErrorResponse{
+			Row:      e.Row,
+			Code:     e.Code,
+			Messages: e.Messages,
+		}
+	}
+	return &ItemImportResponse{
+		DryRun:         result.DryRun,
+		TotalRows:      result.TotalRows,
+		ValidRows:      result.ValidRows,
+		RejectedRows:   result.RejectedRows,
+		Created:        result.Created,
+		Updated:        result.Updated,
+		Errors:         errors,
+		ErrorReportURL: result.ErrorReportURL,
+	}
+}
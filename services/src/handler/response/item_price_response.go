@@ -0,0 +1,33 @@
+package admin_response
+
+import (
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+)
+
+type ItemPriceResponse struct {
+	ID            uint       `json:"id"`
+	ItemID        uint       `json:"itemId"`
+	Price         int        `json:"price"`
+	EffectiveFrom time.Time  `json:"effectiveFrom"`
+	EffectiveTo   *time.Time `json:"effectiveTo"`
+}
+
+func ConvertItemPriceResponse(entity entities.ItemPrice) *ItemPriceResponse {
+	return &ItemPriceResponse{
+		ID:            entity.ID,
+		ItemID:        entity.ItemID,
+		Price:         entity.Price,
+		EffectiveFrom: entity.EffectiveFrom,
+		EffectiveTo:   entity.EffectiveTo,
+	}
+}
+
+func ConvertItemPricesResponse(entities []entities.ItemPrice) []*ItemPriceResponse {
+	list := make([]*ItemPriceResponse, len(entities), len(entities))
+	for i, entity := range entities {
+		list[i] = ConvertItemPriceResponse(entity)
+	}
+	return list
+}
@@ -1,13 +1,34 @@
 package admin_response
 
 import (
+	"strconv"
+
 	entities "github.com/genpsp/go-app/domain/entities"
 )
 
 type ItemResponse struct {
-	ID    uint   `json:"id"`
-	Name  string `json:"name"`
-	Price string `json:"price"`
+	ID         uint                    `json:"id"`
+	Code       *string                 `json:"code"`
+	Name       string                  `json:"name"`
+	Price      string                  `json:"price"`
+	Images     []*ItemImageResponse    `json:"images"`
+	Categories []*ItemCategoryResponse `json:"categories"`
+	Tags       []*TagResponse          `json:"tags"`
+	Variants   []*ItemVariantResponse  `json:"variants,omitempty"`
+}
+
+// ItemAsOfResponse is an item as it was at a past time. Only its versioned
+// fields are known, so the associations are left out.
+type ItemAsOfResponse struct {
+	ID    uint    `json:"id"`
+	Code  *string `json:"code"`
+	Name  string  `json:"name"`
+	Price string  `json:"price"`
+}
+
+type ItemCategoryResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
 }
 
 type ItemsResponse struct {
@@ -16,9 +37,35 @@ type ItemsResponse struct {
 
 func ConvertItemResponse(entity entities.Item) *ItemResponse {
 	return &ItemResponse{
-		ID:   entity.ID,
-		Name: entity.Name,
+		ID:         entity.ID,
+		Code:       entity.Code,
+		Name:       entity.Name,
+		Price:      strconv.Itoa(entity.Price),
+		Images:     ConvertItemImagesResponse(entity.Images),
+		Categories: ConvertItemCategoriesResponse(entity.Categories),
+		Tags:       ConvertTagsResponse(entity.Tags),
+		Variants:   ConvertItemVariantsResponse(entity.Variants),
+	}
+}
+
+func ConvertItemAsOfResponse(entity entities.Item) *ItemAsOfResponse {
+	return &ItemAsOfResponse{
+		ID:    entity.ID,
+		Code:  entity.Code,
+		Name:  entity.Name,
+		Price: strconv.Itoa(entity.Price),
+	}
+}
+
+func ConvertItemCategoriesResponse(entities []entities.Category) []*ItemCategoryResponse {
+	list := make([]*ItemCategoryResponse, len(entities), len(entities))
+	for i, entity := range entities {
+		list[i] = &ItemCategoryResponse{
+			ID:   entity.ID,
+			Name: entity.Name,
+		}
 	}
+	return list
 }
 
 func ConvertItemsResponse(entities *[]entities.Item) []*ItemResponse {
@@ -0,0 +1,35 @@
+package admin_response
+
+import (
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+)
+
+type ItemRevisionResponse struct {
+	Revision  int       `json:"revision"`
+	Action    string    `json:"action"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Deleted   bool      `json:"deleted"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func ConvertItemRevisionResponse(entity entities.ItemRevision) *ItemRevisionResponse {
+	return &ItemRevisionResponse{
+		Revision:  entity.Revision,
+		Action:    string(entity.Action),
+		Code:      entity.Code,
+		Name:      entity.Name,
+		Deleted:   entity.Deleted,
+		CreatedAt: entity.CreatedAt,
+	}
+}
+
+func ConvertItemRevisionsResponse(entities []entities.ItemRevision) []*ItemRevisionResponse {
+	list := make([]*ItemRevisionResponse, len(entities), len(entities))
+	for i, entity := range entities {
+		list[i] = ConvertItemRevisionResponse(entity)
+	}
+	return list
+}
@@ -0,0 +1,31 @@
+package admin_response
+
+import (
+	"github.com/genpsp/go-app/services/src/services"
+)
+
+type ItemSearchHitResponse struct {
+	*ItemResponse
+	Score     float64           `json:"score"`
+	Highlight map[string]string `json:"highlight"`
+}
+
+type ItemSearchResponse struct {
+	Total int64                    `json:"total"`
+	Items []*ItemSearchHitResponse `json:"items"`
+}
+
+func ConvertItemSearchResponse(result services.ItemSearchResult) *ItemSearchResponse {
+	list := make([]*ItemSearchHitResponse, len(result.Hits), len(result.Hits))
+	for i, hit := range result.Hits {
+		list[i] = &ItemSearchHitResponse{
+			ItemResponse: ConvertItemResponse(hit.Item),
+			Score:        hit.Score,
+			Highlight:    map[string]string{"name": hit.HighlightedName},
+		}
+	}
+	return &ItemSearchResponse{
+		Total: result.Total,
+		Items: list,
+	}
+}
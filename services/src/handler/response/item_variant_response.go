@@ -0,0 +1,40 @@
+package admin_response
+
+import (
+	entities "github.com/genpsp/go-app/domain/entities"
+)
+
+type ItemVariantResponse struct {
+	ID      uint              `json:"id"`
+	ItemID  uint              `json:"itemId"`
+	SKU     string            `json:"sku"`
+	Options map[string]string `json:"options"`
+	Price   *int              `json:"price"`
+	Barcode string            `json:"barcode"`
+}
+
+func ConvertItemVariantResponse(entity entities.ItemVariant) *ItemVariantResponse {
+	options := entity.Options
+	if options == nil {
+		options = entities.VariantOptions{}
+	}
+	return &ItemVariantResponse{
+		ID:      entity.ID,
+		ItemID:  entity.ItemID,
+		SKU:     entity.SKU,
+		Options: options,
+		Price:   entity.Price,
+		Barcode: entity.Barcode,
+	}
+}
+
+func ConvertItemVariantsResponse(entities []entities.ItemVariant) []*ItemVariantResponse {
+	if entities == nil {
+		return nil
+	}
+	list := make([]*ItemVariantResponse, len(entities), len(entities))
+	for i, entity := range entities {
+		list[i] = ConvertItemVariantResponse(entity)
+	}
+	return list
+}
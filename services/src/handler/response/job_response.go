@@ -0,0 +1,39 @@
+package admin_response
+
+import (
+	"encoding/json"
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+)
+
+type JobResponse struct {
+	ID          uint            `json:"id"`
+	Type        string          `json:"type"`
+	Status      string          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"maxAttempts"`
+	RunAt       time.Time       `json:"runAt"`
+	LastError   string          `json:"lastError,omitempty"`
+	Result      json.RawMessage `json:"result,omitempty"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	FinishedAt  *time.Time      `json:"finishedAt,omitempty"`
+}
+
+func ConvertJobResponse(entity entities.Job) *JobResponse {
+	response := &JobResponse{
+		ID:          entity.ID,
+		Type:        entity.Type,
+		Status:      string(entity.Status),
+		Attempts:    entity.Attempts,
+		MaxAttempts: entity.MaxAttempts,
+		RunAt:       entity.RunAt,
+		LastError:   entity.LastError,
+		CreatedAt:   entity.CreatedAt,
+		FinishedAt:  entity.FinishedAt,
+	}
+	if entity.Result != "" {
+		response.Result = json.RawMessage(entity.Result)
+	}
+	return response
+}
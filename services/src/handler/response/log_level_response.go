@@ -0,0 +1,17 @@
+package admin_response
+
+import (
+	"github.com/genpsp/go-app/pkg/logger"
+)
+
+type LogLevelsResponse struct {
+	Default  string            `json:"default"`
+	Packages map[string]string `json:"packages"`
+}
+
+func ConvertLogLevelsResponse(s logger.LevelSnapshot) *LogLevelsResponse {
+	return &LogLevelsResponse{
+		Default:  s.Default,
+		Packages: s.Packages,
+	}
+}
@@ -0,0 +1,74 @@
+package admin_response
+
+import (
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/services/src/services"
+)
+
+type StockLevelResponse struct {
+	OnHand    int64 `json:"onHand"`
+	Reserved  int64 `json:"reserved"`
+	Available int64 `json:"available"`
+}
+
+type StockMovementResponse struct {
+	ID            uint      `json:"id"`
+	ItemID        uint      `json:"itemId"`
+	VariantID     *uint     `json:"variantId"`
+	Type          string    `json:"type"`
+	Quantity      int       `json:"quantity"`
+	ReservationID *uint     `json:"reservationId"`
+	Note          string    `json:"note"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+type StockReservationResponse struct {
+	ID        uint      `json:"id"`
+	ItemID    uint      `json:"itemId"`
+	VariantID *uint     `json:"variantId"`
+	Quantity  int       `json:"quantity"`
+	Status    string    `json:"status"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+func ConvertStockLevelResponse(level services.StockLevel) *StockLevelResponse {
+	return &StockLevelResponse{
+		OnHand:    level.OnHand,
+		Reserved:  level.Reserved,
+		Available: level.Available,
+	}
+}
+
+func ConvertStockMovementResponse(entity entities.StockMovement) *StockMovementResponse {
+	return &StockMovementResponse{
+		ID:            entity.ID,
+		ItemID:        entity.ItemID,
+		VariantID:     entity.ItemVariantID,
+		Type:          string(entity.Type),
+		Quantity:      entity.Quantity,
+		ReservationID: entity.StockReservationID,
+		Note:          entity.Note,
+		CreatedAt:     entity.CreatedAt,
+	}
+}
+
+func ConvertStockMovementsResponse(entities []entities.StockMovement) []*StockMovementResponse {
+	list := make([]*StockMovementResponse, len(entities), len(entities))
+	for i, entity := range entities {
+		list[i] = ConvertStockMovementResponse(entity)
+	}
+	return list
+}
+
+func ConvertStockReservationResponse(entity entities.StockReservation) *StockReservationResponse {
+	return &StockReservationResponse{
+		ID:        entity.ID,
+		ItemID:    entity.ItemID,
+		VariantID: entity.ItemVariantID,
+		Quantity:  entity.Quantity,
+		Status:    string(entity.Status),
+		ExpiresAt: entity.ExpiresAt,
+	}
+}
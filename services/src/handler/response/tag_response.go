@@ -0,0 +1,25 @@
+package admin_response
+
+import (
+	entities "github.com/genpsp/go-app/domain/entities"
+)
+
+type TagResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+func ConvertTagResponse(entity entities.Tag) *TagResponse {
+	return &TagResponse{
+		ID:   entity.ID,
+		Name: entity.Name,
+	}
+}
+
+func ConvertTagsResponse(entities []entities.Tag) []*TagResponse {
+	list := make([]*TagResponse, len(entities), len(entities))
+	for i, entity := range entities {
+		list[i] = ConvertTagResponse(entity)
+	}
+	return list
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	admin_response "github.com/genpsp/go-app/services/src/handler/response"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/domain/enum"
+	repositories "github.com/genpsp/go-app/domain/repository"
+
+	"github.com/genpsp/go-app/pkg/logger"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"github.com/genpsp/go-app/pkg/utils"
+	"github.com/genpsp/go-app/services/src/handler/request"
+	"github.com/genpsp/go-app/services/src/services"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	Stock interface {
+		Level(c echo.Context) (err error)
+		Movements(c echo.Context) (err error)
+		Record(c echo.Context) (err error)
+		Reserve(c echo.Context) (err error)
+		Commit(c echo.Context) (err error)
+		Release(c echo.Context) (err error)
+	}
+	stockImpl struct {
+		ss services.StockService
+	}
+)
+
+func NewStock(s services.StockService) Stock {
+	return &stockImpl{
+		ss: s,
+	}
+}
+
+func stockKey(itemID int, variantID uint) repositories.StockKey {
+	key := repositories.StockKey{ItemID: uint(itemID)}
+	if variantID > 0 {
+		key.ItemVariantID = &variantID
+	}
+	return key
+}
+
+func (s *stockImpl) Level(c echo.Context) (err error) {
+	itemID, _ := strconv.Atoi(c.Param("itemId"))
+	gsr := new(request.GetStockRequest)
+	if _, err := utils.RequestValidate(c, gsr); err != "" {
+		log.Ctx(c.Request().Context()).Error("parse in GetStockRequest errors", zap.String("validation", err), logger.Body(gsr))
+		return appErr.AppStatusBadRequestError400
+	}
+	result, err := s.ss.Level(c.Request().Context(), stockKey(itemID, gsr.VariantID))
+	if err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusOK, admin_response.ConvertStockLevelResponse(*result))
+	return nil
+}
+
+func (s *stockImpl) Movements(c echo.Context) (err error) {
+	itemID, _ := strconv.Atoi(c.Param("itemId"))
+	gmr := new(request.GetStockMovementsRequest)
+	if _, err := utils.RequestValidate(c, gmr); err != "" {
+		log.Ctx(c.Request().Context()).Error("parse in GetStockMovementsRequest errors", zap.String("validation", err), logger.Body(gmr))
+		return appErr.AppStatusBadRequestError400
+	}
+	result, err := s.ss.Movements(c.Request().Context(), stockKey(itemID, gmr.VariantID), gmr.Limit, gmr.Offset)
+	if err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusOK, admin_response.ConvertStockMovementsResponse(*result))
+	return nil
+}
+
+func (s *stockImpl) Record(c echo.Context) (err error) {
+	itemID, _ := strconv.Atoi(c.Param("itemId"))
+	cmr := new(request.CreateStockMovementRequest)
+	if _, err := utils.RequestValidate(c, cmr); err != "" {
+		log.Ctx(c.Request().Context()).Error("parse in CreateStockMovementRequest errors", zap.String("validation", err), logger.Body(cmr))
+		return appErr.AppStatusBadRequestError400
+	}
+
+	key := stockKey(itemID, cmr.VariantID)
+	entity := &entities.StockMovement{
+		ItemID:        key.ItemID,
+		ItemVariantID: key.ItemVariantID,
+		Type:          enum.StockMovementType(cmr.Type),
+		Quantity:      cmr.Quantity,
+		Note:          cmr.Note,
+	}
+	if err = s.ss.Record(c.Request().Context(), entity); err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusCreated, admin_response.ConvertStockMovementResponse(*entity))
+	return nil
+}
+
+func (s *stockImpl) Reserve(c echo.Context) (err error) {
+	itemID, _ := strconv.Atoi(c.Param("itemId"))
+	crr := new(request.CreateStockReservationRequest)
+	if _, err := utils.RequestValidate(c, crr); err != "" {
+		log.Ctx(c.Request().Context()).Error("parse in CreateStockReservationRequest errors", zap.String("validation", err), logger.Body(crr))
+		return appErr.AppStatusBadRequestError400
+	}
+	ttl := time.Duration(crr.TTLSeconds) * time.Second
+	result, err := s.ss.Reserve(c.Request().Context(), stockKey(itemID, crr.VariantID), crr.Quantity, ttl)
+	if err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusCreated, admin_response.ConvertStockReservationResponse(*result))
+	return nil
+}
+
+func (s *stockImpl) Commit(c echo.Context) (err error) {
+	id, _ := strconv.Atoi(c.Param("reservationId"))
+	result, err := s.ss.Commit(c.Request().Context(), id)
+	if err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusCreated, admin_response.ConvertStockMovementResponse(*result))
+	return nil
+}
+
+func (s *stockImpl) Release(c echo.Context) (err error) {
+	id, _ := strconv.Atoi(c.Param("reservationId"))
+	if err = s.ss.Release(c.Request().Context(), id); err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusNoContent, nil)
+	return nil
+}
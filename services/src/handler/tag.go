@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	admin_response "github.com/genpsp/go-app/services/src/handler/response"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+
+	"github.com/genpsp/go-app/pkg/logger"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"github.com/genpsp/go-app/pkg/utils"
+	"github.com/genpsp/go-app/services/src/handler/request"
+	"github.com/genpsp/go-app/services/src/services"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	Tag interface {
+		Find(c echo.Context) (err error)
+		Create(c echo.Context) (err error)
+		Delete(c echo.Context) (err error)
+		AssignItem(c echo.Context) (err error)
+	}
+	tagImpl struct {
+		ts services.TagService
+	}
+)
+
+func NewTag(s services.TagService) Tag {
+	return &tagImpl{
+		ts: s,
+	}
+}
+
+func (s *tagImpl) Find(c echo.Context) (err error) {
+	result, err := s.ts.FindAll(c.Request().Context())
+	if err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusOK, admin_response.ConvertTagsResponse(*result))
+	return nil
+}
+
+func (s *tagImpl) Create(c echo.Context) (err error) {
+	ctr := new(request.CreateTagRequest)
+	if _, err := utils.RequestValidate(c, ctr); err != "" {
+		log.Ctx(c.Request().Context()).Error("parse in CreateTagRequest errors", zap.String("validation", err), logger.Body(ctr))
+		return appErr.AppStatusBadRequestError400
+	}
+
+	entity := &entities.Tag{
+		Name: ctr.Name,
+	}
+	if err = s.ts.Create(c.Request().Context(), entity); err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusCreated, admin_response.ConvertTagResponse(*entity))
+	return nil
+}
+
+func (s *tagImpl) Delete(c echo.Context) (err error) {
+	id, _ := strconv.Atoi(c.Param("tagId"))
+	if err = s.ts.Delete(c.Request().Context(), id); err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusNoContent, nil)
+	return nil
+}
+
+// AssignItem replaces the tags of the item, creating the unknown ones.
+func (s *tagImpl) AssignItem(c echo.Context) (err error) {
+	id, _ := strconv.Atoi(c.Param("itemId"))
+	atr := new(request.AssignItemTagsRequest)
+	if _, err := utils.RequestValidate(c, atr); err != "" {
+		log.Ctx(c.Request().Context()).Error("parse in AssignItemTagsRequest errors", zap.String("validation", err), logger.Body(atr))
+		return appErr.AppStatusBadRequestError400
+	}
+	if err = s.ts.AssignItem(c.Request().Context(), id, atr.Tags); err != nil {
+		return appErr.BindAppErrorWithServiceError(err)
+	}
+	c.JSON(http.StatusNoContent, nil)
+	return nil
+}
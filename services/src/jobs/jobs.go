@@ -0,0 +1,47 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/domain/enum"
+	repositories "github.com/genpsp/go-app/domain/repository"
+	"github.com/genpsp/go-app/pkg/configs"
+	"github.com/genpsp/go-app/pkg/logger"
+	"github.com/genpsp/go-app/pkg/storage"
+	"github.com/genpsp/go-app/services/src/services"
+	"gorm.io/gorm"
+)
+
+var log = logger.New("jobs")
+
+// Init registers the handler of every job type on r.
+func Init(r Runner, m *gorm.DB, st storage.Storage) {
+	cfg := configs.GetConfig()
+
+	// repository
+	itemRepo := repositories.NewItemRepository()
+	jobRepo := repositories.NewJobRepository()
+	itemPriceRepo := repositories.NewItemPriceRepository()
+
+	// service
+	jobService := services.NewJobService(jobRepo, m, cfg.Job.MaxAttempts)
+	itemPriceService := services.NewItemPriceService(itemPriceRepo, itemRepo, m)
+	itemExportService := services.NewItemExportService(itemRepo, jobService, itemPriceService, m, st, cfg.Storage.SignedURLExpire)
+
+	r.Register(enum.JobTypeItemExport, itemExport(itemExportService))
+}
+
+func itemExport(s services.ItemExportService) HandlerFunc {
+	return func(ctx context.Context, job *entities.Job) (interface{}, error) {
+		var opts services.ItemExportOptions
+		if err := json.Unmarshal([]byte(job.Payload), &opts); err != nil {
+			return nil, Permanent(err)
+		}
+		if err := opts.Validate(); err != nil {
+			return nil, Permanent(err)
+		}
+		return s.ExportToStorage(ctx, job.ID, opts)
+	}
+}
@@ -0,0 +1,240 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	repositories "github.com/genpsp/go-app/domain/repository"
+	jobcfg "github.com/genpsp/go-app/pkg/configs/job"
+	"github.com/genpsp/go-app/pkg/logger"
+	"github.com/genpsp/go-app/pkg/tracing"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type (
+	// HandlerFunc runs a job. The returned result is stored as JSON on success.
+	// ctx is cancelled when the lease is lost or Stop gives up waiting.
+	HandlerFunc func(ctx context.Context, job *entities.Job) (result interface{}, err error)
+
+	Runner interface {
+		Register(jobType string, h HandlerFunc)
+		Start()
+		Stop(ctx context.Context) (err error)
+	}
+
+	runnerImpl struct {
+		jr       repositories.JobRepository
+		master   *gorm.DB
+		cfg      jobcfg.Job
+		workerID string
+		handlers map[string]HandlerFunc
+
+		// stop is closed to stop leasing; ctx is cancelled to abort running jobs
+		stop     chan struct{}
+		stopOnce sync.Once
+		ctx      context.Context
+		cancel   context.CancelFunc
+		wg       sync.WaitGroup
+	}
+
+	permanentError struct {
+		err error
+	}
+)
+
+// Permanent marks err as not retryable; the job fails without further attempts.
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+func NewRunner(jobRepo repositories.JobRepository, m *gorm.DB, cfg jobcfg.Job) Runner {
+	hostname, _ := os.Hostname()
+	ctx, cancel := context.WithCancel(context.Background())
+	return &runnerImpl{
+		jr:       jobRepo,
+		master:   m,
+		cfg:      cfg,
+		workerID: fmt.Sprintf("%s-%s", hostname, uuid.New().String()[:8]),
+		handlers: map[string]HandlerFunc{},
+		stop:     make(chan struct{}),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Register must be called before Start.
+func (r *runnerImpl) Register(jobType string, h HandlerFunc) {
+	r.handlers[jobType] = h
+}
+
+func (r *runnerImpl) Start() {
+	log.Info("start job runner worker", zap.String("worker_id", r.workerID), zap.Int("concurrency", r.cfg.Concurrency))
+	for i := 0; i < r.cfg.Concurrency; i++ {
+		r.wg.Add(1)
+		go r.loop()
+	}
+}
+
+// Stop stops leasing new jobs and waits for running ones until ctx is done.
+// Jobs still running afterwards are cancelled and picked up again once their lease expires.
+func (r *runnerImpl) Stop(ctx context.Context) (err error) {
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+	r.stopOnce.Do(func() { close(r.stop) })
+	select {
+	case <-done:
+		r.cancel()
+		log.Info("stopping job runner worker", zap.String("worker_id", r.workerID))
+		return nil
+	case <-ctx.Done():
+		r.cancel()
+		return ctx.Err()
+	}
+}
+
+func (r *runnerImpl) loop() {
+	defer r.wg.Done()
+	for {
+		select {
+		case <-r.stop:
+			return
+		default:
+		}
+
+		job, err := r.lease()
+		if err != nil || job == nil {
+			select {
+			case <-r.stop:
+				return
+			case <-time.After(r.cfg.PollInterval):
+			}
+			continue
+		}
+		r.run(job)
+	}
+}
+
+func (r *runnerImpl) lease() (job *entities.Job, err error) {
+	err = r.master.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		n, err := r.jr.FailExhausted(tx, now)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			log.Error("jobs failed after their last lease expired", zap.Int64("count", n))
+		}
+		job, err = r.jr.Lease(tx, r.workerID, now, now.Add(r.cfg.LeaseDuration))
+		return err
+	})
+	if err != nil {
+		log.Error("occurred error when JobRunner call JobRepository Lease", zap.Error(err))
+	}
+	return
+}
+
+func (r *runnerImpl) run(job *entities.Job) {
+	ctx, cancel := context.WithCancel(r.ctx)
+	defer cancel()
+
+	heartbeatDone := make(chan struct{})
+	defer close(heartbeatDone)
+	go r.heartbeat(job, cancel, heartbeatDone)
+
+	// a job has no request to continue, so it starts its own trace
+	ctx, span := tracing.Start(ctx, "job "+string(job.Type), trace.WithAttributes(
+		attribute.Int64("job.id", int64(job.ID)),
+		attribute.Int("job.attempt", job.Attempts),
+	))
+	start := time.Now()
+	result, err := r.handle(ctx, job)
+	tracing.End(span, err)
+	if ctx.Err() != nil && err != nil {
+		// the lease was lost or the runner is stopping; leave the job for its next lease
+		log.Info("job interrupted", zap.Uint("job_id", job.ID), zap.String("job_type", string(job.Type)), zap.Int("attempt", job.Attempts))
+		return
+	}
+
+	if err == nil {
+		b, _ := json.Marshal(result)
+		if err := r.jr.Complete(r.master, job.ID, r.workerID, string(b), time.Now()); err != nil {
+			log.Error("occurred error when JobRunner call JobRepository Complete", zap.Error(err))
+		}
+		log.Info("job succeeded", zap.Uint("job_id", job.ID), zap.String("job_type", string(job.Type)), zap.Int("attempt", job.Attempts), logger.Latency(time.Since(start)))
+		return
+	}
+
+	var retryAt *time.Time
+	var permanent *permanentError
+	if !errors.As(err, &permanent) && job.Attempts < job.MaxAttempts {
+		t := time.Now().Add(r.backoff(job.Attempts))
+		retryAt = &t
+	}
+	if failErr := r.jr.Fail(r.master, job.ID, r.workerID, err.Error(), retryAt, time.Now()); failErr != nil {
+		log.Error("occurred error when JobRunner call JobRepository Fail", zap.Error(failErr))
+	}
+	log.Error("job failed", zap.Uint("job_id", job.ID), zap.String("job_type", string(job.Type)), zap.Int("attempt", job.Attempts), zap.Bool("retry", retryAt != nil), zap.Error(err))
+}
+
+func (r *runnerImpl) handle(ctx context.Context, job *entities.Job) (result interface{}, err error) {
+	h, ok := r.handlers[job.Type]
+	if !ok {
+		return nil, Permanent(fmt.Errorf("unknown job type: %s", job.Type))
+	}
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("job panic: %v", rec)
+		}
+	}()
+	return h(ctx, job)
+}
+
+func (r *runnerImpl) heartbeat(job *entities.Job, cancel context.CancelFunc, done <-chan struct{}) {
+	ticker := time.NewTicker(r.cfg.HeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			ok, err := r.jr.Heartbeat(r.master, job.ID, r.workerID, time.Now().Add(r.cfg.LeaseDuration))
+			if err != nil {
+				log.Error("occurred error when JobRunner call JobRepository Heartbeat", zap.Error(err))
+				continue
+			}
+			if !ok {
+				log.Error("job lease lost", zap.Uint("job_id", job.ID), zap.String("job_type", string(job.Type)))
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+// backoff returns BaseBackoff doubled for every previous attempt, capped at MaxBackoff.
+func (r *runnerImpl) backoff(attempts int) time.Duration {
+	d := r.cfg.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= r.cfg.MaxBackoff {
+			return r.cfg.MaxBackoff
+		}
+	}
+	return d
+}
@@ -0,0 +1,69 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/domain/repository/mock_repositories"
+	jobcfg "github.com/genpsp/go-app/pkg/configs/job"
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_Runner(t *testing.T) {
+	Convey("Runnerを初期化", t, func() {
+		r := NewRunner(nil, nil, jobcfg.Job{
+			BaseBackoff: 10 * time.Second,
+			MaxBackoff:  time.Minute,
+		}).(*runnerImpl)
+
+		Convey("試行回数に応じて待機時間が倍になり上限で止まる", func() {
+			So(r.backoff(1), ShouldEqual, 10*time.Second)
+			So(r.backoff(2), ShouldEqual, 20*time.Second)
+			So(r.backoff(3), ShouldEqual, 40*time.Second)
+			So(r.backoff(4), ShouldEqual, time.Minute)
+			So(r.backoff(10), ShouldEqual, time.Minute)
+		})
+		Convey("未登録のジョブはリトライしないエラーになる", func() {
+			_, err := r.handle(context.Background(), &entities.Job{Type: "unknown"})
+			var permanent *permanentError
+			So(errors.As(err, &permanent), ShouldBeTrue)
+		})
+		Convey("ハンドラのpanicはエラーとして扱う", func() {
+			r.Register("panic", func(ctx context.Context, job *entities.Job) (interface{}, error) {
+				panic("boom")
+			})
+			_, err := r.handle(context.Background(), &entities.Job{Type: "panic"})
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func Test_Runner_lease(t *testing.T) {
+	Convey("Runnerを初期化", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		db, mock, _ := mock_repositories.GetDBMock()
+		jr := mock_repositories.NewMockJobRepository(ctrl)
+		r := NewRunner(jr, db, jobcfg.Job{LeaseDuration: time.Minute}).(*runnerImpl)
+
+		Convey("試行回数を使い切ったジョブを失敗にしてから次のジョブを取る", func() {
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			job := &entities.Job{Type: "export", Attempts: 1}
+			gomock.InOrder(
+				jr.EXPECT().FailExhausted(gomock.Any(), gomock.Any()).Return(int64(1), nil),
+				jr.EXPECT().Lease(gomock.Any(), r.workerID, gomock.Any(), gomock.Any()).Return(job, nil),
+			)
+
+			leased, err := r.lease()
+			So(err, ShouldBeNil)
+			So(leased, ShouldEqual, job)
+			So(mock.ExpectationsWereMet(), ShouldBeNil)
+		})
+	})
+}
@@ -1,37 +1,153 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/genpsp/go-app/database/migration"
+	repositories "github.com/genpsp/go-app/domain/repository"
 	"github.com/genpsp/go-app/pkg/channel"
 	"github.com/genpsp/go-app/pkg/configs"
 	"github.com/genpsp/go-app/pkg/database"
 	"github.com/genpsp/go-app/pkg/firebase"
+	"github.com/genpsp/go-app/pkg/health"
 	"github.com/genpsp/go-app/pkg/logger"
+	"github.com/genpsp/go-app/pkg/metrics"
+	"github.com/genpsp/go-app/pkg/secrets"
 	"github.com/genpsp/go-app/pkg/server"
+	"github.com/genpsp/go-app/pkg/storage"
+	"github.com/genpsp/go-app/pkg/tracing"
 	"github.com/genpsp/go-app/services/src/handler"
+	"github.com/genpsp/go-app/services/src/jobs"
 	"github.com/genpsp/go-app/services/src/middlewares"
 	"github.com/genpsp/go-app/services/src/routes"
 	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
 )
 
+var log = logger.New("main")
+
 func main() {
 	configs.LoadConfig()
 	cfg := configs.GetConfig()
 	logger.LoadLogger(cfg.System.Env, cfg.Logger.LogLevel, cfg.Logger.LogEncoding)
+	log.Info("configuration loaded", zap.Any("config", cfg.Redacted()))
+
+	lifecycle := server.NewLifecycle(cfg.System.HttpContextTimeout)
+	// stopped last, so every other component can log its stop
+	lifecycle.Add(server.Component{Name: "logger", Stop: func(context.Context) error {
+		logger.Sync()
+		return nil
+	}})
 
-	db := database.Open(cfg.MySQL)
-	defer db.Close()
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Fatal("tracing initialize failed", zap.Error(err))
+	}
+	lifecycle.Add(server.Component{Name: "tracing", Stop: shutdownTracing})
 
-	httpServer := server.NewHttpServer()
-	authClient := firebase.NewFirebaseAppAdmin()
-	handler := handler.NewHandler(db.Master, authClient)
-	middleware := middlewares.NewMiddleware(authClient)
+	// config values may reference secrets, e.g. sm://<project>/db-password
+	resolver := secrets.NewResolver()
+	resolved, err := resolver.ResolveStruct(context.Background(), cfg)
+	if err != nil {
+		log.Fatal("secret resolution failed", zap.Error(err))
+	}
+	dbPassword := resolved["MySQL.MasterPassword"]
+	// only the database picks up a rotated value, on its next connection
+	refresher := secrets.NewRefresher(cfg.Secrets.RefreshInterval, map[string]*secrets.Secret{"MySQL.MasterPassword": dbPassword})
+	lifecycle.Add(server.Component{Name: "secret refresher", Start: refresher.Start, Stop: func(ctx context.Context) error {
+		if err := refresher.Stop(ctx); err != nil {
+			return err
+		}
+		return resolver.Close()
+	}})
 
-	httpServer.Handler = func(e *echo.Echo) {
-		routes.Init(handler, middleware, e)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		openDB := func() (*sql.DB, error) { return database.Open(cfg.MySQL, dbPassword.Value).Master.DB() }
+		if err := migrateCommand(cfg.Migration, openDB, os.Args[2:], os.Stdout); err != nil {
+			log.Fatal("migrate failed", zap.Error(err))
+		}
+		logger.Sync()
+		return
 	}
 
-	httpServer.Start()
+	db := database.Open(cfg.MySQL, dbPassword.Value)
+	if cfg.Migration.OnStartup {
+		sqlDB, err := db.Master.DB()
+		if err != nil {
+			log.Fatal("migrate on startup failed", zap.Error(err))
+		}
+		n, err := migration.NewMigrator(sqlDB, cfg.Migration.LockTimeout).Up(context.Background())
+		if err != nil {
+			log.Fatal("migrate on startup failed", zap.Error(err))
+		}
+		log.Info("applied migrations", zap.Int("count", n))
+	}
+	lifecycle.Add(server.Component{Name: "mysql", Stop: func(context.Context) error { return db.Close() }})
+
+	store, err := storage.New(context.Background(), cfg.Storage, cfg.GCS)
+	if err != nil {
+		log.Fatal("storage initialize failed", zap.Error(err))
+	}
+
+	// "worker" runs the job runner without the HTTP server
+	worker := len(os.Args) > 1 && os.Args[1] == "worker"
+
+	if cfg.Metrics.Enabled || cfg.Admin.Enabled {
+		adminServer := server.NewAdminServer()
+		if cfg.Admin.Enabled {
+			adminServer.Handler = func(e *echo.Echo) {
+				routes.InitAdmin(handler.NewLogLevel(), e)
+			}
+		}
+		lifecycle.Add(server.Component{Name: "admin server", Start: adminServer.Start, Stop: adminServer.Stop})
+	}
+
+	runner := jobs.NewRunner(repositories.NewJobRepository(), db.Master, cfg.Job)
+	jobs.Init(runner, db.Master, store)
+	if worker || cfg.Job.Embedded {
+		lifecycle.Add(server.Component{Name: "job runner", Start: func() error {
+			runner.Start()
+			return nil
+		}, Stop: runner.Stop})
+	}
+
+	var readiness *health.Health
+	if !worker {
+		readiness = health.New(cfg.Health.Timeout)
+		readiness.Register("mysql", db.Ping)
+		if cfg.Health.CheckFirebase {
+			readiness.Register("firebase", health.HTTPCheck(&http.Client{}, cfg.Health.FirebaseURL))
+		}
+
+		httpServer := server.NewHttpServer()
+		httpServer.Health = readiness
+		authClient := metrics.InstrumentAuthAdmin(firebase.NewFirebaseAppAdmin(cfg.Firebase.ClientOptions()...))
+		handler := handler.NewHandler(db.Master, authClient, store)
+		middleware := middlewares.NewMiddleware(authClient, db.Master)
+
+		httpServer.Handler = func(e *echo.Echo) {
+			routes.Init(handler, middleware, e)
+		}
+		lifecycle.Add(server.Component{Name: "http server", Start: httpServer.Start, Stop: httpServer.Stop})
+	}
+
+	if err := lifecycle.Start(); err != nil {
+		log.Fatal("startup failed", zap.Error(err))
+	}
 
 	signal := <-channel.Quit()
-	defer httpServer.Stop(signal)
+	log.Info("shutting down", zap.Stringer("signal", signal), zap.Int("exit_code", channel.GetExitCode(signal)))
+	if readiness != nil {
+		// fail readiness first so the load balancer stops routing before the server stops
+		readiness.Drain()
+		log.Info("draining http server", zap.Duration("delay", cfg.Health.DrainDelay))
+		time.Sleep(cfg.Health.DrainDelay)
+	}
+	if err := lifecycle.Stop(); err != nil {
+		log.Error("shutdown incomplete", zap.Error(err))
+	}
 }
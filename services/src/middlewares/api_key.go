@@ -0,0 +1,50 @@
+package middlewares
+
+import (
+	"crypto/sha256"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	APIKey interface {
+		Validate() echo.MiddlewareFunc
+	}
+
+	apiKeyImpl struct {
+		// keys holds the sha256 of the accepted keys, so looking one up
+		// does not compare the credential byte by byte.
+		keys map[[sha256.Size]byte]struct{}
+	}
+)
+
+// NewAPIKey accepts the comma separated keys.
+func NewAPIKey(keys string) APIKey {
+	s := &apiKeyImpl{keys: map[[sha256.Size]byte]struct{}{}}
+	for _, key := range strings.Split(keys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			s.keys[sha256.Sum256([]byte(key))] = struct{}{}
+		}
+	}
+	return s
+}
+
+// Validate sets ContextKeyAPIKey when the request carries an accepted
+// X-API-Key. Other keys are ignored, the request is then limited by IP.
+func (s *apiKeyImpl) Validate() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(HeaderAPIKey)
+			if key == "" {
+				return next(c)
+			}
+			if _, ok := s.keys[sha256.Sum256([]byte(key))]; ok {
+				c.Set(ContextKeyAPIKey, key)
+			} else {
+				log.Ctx(c.Request().Context()).Info("unknown api key")
+			}
+			return next(c)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/genpsp/go-app/domain/enum"
 	"github.com/genpsp/go-app/pkg/logger"
+	"github.com/genpsp/go-app/pkg/tracing"
 	"github.com/genpsp/go-app/services/src/services"
 
 	appErr "github.com/genpsp/go-app/pkg/server/error"
@@ -37,11 +38,19 @@ func (s *authImpl) RequireJWTAuthorizationHeader() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			firebaseJWT := c.Request().Header.Get("Authorization")
+			// AuthService verifies the ID token with Firebase
+			_, span := tracing.Start(c.Request().Context(), "AuthService.Authorize")
 			token, err := s.as.Authorize(firebaseJWT)
+			tracing.End(span, err)
 			if err != nil {
 				return appErr.BindAppErrorWithServiceError(err)
 			}
 
+			// every later log line of the request carries the user
+			req := c.Request()
+			ctx := req.Context()
+			c.SetRequest(req.WithContext(logger.NewContext(ctx, logger.FromContext(ctx).With(logger.UID(token.UID)))))
+
 			jc := &JWTContext{c, &jwt.Token{UID: token.UID}}
 
 			c.Set("token", jc.Token)
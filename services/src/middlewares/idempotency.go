@@ -0,0 +1,118 @@
+package middlewares
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/genpsp/go-app/pkg/server/jwt"
+	"github.com/genpsp/go-app/services/src/services"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+type (
+	Idempotency interface {
+		Handle() echo.MiddlewareFunc
+	}
+
+	idempotencyImpl struct {
+		is services.IdempotencyService
+	}
+
+	// idempotencyRecorder copies the response body while it is written to the client.
+	idempotencyRecorder struct {
+		http.ResponseWriter
+		body bytes.Buffer
+	}
+)
+
+func NewIdempotency(s services.IdempotencyService) Idempotency {
+	return &idempotencyImpl{
+		is: s,
+	}
+}
+
+func (w *idempotencyRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// Handle makes mutations sent with an Idempotency-Key header safe to retry:
+// the first response is stored and replayed, reusing the key for another body
+// fails with 422, and a retry racing the first request fails with 409.
+// Keys are scoped to the authenticated user, so it runs after the auth middleware.
+func (s *idempotencyImpl) Handle() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			key := req.Header.Get(HeaderIdempotencyKey)
+			if key == "" || req.Method == http.MethodGet || req.Method == http.MethodHead || req.Method == http.MethodOptions {
+				return next(c)
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				return echo.NewHTTPError(http.StatusBadRequest, "Idempotency-Key is too long")
+			}
+
+			body, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "failed to read request body")
+			}
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+			var principal string
+			if token, ok := c.Get("token").(*jwt.Token); ok && token != nil {
+				principal = token.UID
+			}
+			fingerprint := services.IdempotencyFingerprint(req.Method, req.URL.RequestURI(), body)
+
+			record, replay, err := s.is.Begin(c.Request().Context(), principal, key, fingerprint)
+			switch {
+			case errors.Is(err, services.ErrIdempotencyKeyMismatch):
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, "Idempotency-Key was used for a different request")
+			case errors.Is(err, services.ErrIdempotencyKeyInFlight):
+				return echo.NewHTTPError(http.StatusConflict, "a request with the same Idempotency-Key is in progress")
+			case err != nil:
+				return err
+			}
+
+			if replay {
+				c.Response().Header().Set(HeaderIdempotentReplayed, "true")
+				if record.ResponseBody == nil {
+					return c.NoContent(record.ResponseStatus)
+				}
+				return c.Blob(record.ResponseStatus, record.ContentType, record.ResponseBody)
+			}
+
+			recorder := &idempotencyRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+			// render handler errors here so their response is stored like any other
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+
+			status := c.Response().Status
+			if status >= http.StatusInternalServerError {
+				if err := s.is.Release(c.Request().Context(), record); err != nil {
+					log.Ctx(c.Request().Context()).Error("idempotency key release error", zap.Error(err))
+				}
+				return nil
+			}
+			var stored []byte
+			if recorder.body.Len() > 0 {
+				stored = recorder.body.Bytes()
+			}
+			if err := s.is.Complete(c.Request().Context(), record, status, c.Response().Header().Get(echo.HeaderContentType), stored); err != nil {
+				log.Ctx(c.Request().Context()).Error("idempotency key complete error", zap.Error(err))
+			}
+			return nil
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/pkg/configs"
+	"github.com/genpsp/go-app/pkg/logger"
+	"github.com/genpsp/go-app/services/src/services"
+	mock_services "github.com/genpsp/go-app/services/src/services/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_Idempotency(t *testing.T) {
+	Convey("Idempotencyを初期化", t, func() {
+		configs.TestLoadConfig()
+		cfg := configs.GetConfig()
+		logger.LoadLogger(cfg.System.Env, cfg.Logger.LogLevel, cfg.Logger.LogEncoding)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		is := mock_services.NewMockIdempotencyService(ctrl)
+		e := echo.New()
+		calls := 0
+		e.POST("/items", func(c echo.Context) error {
+			calls++
+			return c.JSON(http.StatusCreated, map[string]int{"id": 1})
+		}, NewIdempotency(is).Handle())
+
+		newRequest := func(body string) *http.Request {
+			req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(HeaderIdempotencyKey, "key-1")
+			return req
+		}
+		fingerprint := services.IdempotencyFingerprint(http.MethodPost, "/items", []byte(`{"name":"a"}`))
+
+		Convey("初回のレスポンスを保存する", func() {
+			record := &entities.IdempotencyKey{ID: 1}
+			is.EXPECT().Begin(gomock.Any(), "", "key-1", fingerprint).Return(record, false, nil)
+			is.EXPECT().Complete(gomock.Any(), record, http.StatusCreated, echo.MIMEApplicationJSONCharsetUTF8, []byte("{\"id\":1}\n")).Return(nil)
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, newRequest(`{"name":"a"}`))
+			So(rec.Code, ShouldEqual, http.StatusCreated)
+			So(calls, ShouldEqual, 1)
+		})
+		Convey("再送時は保存したレスポンスを返す", func() {
+			record := &entities.IdempotencyKey{ID: 1, ResponseStatus: http.StatusCreated, ContentType: echo.MIMEApplicationJSON, ResponseBody: []byte(`{"id":1}`)}
+			is.EXPECT().Begin(gomock.Any(), "", "key-1", fingerprint).Return(record, true, nil)
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, newRequest(`{"name":"a"}`))
+			So(rec.Code, ShouldEqual, http.StatusCreated)
+			So(rec.Body.String(), ShouldEqual, `{"id":1}`)
+			So(rec.Header().Get(HeaderIdempotentReplayed), ShouldEqual, "true")
+			So(calls, ShouldEqual, 0)
+		})
+		Convey("異なるリクエストでのキーの再利用は422を返す", func() {
+			is.EXPECT().Begin(gomock.Any(), "", "key-1", gomock.Any()).Return(nil, false, services.ErrIdempotencyKeyMismatch)
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, newRequest(`{"name":"b"}`))
+			So(rec.Code, ShouldEqual, http.StatusUnprocessableEntity)
+			So(calls, ShouldEqual, 0)
+		})
+		Convey("処理中の重複リクエストは409を返す", func() {
+			is.EXPECT().Begin(gomock.Any(), "", "key-1", fingerprint).Return(nil, false, services.ErrIdempotencyKeyInFlight)
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, newRequest(`{"name":"a"}`))
+			So(rec.Code, ShouldEqual, http.StatusConflict)
+		})
+		Convey("キーがなければそのまま処理する", func() {
+			req := newRequest(`{"name":"a"}`)
+			req.Header.Del(HeaderIdempotencyKey)
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			So(rec.Code, ShouldEqual, http.StatusCreated)
+			So(calls, ShouldEqual, 1)
+		})
+	})
+}
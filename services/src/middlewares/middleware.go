@@ -1,21 +1,46 @@
 package middlewares
 
 import (
+	repositories "github.com/genpsp/go-app/domain/repository"
+	"github.com/genpsp/go-app/pkg/configs"
 	"github.com/genpsp/go-app/pkg/firebase"
+	"github.com/genpsp/go-app/pkg/logger"
+	"github.com/genpsp/go-app/pkg/ratelimit"
 	"github.com/genpsp/go-app/services/src/services"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
+var log = logger.New("middlewares")
+
 type (
 	Middleware struct {
-		Auth Auth
+		APIKey      APIKey
+		Auth        Auth
+		Idempotency Idempotency
+		RateLimit   RateLimit
 	}
 )
 
-func NewMiddleware(authClient firebase.AuthAdmin) Middleware {
+func NewMiddleware(authClient firebase.AuthAdmin, m *gorm.DB) Middleware {
+	cfg := configs.GetConfig()
+
+	// repository
+	idempotencyKeyRepo := repositories.NewIdempotencyKeyRepository()
+
 	// service
 	authService := services.NewAuthService(&authClient)
+	idempotencyService := services.NewIdempotencyService(idempotencyKeyRepo, m, cfg.Idempotency.TTL, cfg.Idempotency.LockTimeout)
+
+	limiter, err := ratelimit.New(cfg.RateLimit, m)
+	if err != nil {
+		log.Fatal("rate limiter initialize failed", zap.Error(err))
+	}
 
 	return Middleware{
-		Auth: NewAuth(authService),
+		APIKey:      NewAPIKey(cfg.Security.APIKeys),
+		Auth:        NewAuth(authService),
+		Idempotency: NewIdempotency(idempotencyService),
+		RateLimit:   NewRateLimit(cfg.RateLimit, limiter),
 	}
 }
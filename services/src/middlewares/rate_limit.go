@@ -0,0 +1,132 @@
+package middlewares
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	ratelimitcfg "github.com/genpsp/go-app/pkg/configs/ratelimit"
+	"github.com/genpsp/go-app/pkg/ratelimit"
+	"github.com/genpsp/go-app/pkg/server/jwt"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+const (
+	HeaderAPIKey = "X-API-Key"
+	// ContextKeyAPIKey holds the X-API-Key once APIKey.Validate accepted it.
+	ContextKeyAPIKey = "apiKey"
+
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+	HeaderRetryAfter         = "Retry-After"
+)
+
+type (
+	RateLimit interface {
+		HandleBeforeAuth() echo.MiddlewareFunc
+		Handle() echo.MiddlewareFunc
+	}
+
+	rateLimitImpl struct {
+		cfg     ratelimitcfg.RateLimit
+		limiter ratelimit.Limiter
+	}
+)
+
+func NewRateLimit(cfg ratelimitcfg.RateLimit, l ratelimit.Limiter) RateLimit {
+	return &rateLimitImpl{
+		cfg:     cfg,
+		limiter: l,
+	}
+}
+
+// HandleBeforeAuth limits requests per validated X-API-Key, else per client
+// IP, with the BeforeAuth limit. Run it after APIKey.Validate and before the
+// auth middleware, so invalid tokens are throttled without a Firebase call.
+func (s *rateLimitImpl) HandleBeforeAuth() echo.MiddlewareFunc {
+	return s.handle(func(c echo.Context) (string, ratelimit.Limit) {
+		return "before_auth|" + rateLimitPrincipal(c), toLimit(s.cfg.BeforeAuth)
+	})
+}
+
+// Handle limits requests per principal: the authenticated user, else the
+// validated X-API-Key, else the client IP. Run it after the auth middleware so
+// users are not all counted by IP. An X-API-Key nobody validated counts by IP,
+// or a client could get a fresh budget by sending a new random key.
+//
+// A principal override in the config is one budget across every route.
+// Otherwise a route with its own limit gets its own budget and every other
+// route shares the default one.
+func (s *rateLimitImpl) Handle() echo.MiddlewareFunc {
+	return s.handle(func(c echo.Context) (string, ratelimit.Limit) {
+		return s.resolve(c.Request().Method+" "+c.Path(), rateLimitPrincipal(c))
+	})
+}
+
+// handle counts the request against the budget resolve picks.
+// Store errors are logged and the request is let through.
+func (s *rateLimitImpl) handle(resolve func(c echo.Context) (string, ratelimit.Limit)) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !s.cfg.Enabled {
+				return next(c)
+			}
+
+			key, limit := resolve(c)
+			res, err := s.limiter.Allow(c.Request().Context(), key, limit)
+			if err != nil {
+				log.Ctx(c.Request().Context()).Error("rate limit error", zap.String("key", key), zap.Error(err))
+				return next(c)
+			}
+
+			h := c.Response().Header()
+			h.Set(HeaderRateLimitLimit, strconv.Itoa(res.Limit))
+			h.Set(HeaderRateLimitRemaining, strconv.Itoa(res.Remaining))
+			h.Set(HeaderRateLimitReset, strconv.Itoa(ceilSeconds(res.Reset)))
+			if !res.Allowed {
+				h.Set(HeaderRetryAfter, strconv.Itoa(ceilSeconds(res.RetryAfter)))
+				return echo.NewHTTPError(http.StatusTooManyRequests, "rate limit exceeded")
+			}
+			return next(c)
+		}
+	}
+}
+
+func (s *rateLimitImpl) resolve(route, principal string) (string, ratelimit.Limit) {
+	if l, ok := s.cfg.Principals[principal]; ok {
+		return principal, toLimit(l)
+	}
+	if l, ok := s.cfg.Routes[route]; ok {
+		return route + "|" + principal, toLimit(l)
+	}
+	return principal, toLimit(s.cfg.Default)
+}
+
+func rateLimitPrincipal(c echo.Context) string {
+	if token, ok := c.Get("token").(*jwt.Token); ok && token != nil && token.UID != "" {
+		return "user:" + token.UID
+	}
+	if key, ok := c.Get(ContextKeyAPIKey).(string); ok && key != "" {
+		// keys are stored hashed so the store never holds the credential itself
+		sum := sha256.Sum256([]byte(key))
+		return "apikey:" + hex.EncodeToString(sum[:8])
+	}
+	return "ip:" + c.RealIP()
+}
+
+func toLimit(l ratelimitcfg.Limit) ratelimit.Limit {
+	return ratelimit.Limit{
+		Requests: l.Requests,
+		Window:   l.Window,
+		Burst:    l.Burst,
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
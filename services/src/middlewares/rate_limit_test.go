@@ -0,0 +1,132 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/genpsp/go-app/pkg/configs"
+	ratelimitcfg "github.com/genpsp/go-app/pkg/configs/ratelimit"
+	"github.com/genpsp/go-app/pkg/logger"
+	"github.com/genpsp/go-app/pkg/ratelimit"
+	"github.com/genpsp/go-app/pkg/server/jwt"
+	"github.com/labstack/echo/v4"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_RateLimit(t *testing.T) {
+	Convey("RateLimitを初期化", t, func() {
+		configs.TestLoadConfig()
+		cfg := configs.GetConfig()
+		logger.LoadLogger(cfg.System.Env, cfg.Logger.LogLevel, cfg.Logger.LogEncoding)
+
+		limiter, _ := ratelimit.NewLimiter(ratelimitcfg.AlgorithmSlidingWindow, ratelimit.NewMemoryStore())
+		rlCfg := ratelimitcfg.RateLimit{
+			Enabled: true,
+			Default: ratelimitcfg.Limit{Requests: 2, Window: time.Hour},
+			Routes: map[string]ratelimitcfg.Limit{
+				"POST /items": {Requests: 1, Window: time.Hour},
+			},
+			Principals: map[string]ratelimitcfg.Limit{
+				"user:vip": {Requests: 3, Window: time.Hour},
+			},
+		}
+
+		e := echo.New()
+		ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+		setUser := func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				if uid := c.Request().Header.Get("X-Test-UID"); uid != "" {
+					c.Set("token", &jwt.Token{UID: uid})
+				}
+				return next(c)
+			}
+		}
+		apiKey := NewAPIKey("valid, other").Validate()
+		rl := NewRateLimit(rlCfg, limiter).Handle()
+		e.GET("/items", ok, apiKey, setUser, rl)
+		e.POST("/items", ok, apiKey, setUser, rl)
+
+		doWithKey := func(method, uid, ip, apiKey string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(method, "/items", nil)
+			req.RemoteAddr = ip + ":1234"
+			if uid != "" {
+				req.Header.Set("X-Test-UID", uid)
+			}
+			if apiKey != "" {
+				req.Header.Set(HeaderAPIKey, apiKey)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			return rec
+		}
+		do := func(method, uid, ip string) *httptest.ResponseRecorder {
+			return doWithKey(method, uid, ip, "")
+		}
+
+		Convey("既定の上限を超えると429とRetry-Afterを返す", func() {
+			So(do(http.MethodGet, "u1", "10.0.0.1").Header().Get(HeaderRateLimitRemaining), ShouldEqual, "1")
+			So(do(http.MethodGet, "u1", "10.0.0.1").Code, ShouldEqual, http.StatusOK)
+
+			rec := do(http.MethodGet, "u1", "10.0.0.1")
+			So(rec.Code, ShouldEqual, http.StatusTooManyRequests)
+			So(rec.Header().Get(HeaderRateLimitLimit), ShouldEqual, "2")
+			So(rec.Header().Get(HeaderRateLimitRemaining), ShouldEqual, "0")
+			So(rec.Header().Get(HeaderRetryAfter), ShouldNotBeEmpty)
+
+			Convey("別のユーザーは影響を受けない", func() {
+				So(do(http.MethodGet, "u2", "10.0.0.1").Code, ShouldEqual, http.StatusOK)
+			})
+		})
+		Convey("ルートごとの上限は別に数える", func() {
+			So(do(http.MethodPost, "u1", "10.0.0.1").Code, ShouldEqual, http.StatusOK)
+			So(do(http.MethodPost, "u1", "10.0.0.1").Code, ShouldEqual, http.StatusTooManyRequests)
+			So(do(http.MethodGet, "u1", "10.0.0.1").Code, ShouldEqual, http.StatusOK)
+		})
+		Convey("ユーザーごとの上限はルートの上限より優先する", func() {
+			for i := 0; i < 3; i++ {
+				So(do(http.MethodPost, "vip", "10.0.0.1").Code, ShouldEqual, http.StatusOK)
+			}
+			So(do(http.MethodGet, "vip", "10.0.0.1").Code, ShouldEqual, http.StatusTooManyRequests)
+		})
+		Convey("未認証のリクエストはIPで数える", func() {
+			So(do(http.MethodGet, "", "10.0.0.2").Code, ShouldEqual, http.StatusOK)
+			So(do(http.MethodGet, "", "10.0.0.2").Code, ShouldEqual, http.StatusOK)
+			So(do(http.MethodGet, "", "10.0.0.2").Code, ShouldEqual, http.StatusTooManyRequests)
+			So(do(http.MethodGet, "", "10.0.0.3").Code, ShouldEqual, http.StatusOK)
+		})
+		Convey("検証されていないAPIキーはIPで数える", func() {
+			So(doWithKey(http.MethodGet, "", "10.0.0.4", "random-1").Code, ShouldEqual, http.StatusOK)
+			So(doWithKey(http.MethodGet, "", "10.0.0.4", "random-2").Code, ShouldEqual, http.StatusOK)
+			So(doWithKey(http.MethodGet, "", "10.0.0.4", "random-3").Code, ShouldEqual, http.StatusTooManyRequests)
+		})
+		Convey("検証されたAPIキーはキーで数える", func() {
+			So(doWithKey(http.MethodGet, "", "10.0.0.5", "valid").Code, ShouldEqual, http.StatusOK)
+			So(doWithKey(http.MethodGet, "", "10.0.0.5", "valid").Code, ShouldEqual, http.StatusOK)
+			So(doWithKey(http.MethodGet, "", "10.0.0.5", "").Code, ShouldEqual, http.StatusOK)
+		})
+		Convey("認証前の上限は認証に失敗するリクエストも数える", func() {
+			rlCfg.BeforeAuth = ratelimitcfg.Limit{Requests: 1, Window: time.Hour}
+			verified := 0
+			reject := func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					verified++
+					return echo.NewHTTPError(http.StatusUnauthorized)
+				}
+			}
+			e.GET("/auth", ok, apiKey, NewRateLimit(rlCfg, limiter).HandleBeforeAuth(), reject)
+
+			serve := func(ip string) int {
+				req := httptest.NewRequest(http.MethodGet, "/auth", nil)
+				req.RemoteAddr = ip + ":1234"
+				rec := httptest.NewRecorder()
+				e.ServeHTTP(rec, req)
+				return rec.Code
+			}
+			So(serve("10.0.0.6"), ShouldEqual, http.StatusUnauthorized)
+			So(serve("10.0.0.6"), ShouldEqual, http.StatusTooManyRequests)
+			So(verified, ShouldEqual, 1)
+		})
+	})
+}
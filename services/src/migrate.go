@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/genpsp/go-app/database/migration"
+	migrationcfg "github.com/genpsp/go-app/pkg/configs/migration"
+)
+
+const migrateUsage = "usage: migrate up | down [N] | status | redo | drift | create NAME"
+
+// migrateCommand runs "migrate <subcommand>". openDB is only called by the
+// subcommands that need the database.
+func migrateCommand(cfg migrationcfg.Migration, openDB func() (*sql.DB, error), args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	if args[0] == "create" {
+		if len(args) != 2 {
+			return errors.New(migrateUsage)
+		}
+		path, err := migration.Create(cfg.Dir, args[1], time.Now())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "created %s\n", path)
+		return nil
+	}
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	m := migration.NewMigrator(db, cfg.LockTimeout)
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		n, err := m.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "applied %d migrations\n", n)
+	case "down":
+		max := 1
+		if len(args) > 1 {
+			if max, err = strconv.Atoi(args[1]); err != nil {
+				return errors.New(migrateUsage)
+			}
+		}
+		n, err := m.Down(ctx, max)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "rolled back %d migrations\n", n)
+	case "redo":
+		id, err := m.Redo(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "redone %s\n", id)
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "MIGRATION\tAPPLIED")
+		for _, s := range statuses {
+			applied := "pending"
+			if s.AppliedAt != nil {
+				applied = s.AppliedAt.Format(time.RFC3339)
+			}
+			if s.Unknown {
+				applied += " (not in this binary)"
+			}
+			fmt.Fprintf(w, "%s\t%s\n", s.ID, applied)
+		}
+		return w.Flush()
+	case "drift":
+		drifts, err := migration.Check(ctx, db, migration.Models...)
+		if err != nil {
+			return err
+		}
+		for _, d := range drifts {
+			fmt.Fprintln(out, d)
+		}
+		if len(drifts) > 0 {
+			return fmt.Errorf("schema drifts from the entities in %d places", len(drifts))
+		}
+		fmt.Fprintln(out, "schema matches the entities")
+	default:
+		return errors.New(migrateUsage)
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package routes
 
 import (
+	"net/http"
+
 	"github.com/genpsp/go-app/services/src/handler"
 	"github.com/genpsp/go-app/services/src/middlewares"
 	"github.com/labstack/echo/v4"
@@ -9,7 +11,78 @@ import (
 func Init(handler handler.Handler, m middlewares.Middleware, e *echo.Echo) {
 	admin := e.Group("/app")
 
+	// callers are limited by API key or IP before auth, so rejected tokens
+	// are throttled too, and by user after it
+	auth := []echo.MiddlewareFunc{
+		m.APIKey.Validate(),
+		m.RateLimit.HandleBeforeAuth(),
+		m.Auth.RequireJWTAuthorizationHeader(),
+		m.RateLimit.Handle(),
+	}
+
 	item := admin.Group("/item")
-	item.GET("", handler.Enum.GetEnums, m.Auth.RequireJWTAuthorizationHeader())
+	item.GET("", handler.Enum.GetEnums, auth...)
+
+	// only the JSON mutations replay a stored response; uploads and imports
+	// are too large to buffer and store
+	idempotent := m.Idempotency.Handle()
+	items := admin.Group("/items", auth...)
+	items.GET("", handler.Item.Find)
+	items.POST("", handler.Item.Create, idempotent)
+	items.GET("/export", handler.ItemExport.Export)
+	items.GET("/export/:jobId", handler.ItemExport.Download)
+	items.POST("/import", handler.ItemImport.Import)
+	items.GET("/search", handler.ItemSearch.Search)
+	items.GET("/:itemId", handler.Item.FindByID)
+	items.PUT("/:itemId", handler.Item.Update, idempotent)
+	items.DELETE("/:itemId", handler.Item.Delete, idempotent)
+	items.POST("/:itemId/images", handler.ItemImage.Create)
+	items.POST("/:itemId/images/:imageId/confirm", handler.ItemImage.Confirm)
+	items.PUT("/:itemId/categories", handler.Category.AssignItem, idempotent)
+	items.PUT("/:itemId/tags", handler.Tag.AssignItem, idempotent)
+	items.GET("/:itemId/variants", handler.ItemVariant.Find)
+	items.POST("/:itemId/variants", handler.ItemVariant.Create, idempotent)
+	items.GET("/:itemId/variants/:variantId", handler.ItemVariant.FindByID)
+	items.PUT("/:itemId/variants/:variantId", handler.ItemVariant.Update, idempotent)
+	items.DELETE("/:itemId/variants/:variantId", handler.ItemVariant.Delete, idempotent)
+	items.GET("/:itemId/revisions", handler.Item.Revisions)
+	items.POST("/:itemId/revisions/:revision/revert", handler.Item.Revert, idempotent)
+	items.GET("/:itemId/prices", handler.ItemPrice.Find)
+	items.POST("/:itemId/prices", handler.ItemPrice.Schedule, idempotent)
+	items.GET("/:itemId/stock", handler.Stock.Level)
+	items.GET("/:itemId/stock/movements", handler.Stock.Movements)
+	items.POST("/:itemId/stock/movements", handler.Stock.Record, idempotent)
+	items.POST("/:itemId/stock/reservations", handler.Stock.Reserve, idempotent)
+
+	reservations := admin.Group("/stock/reservations", auth...)
+	reservations.POST("/:reservationId/commit", handler.Stock.Commit)
+	reservations.DELETE("/:reservationId", handler.Stock.Release)
+
+	categories := admin.Group("/categories", auth...)
+	categories.GET("", handler.Category.Find)
+	categories.POST("", handler.Category.Create)
+	categories.PUT("/:categoryId", handler.Category.Update)
+	categories.DELETE("/:categoryId", handler.Category.Delete)
+
+	tags := admin.Group("/tags", auth...)
+	tags.GET("", handler.Tag.Find)
+	tags.POST("", handler.Tag.Create)
+	tags.DELETE("/:tagId", handler.Tag.Delete)
+
+	jobs := admin.Group("/jobs", auth...)
+	jobs.GET("/:jobId", handler.Job.FindByID)
+
+	// the local storage backend serves its own signed URLs
+	if h, ok := handler.Storage.(http.Handler); ok {
+		e.Any("/storage/*", echo.WrapHandler(http.StripPrefix("/storage", h)), m.RateLimit.Handle())
+	}
+}
 
+// InitAdmin registers the routes of the admin server, which is not exposed
+// publicly and does not authenticate.
+func InitAdmin(logLevel handler.LogLevel, e *echo.Echo) {
+	logLevels := e.Group("/log-levels")
+	logLevels.GET("", logLevel.Find)
+	logLevels.PUT("/:package", logLevel.Update)
+	logLevels.DELETE("/:package", logLevel.Delete)
 }
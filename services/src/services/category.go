@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	entities "github.com/genpsp/go-app/domain/entities"
+	repositories "github.com/genpsp/go-app/domain/repository"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type (
+	CategoryService interface {
+		FindTree(ctx context.Context) (tree []*CategoryNode, err error)
+		Create(ctx context.Context, categoryEntity *entities.Category) (err error)
+		Update(ctx context.Context, categoryID int, categoryEntity *entities.Category) (err error)
+		Delete(ctx context.Context, categoryID int) (err error)
+		AssignItem(ctx context.Context, itemID int, categoryIDs []uint) (err error)
+	}
+
+	// CategoryNode is a category with its children and the number of items
+	// linked to it directly (ItemCount) and to its whole subtree (TotalItemCount).
+	CategoryNode struct {
+		entities.Category
+		ItemCount      int64
+		TotalItemCount int64
+		Children       []*CategoryNode
+	}
+
+	categoryServiceImpl struct {
+		cr     repositories.CategoryRepository
+		ir     repositories.ItemRepository
+		master *gorm.DB
+	}
+)
+
+func NewCategoryService(
+	categoryRepo repositories.CategoryRepository,
+	itemRepo repositories.ItemRepository,
+	m *gorm.DB) CategoryService {
+
+	return &categoryServiceImpl{
+		cr:     categoryRepo,
+		ir:     itemRepo,
+		master: m,
+	}
+}
+
+// buildCategoryTree links the categories to their parents. Categories whose
+// parent is missing, e.g. soft deleted, are returned as roots.
+func buildCategoryTree(categories []entities.Category, counts []repositories.CategoryItemCount) []*CategoryNode {
+	nodes := make(map[uint]*CategoryNode, len(categories))
+	for _, category := range categories {
+		nodes[category.ID] = &CategoryNode{Category: category, Children: []*CategoryNode{}}
+	}
+	for _, count := range counts {
+		if node, ok := nodes[count.CategoryID]; ok {
+			node.ItemCount = count.ItemCount
+			node.TotalItemCount = count.TotalCount
+		}
+	}
+
+	roots := []*CategoryNode{}
+	for _, category := range categories {
+		node := nodes[category.ID]
+		if category.ParentID != nil {
+			if parent, ok := nodes[*category.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+	return roots
+}
+
+func (s *categoryServiceImpl) FindTree(ctx context.Context) (tree []*CategoryNode, err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		categories, err := s.cr.FindAll(tx)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Category with FindTree call CategoryRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		counts, err := s.cr.CountItems(tx)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Category with FindTree call CategoryRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		tree = buildCategoryTree(*categories, *counts)
+		return nil
+	})
+	return
+}
+
+// validateParent rejects a parent that does not exist or that lies in the
+// subtree of the category itself, which would create a cycle.
+func (s *categoryServiceImpl) validateParent(ctx context.Context, tx *gorm.DB, categoryID int, parentID *uint) error {
+	if parentID == nil {
+		return nil
+	}
+	parent, err := s.cr.FindByID(tx, int(*parentID))
+	if err != nil {
+		log.Ctx(ctx).Error("occurred error when Category with validateParent call CategoryRepository", zap.Error(err))
+		return appErr.BindServiceErrorWithDBError(err)
+	}
+	if parent == nil {
+		return appErr.ServiceStatusBadRequestError
+	}
+	if categoryID == 0 {
+		return nil
+	}
+	subtree, err := s.cr.FindSubtreeIDs(tx, categoryID)
+	if err != nil {
+		log.Ctx(ctx).Error("occurred error when Category with validateParent call CategoryRepository", zap.Error(err))
+		return appErr.BindServiceErrorWithDBError(err)
+	}
+	for _, id := range subtree {
+		if id == *parentID {
+			log.Info("Category can not be moved under its descendant", zap.Int("category_id", categoryID), zap.Uint("parent_id", *parentID))
+			return appErr.ServiceStatusBadRequestError
+		}
+	}
+	return nil
+}
+
+func (s *categoryServiceImpl) Create(ctx context.Context, categoryEntity *entities.Category) (err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.validateParent(ctx, tx, 0, categoryEntity.ParentID); err != nil {
+			return err
+		}
+		err := s.cr.Create(tx, categoryEntity)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Category with Create call CategoryRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	return
+}
+
+func (s *categoryServiceImpl) Update(ctx context.Context, categoryID int, categoryEntity *entities.Category) (err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		category, err := s.cr.FindByID(tx, categoryID)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Category with Update call CategoryRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		if category == nil {
+			return appErr.ServiceStatusBadRequestError
+		}
+		if err := s.validateParent(ctx, tx, categoryID, categoryEntity.ParentID); err != nil {
+			return err
+		}
+		err = s.cr.Update(tx, categoryID, categoryEntity)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Category with Update call CategoryRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	return
+}
+
+// Delete removes a leaf category. Categories with children have to be emptied first.
+func (s *categoryServiceImpl) Delete(ctx context.Context, categoryID int) (err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		subtree, err := s.cr.FindSubtreeIDs(tx, categoryID)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Category with Delete call CategoryRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		if len(subtree) == 0 {
+			return appErr.ServiceStatusBadRequestError
+		}
+		if len(subtree) > 1 {
+			log.Ctx(ctx).Info("Category has children", zap.Int("category_id", categoryID))
+			return appErr.ServiceStatusBadRequestError
+		}
+		err = s.cr.Delete(tx, categoryID)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Category with Delete call CategoryRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	return
+}
+
+// AssignItem replaces the categories of the item with categoryIDs.
+func (s *categoryServiceImpl) AssignItem(ctx context.Context, itemID int, categoryIDs []uint) (err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		item, err := s.ir.FindByID(tx, itemID)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Category with AssignItem call ItemRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		if item == nil {
+			return appErr.ServiceStatusBadRequestError
+		}
+		categories := []entities.Category{}
+		if len(categoryIDs) > 0 {
+			found, err := s.cr.FindByIDs(tx, categoryIDs)
+			if err != nil {
+				log.Ctx(ctx).Error("occurred error when Category with AssignItem call CategoryRepository", zap.Error(err))
+				return appErr.BindServiceErrorWithDBError(err)
+			}
+			categories = *found
+		}
+		if len(categories) != len(uniqueIDs(categoryIDs)) {
+			log.Ctx(ctx).Info("Category unknown category", zap.Any("category_ids", categoryIDs))
+			return appErr.ServiceStatusBadRequestError
+		}
+		err = s.ir.ReplaceCategories(tx, item, categories)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Category with AssignItem call ItemRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	return
+}
+
+func uniqueIDs(ids []uint) map[uint]struct{} {
+	set := make(map[uint]struct{}, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+	return set
+}
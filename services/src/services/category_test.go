@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	repositories "github.com/genpsp/go-app/domain/repository"
+	"github.com/genpsp/go-app/domain/repository/mock_repositories"
+	"github.com/genpsp/go-app/pkg/configs"
+	"github.com/genpsp/go-app/pkg/logger"
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+	"gorm.io/gorm"
+)
+
+func Test_CategoryService(t *testing.T) {
+	Convey("CategoryServiceを初期化", t, func() {
+		configs.TestLoadConfig()
+		cfg := configs.GetConfig()
+		logger.LoadLogger(cfg.System.Env, cfg.Logger.LogLevel, cfg.Logger.LogEncoding)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		db, mock, _ := mock_repositories.GetDBMock()
+		cr := mock_repositories.NewMockCategoryRepository(ctrl)
+		ir := mock_repositories.NewMockItemRepository(ctrl)
+		cs := NewCategoryService(cr, ir, db)
+
+		root, child := uint(1), uint(2)
+
+		Convey("件数付きのツリーを取得できる", func() {
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			cr.EXPECT().FindAll(gomock.Any()).Return(&[]entities.Category{
+				{Model: gorm.Model{ID: root}, Name: "服"},
+				{Model: gorm.Model{ID: child}, ParentID: &root, Name: "Tシャツ"},
+				{Model: gorm.Model{ID: 3}, ParentID: &child, Name: "半袖"},
+				{Model: gorm.Model{ID: 4}, Name: "靴"},
+			}, nil)
+			cr.EXPECT().CountItems(gomock.Any()).Return(&[]repositories.CategoryItemCount{
+				{CategoryID: root, ItemCount: 1, TotalCount: 4},
+				{CategoryID: child, ItemCount: 2, TotalCount: 3},
+				{CategoryID: 3, ItemCount: 1, TotalCount: 1},
+			}, nil)
+
+			tree, err := cs.FindTree(context.Background())
+			So(err, ShouldBeNil)
+			So(len(tree), ShouldEqual, 2)
+			So(tree[0].TotalItemCount, ShouldEqual, 4)
+			So(tree[0].Children[0].Name, ShouldEqual, "Tシャツ")
+			So(tree[0].Children[0].ItemCount, ShouldEqual, 2)
+			So(tree[0].Children[0].Children[0].Name, ShouldEqual, "半袖")
+			So(tree[1].Name, ShouldEqual, "靴")
+			So(tree[1].TotalItemCount, ShouldEqual, 0)
+			So(len(tree[1].Children), ShouldEqual, 0)
+		})
+		Convey("子孫カテゴリの下には移動できない", func() {
+			mock.ExpectBegin()
+			mock.ExpectRollback()
+			descendant := uint(3)
+			cr.EXPECT().FindByID(gomock.Any(), int(child)).Return(&entities.Category{Model: gorm.Model{ID: child}}, nil)
+			cr.EXPECT().FindByID(gomock.Any(), int(descendant)).Return(&entities.Category{Model: gorm.Model{ID: descendant}}, nil)
+			cr.EXPECT().FindSubtreeIDs(gomock.Any(), int(child)).Return([]uint{child, descendant}, nil)
+
+			err := cs.Update(context.Background(), int(child), &entities.Category{ParentID: &descendant, Name: "Tシャツ"})
+			So(err, ShouldNotBeNil)
+		})
+		Convey("子カテゴリを持つカテゴリは削除できない", func() {
+			mock.ExpectBegin()
+			mock.ExpectRollback()
+			cr.EXPECT().FindSubtreeIDs(gomock.Any(), int(root)).Return([]uint{root, child}, nil)
+
+			err := cs.Delete(context.Background(), int(root))
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
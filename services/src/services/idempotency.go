@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/domain/enum"
+	repositories "github.com/genpsp/go-app/domain/repository"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// idempotencySweepEvery is how many keys are claimed between sweeps of the expired ones.
+const idempotencySweepEvery = 1024
+
+var (
+	// ErrIdempotencyKeyMismatch is returned when a key is reused for a different request.
+	ErrIdempotencyKeyMismatch = errors.New("idempotency key reused with a different request")
+	// ErrIdempotencyKeyInFlight is returned while the first request with the key is still running.
+	ErrIdempotencyKeyInFlight = errors.New("idempotency key in flight")
+)
+
+type (
+	IdempotencyService interface {
+		Begin(ctx context.Context, principal string, key string, fingerprint string) (keyEntity *entities.IdempotencyKey, replay bool, err error)
+		Complete(ctx context.Context, keyEntity *entities.IdempotencyKey, status int, contentType string, body []byte) (err error)
+		Release(ctx context.Context, keyEntity *entities.IdempotencyKey) (err error)
+	}
+
+	idempotencyServiceImpl struct {
+		ikr         repositories.IdempotencyKeyRepository
+		master      *gorm.DB
+		ttl         time.Duration
+		lockTimeout time.Duration
+		begins      int64
+	}
+)
+
+func NewIdempotencyService(
+	idempotencyKeyRepo repositories.IdempotencyKeyRepository,
+	m *gorm.DB, ttl time.Duration, lockTimeout time.Duration) IdempotencyService {
+
+	return &idempotencyServiceImpl{
+		ikr:         idempotencyKeyRepo,
+		master:      m,
+		ttl:         ttl,
+		lockTimeout: lockTimeout,
+	}
+}
+
+// IdempotencyFingerprint identifies a request by its method, URI and body.
+func IdempotencyFingerprint(method string, requestURI string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{'\n'})
+	h.Write([]byte(requestURI))
+	h.Write([]byte{'\n'})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Begin claims key for a request. replay is true when a completed response is
+// stored for the same request, which keyEntity then carries.
+func (s *idempotencyServiceImpl) Begin(ctx context.Context, principal string, key string, fingerprint string) (keyEntity *entities.IdempotencyKey, replay bool, err error) {
+	if atomic.AddInt64(&s.begins, 1)%idempotencySweepEvery == 0 {
+		if err := s.ikr.DeleteExpired(s.master.WithContext(ctx), time.Now()); err != nil {
+			log.Ctx(ctx).Error("occurred error when Idempotency with Begin call IdempotencyKeyRepository", zap.Error(err))
+		}
+	}
+
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		// insert first: reading a missing row FOR UPDATE would only take a gap
+		// lock, and two requests racing on it would deadlock on their inserts
+		keyEntity = &entities.IdempotencyKey{
+			Principal:   principal,
+			Key:         key,
+			Fingerprint: fingerprint,
+			Status:      enum.IdempotencyKeyStatusInFlight,
+			LockedUntil: now.Add(s.lockTimeout),
+			ExpiresAt:   now.Add(s.ttl),
+		}
+		created, err := s.ikr.Create(tx, keyEntity)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Idempotency with Begin call IdempotencyKeyRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		if created {
+			return nil
+		}
+
+		existing, err := s.ikr.FindForUpdate(tx, principal, key)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Idempotency with Begin call IdempotencyKeyRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		if existing == nil {
+			// released by the request that held it in the meantime
+			return ErrIdempotencyKeyInFlight
+		}
+
+		expired := !existing.ExpiresAt.After(now)
+		if !expired && existing.Fingerprint != fingerprint {
+			return ErrIdempotencyKeyMismatch
+		}
+		if !expired && existing.Status == enum.IdempotencyKeyStatusCompleted {
+			keyEntity, replay = existing, true
+			return nil
+		}
+		if !expired && existing.LockedUntil.After(now) {
+			return ErrIdempotencyKeyInFlight
+		}
+
+		// expired, or abandoned by a request that never completed
+		existing.Fingerprint = fingerprint
+		existing.Status = enum.IdempotencyKeyStatusInFlight
+		existing.LockedUntil = now.Add(s.lockTimeout)
+		existing.ExpiresAt = now.Add(s.ttl)
+		if err := s.ikr.Restart(tx, existing); err != nil {
+			log.Ctx(ctx).Error("occurred error when Idempotency with Begin call IdempotencyKeyRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		keyEntity = existing
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return
+}
+
+// Complete stores the response to replay for retries.
+func (s *idempotencyServiceImpl) Complete(ctx context.Context, keyEntity *entities.IdempotencyKey, status int, contentType string, body []byte) (err error) {
+	err = s.ikr.Complete(s.master.WithContext(ctx), keyEntity.ID, status, contentType, body)
+	if err != nil {
+		log.Ctx(ctx).Error("occurred error when Idempotency with Complete call IdempotencyKeyRepository", zap.Error(err))
+		return appErr.BindServiceErrorWithDBError(err)
+	}
+	return nil
+}
+
+// Release forgets the key so a retry runs the request again.
+func (s *idempotencyServiceImpl) Release(ctx context.Context, keyEntity *entities.IdempotencyKey) (err error) {
+	err = s.ikr.Delete(s.master.WithContext(ctx), keyEntity.ID)
+	if err != nil {
+		log.Ctx(ctx).Error("occurred error when Idempotency with Release call IdempotencyKeyRepository", zap.Error(err))
+		return appErr.BindServiceErrorWithDBError(err)
+	}
+	return nil
+}
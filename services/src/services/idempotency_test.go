@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/domain/repository/mock_repositories"
+	"github.com/genpsp/go-app/pkg/configs"
+	"github.com/genpsp/go-app/pkg/logger"
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_IdempotencyService(t *testing.T) {
+	Convey("IdempotencyServiceを初期化", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		configs.TestLoadConfig()
+		cfg := configs.GetConfig()
+		logger.LoadLogger(cfg.System.Env, cfg.Logger.LogLevel, cfg.Logger.LogEncoding)
+
+		db, mock, _ := mock_repositories.GetDBMock()
+		ikr := mock_repositories.NewMockIdempotencyKeyRepository(ctrl)
+		is := NewIdempotencyService(ikr, db, 0, 0).(*idempotencyServiceImpl)
+
+		Convey("一定回数ごとに期限切れのキーを削除する", func() {
+			is.begins = idempotencySweepEvery - 1
+			ikr.EXPECT().DeleteExpired(gomock.Any(), gomock.Any()).Return(nil)
+			mock.ExpectBegin()
+			ikr.EXPECT().Create(gomock.Any(), gomock.Any()).Return(true, nil)
+			mock.ExpectCommit()
+
+			record, replay, err := is.Begin(context.Background(), "user", "key-1", "fingerprint")
+			So(err, ShouldBeNil)
+			So(replay, ShouldBeFalse)
+			So(record, ShouldHaveSameTypeAs, &entities.IdempotencyKey{})
+		})
+		Convey("それ以外は削除しない", func() {
+			mock.ExpectBegin()
+			ikr.EXPECT().Create(gomock.Any(), gomock.Any()).Return(true, nil)
+			mock.ExpectCommit()
+
+			_, _, err := is.Begin(context.Background(), "user", "key-1", "fingerprint")
+			So(err, ShouldBeNil)
+		})
+	})
+}
@@ -1,28 +1,40 @@
 package services
 
 import (
-	"fmt"
+	"context"
+	"time"
+
 	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/domain/enum"
 	repositories "github.com/genpsp/go-app/domain/repository"
 	"github.com/genpsp/go-app/pkg/firebase"
 	"github.com/genpsp/go-app/pkg/logger"
 	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"github.com/genpsp/go-app/pkg/tracing"
 	"github.com/genpsp/go-app/services/src/handler/request"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
+var log = logger.New("services")
+
 type (
 	ItemService interface {
-		FindAll() (items *[]entities.Item, err error)
-		Find(gar *request.GetItemRequest) (items *[]entities.Item, err error)
-		FindByID(itemID int) (item *entities.Item, err error)
-		Create(itemEntity *entities.Item, password string) (err error)
-		Update(itemID int, itemEntity *entities.Item) (err error)
-		Delete(itemID int) (err error)
+		FindAll(ctx context.Context) (items *[]entities.Item, err error)
+		Find(ctx context.Context, gar *request.GetItemRequest) (items *[]entities.Item, err error)
+		FindByID(ctx context.Context, itemID int) (item *entities.Item, err error)
+		FindByFilter(ctx context.Context, filter repositories.ItemFilter) (items *[]entities.Item, err error)
+		Create(ctx context.Context, itemEntity *entities.Item, password string) (err error)
+		Update(ctx context.Context, itemID int, itemEntity *entities.Item) (err error)
+		Delete(ctx context.Context, itemID int) (err error)
+		Revisions(ctx context.Context, itemID int) (revisions *[]entities.ItemRevision, err error)
+		FindAsOf(ctx context.Context, itemID int, at time.Time) (item *entities.Item, err error)
+		Revert(ctx context.Context, itemID int, revision int) (err error)
 	}
 
 	itemServiceImpl struct {
 		aur    repositories.ItemRepository
+		irr    repositories.ItemRevisionRepository
 		master *gorm.DB
 		auth   firebase.AuthAdmin
 	}
@@ -30,20 +42,22 @@ type (
 
 func NewItemService(
 	itemRepo repositories.ItemRepository,
+	itemRevisionRepo repositories.ItemRevisionRepository,
 	m *gorm.DB, auth firebase.AuthAdmin) ItemService {
 
 	return &itemServiceImpl{
 		aur:    itemRepo,
+		irr:    itemRevisionRepo,
 		master: m,
 		auth:   auth,
 	}
 }
 
-func (s *itemServiceImpl) FindAll() (items *[]entities.Item, err error) {
-	err = s.master.Transaction(func(tx *gorm.DB) error {
+func (s *itemServiceImpl) FindAll(ctx context.Context) (items *[]entities.Item, err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		items, err = s.aur.FindAll(tx)
 		if err != nil {
-			logger.Logging.Error(fmt.Sprintf("occurred error when Item with FindAll call ItemRepository: %s", err.Error()))
+			log.Ctx(ctx).Error("occurred error when Item with FindAll call ItemRepository", zap.Error(err))
 			return appErr.BindServiceErrorWithDBErrorCaseRecordNotFoundIsNil(err)
 		}
 		return nil
@@ -51,11 +65,11 @@ func (s *itemServiceImpl) FindAll() (items *[]entities.Item, err error) {
 	return
 }
 
-func (s *itemServiceImpl) Find(gar *request.GetItemRequest) (items *[]entities.Item, err error) {
-	err = s.master.Transaction(func(tx *gorm.DB) error {
+func (s *itemServiceImpl) Find(ctx context.Context, gar *request.GetItemRequest) (items *[]entities.Item, err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		items, err = s.aur.Find(tx, gar.EmailAddress, gar.Name)
 		if err != nil {
-			logger.Logging.Error(fmt.Sprintf("occurred error when Item with FindOne call ItemRepository: %s", err.Error()))
+			log.Ctx(ctx).Error("occurred error when Item with FindOne call ItemRepository", zap.Error(err))
 			return appErr.BindServiceErrorWithDBErrorCaseRecordNotFoundIsNil(err)
 		}
 		return nil
@@ -63,11 +77,23 @@ func (s *itemServiceImpl) Find(gar *request.GetItemRequest) (items *[]entities.I
 	return
 }
 
-func (s *itemServiceImpl) FindByID(itemID int) (itemEntity *entities.Item, err error) {
-	err = s.master.Transaction(func(tx *gorm.DB) error {
+func (s *itemServiceImpl) FindByID(ctx context.Context, itemID int) (itemEntity *entities.Item, err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		itemEntity, err = s.aur.FindByID(tx, itemID)
 		if err != nil {
-			logger.Logging.Error(fmt.Sprintf("occurred error when Item with FindByID call ItemRepository: %s", err.Error()))
+			log.Ctx(ctx).Error("occurred error when Item with FindByID call ItemRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	return
+}
+
+func (s *itemServiceImpl) FindByFilter(ctx context.Context, filter repositories.ItemFilter) (items *[]entities.Item, err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		items, err = s.aur.FindByFilter(tx, filter)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Item with FindByFilter call ItemRepository", zap.Error(err))
 			return appErr.BindServiceErrorWithDBError(err)
 		}
 		return nil
@@ -75,15 +101,18 @@ func (s *itemServiceImpl) FindByID(itemID int) (itemEntity *entities.Item, err e
 	return
 }
 
-func (s *itemServiceImpl) Create(itemEntity *entities.Item, password string) (err error) {
-	err = s.master.Transaction(func(tx *gorm.DB) error {
-		result, createUserErr := s.auth.CreateUser(itemEntity, password)
+func (s *itemServiceImpl) Create(ctx context.Context, itemEntity *entities.Item, password string) (err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result, createUserErr := tracing.AuthAdmin(ctx, s.auth).CreateUser(itemEntity, password)
 		if createUserErr != nil || result == nil {
 			return appErr.BindServiceErrorWithDBError(createUserErr)
 		}
 		itemEntity.ExternalUserID = result.UID
 
 		createErr := s.aur.Create(tx, itemEntity)
+		if createErr == nil {
+			createErr = s.irr.Record(tx, []uint{itemEntity.ID}, enum.ItemRevisionActionCreate, time.Now())
+		}
 		if createErr != nil {
 			deleteUserErr := s.auth.DeleteUser(result.UID)
 			return appErr.BindServiceErrorWithDBError(deleteUserErr)
@@ -100,11 +129,16 @@ func (s *itemServiceImpl) Create(itemEntity *entities.Item, password string) (er
 	return
 }
 
-func (s *itemServiceImpl) Update(itemID int, itemEntity *entities.Item) (err error) {
-	err = s.master.Transaction(func(tx *gorm.DB) error {
+func (s *itemServiceImpl) Update(ctx context.Context, itemID int, itemEntity *entities.Item) (err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		err := s.aur.Update(tx, itemID, itemEntity)
 		if err != nil {
-			logger.Logging.Error(fmt.Sprintf("occurred error when Item with Update call ItemRepository: %s", err.Error()))
+			log.Ctx(ctx).Error("occurred error when Item with Update call ItemRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		err = s.irr.Record(tx, []uint{uint(itemID)}, enum.ItemRevisionActionUpdate, time.Now())
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Item with Update call ItemRevisionRepository", zap.Error(err))
 			return appErr.BindServiceErrorWithDBError(err)
 		}
 		return nil
@@ -112,25 +146,118 @@ func (s *itemServiceImpl) Update(itemID int, itemEntity *entities.Item) (err err
 	return
 }
 
-func (s *itemServiceImpl) Delete(itemID int) (err error) {
-	err = s.master.Transaction(func(tx *gorm.DB) error {
+func (s *itemServiceImpl) Delete(ctx context.Context, itemID int) (err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		item, err := s.aur.FindByID(tx, itemID)
 		if err != nil {
-			logger.Logging.Error(fmt.Sprintf("occurred error when Item with Delete call ItemRepository: %s", err.Error()))
+			log.Ctx(ctx).Error("occurred error when Item with Delete call ItemRepository", zap.Error(err))
 			return appErr.BindServiceErrorWithDBError(err)
 		}
 
 		if err = s.auth.DeleteUser(item.ExternalUserID); err != nil {
-			logger.Logging.Error(fmt.Sprintf("occurred error when Item with Delete call Firebase deleteUser: %s", err.Error()))
+			log.Ctx(ctx).Error("occurred error when Item with Delete call Firebase deleteUser", zap.Error(err))
 			return appErr.BindServiceErrorWithFirebaseError(err)
 		}
 
 		err = s.aur.Delete(tx, itemID)
 		if err != nil {
-			logger.Logging.Error(fmt.Sprintf("occurred error when Item with Delete call ItemRepository: %s", err.Error()))
+			log.Ctx(ctx).Error("occurred error when Item with Delete call ItemRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		err = s.irr.Record(tx, []uint{uint(itemID)}, enum.ItemRevisionActionDelete, time.Now())
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Item with Delete call ItemRevisionRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	return
+}
+
+func (s *itemServiceImpl) Revisions(ctx context.Context, itemID int) (revisions *[]entities.ItemRevision, err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		revisions, err = s.irr.FindByItemID(tx, itemID)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Item with Revisions call ItemRevisionRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	return
+}
+
+// FindAsOf rebuilds the item from the revision current at the given time.
+// It returns nil when the item did not exist yet or was deleted at that time.
+// Only code and name are versioned, so images, categories and tags are left
+// empty rather than filled with the current ones. The price is the current one
+// for ItemPriceService.ApplyEffective to replace with the price effective then.
+func (s *itemServiceImpl) FindAsOf(ctx context.Context, itemID int, at time.Time) (itemEntity *entities.Item, err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		revision, err := s.irr.FindAsOf(tx, itemID, at)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Item with FindAsOf call ItemRevisionRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		if revision == nil || revision.Deleted {
+			return nil
+		}
+		itemEntity = &entities.Item{
+			Model: gorm.Model{ID: revision.ItemID, UpdatedAt: revision.CreatedAt},
+			Code:  itemCode(revision.Code),
+			Name:  revision.Name,
+		}
+
+		current, err := s.aur.FindByID(tx, itemID)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Item with FindAsOf call ItemRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		if current != nil {
+			itemEntity.Price = current.Price
+		}
+		return nil
+	})
+	return
+}
+
+// Revert restores the item to a previous revision, recording the result as a new revision.
+func (s *itemServiceImpl) Revert(ctx context.Context, itemID int, revision int) (err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		item, err := s.aur.FindByID(tx, itemID)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Item with Revert call ItemRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		if item == nil {
+			return appErr.ServiceStatusBadRequestError
+		}
+		revisionEntity, err := s.irr.FindByRevision(tx, itemID, revision)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Item with Revert call ItemRevisionRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		if revisionEntity == nil || revisionEntity.Deleted {
+			return appErr.ServiceStatusBadRequestError
+		}
+		err = s.aur.Restore(tx, itemID, revisionEntity)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Item with Revert call ItemRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		err = s.irr.Record(tx, []uint{uint(itemID)}, enum.ItemRevisionActionRevert, time.Now())
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Item with Revert call ItemRevisionRepository", zap.Error(err))
 			return appErr.BindServiceErrorWithDBError(err)
 		}
 		return nil
 	})
 	return
 }
+
+// itemCode returns nil for an empty code, which is stored as NULL.
+func itemCode(code string) *string {
+	if code == "" {
+		return nil
+	}
+	return &code
+}
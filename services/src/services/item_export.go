@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/domain/enum"
+	repositories "github.com/genpsp/go-app/domain/repository"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"github.com/genpsp/go-app/pkg/storage"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	ItemExportFormatCSV    = "csv"
+	ItemExportFormatNDJSON = "ndjson"
+
+	itemExportBatchSize = 500
+)
+
+var itemExportColumns = map[string]func(entities.Item) interface{}{
+	"id":         func(i entities.Item) interface{} { return i.ID },
+	"code":       func(i entities.Item) interface{} { return i.Code },
+	"name":       func(i entities.Item) interface{} { return i.Name },
+	"price":      func(i entities.Item) interface{} { return i.Price },
+	"created_at": func(i entities.Item) interface{} { return i.CreatedAt.Format(time.RFC3339) },
+	"updated_at": func(i entities.Item) interface{} { return i.UpdatedAt.Format(time.RFC3339) },
+}
+
+var ItemExportDefaultColumns = []string{"id", "code", "name", "price", "created_at", "updated_at"}
+
+type (
+	ItemExportService interface {
+		Export(ctx context.Context, w io.Writer, opts ItemExportOptions) (rows int, err error)
+		ExportToStorage(ctx context.Context, jobID uint, opts ItemExportOptions) (result *ItemExportResult, err error)
+		Enqueue(ctx context.Context, createdBy string, opts ItemExportOptions) (job *entities.Job, err error)
+		DownloadURL(ctx context.Context, createdBy string, jobID int) (url string, err error)
+	}
+
+	// ItemExportOptions is also the payload of item export jobs.
+	ItemExportOptions struct {
+		Format  string                  `json:"format"`
+		Columns []string                `json:"columns"`
+		Header  bool                    `json:"header"`
+		Filter  repositories.ItemFilter `json:"filter"`
+	}
+
+	ItemExportResult struct {
+		ObjectKey string `json:"objectKey"`
+		Rows      int    `json:"rows"`
+	}
+
+	itemExportServiceImpl struct {
+		ir        repositories.ItemRepository
+		js        JobService
+		ips       ItemPriceService
+		master    *gorm.DB
+		storage   storage.Storage
+		urlExpire time.Duration
+	}
+)
+
+func NewItemExportService(
+	itemRepo repositories.ItemRepository, jobService JobService, itemPriceService ItemPriceService,
+	m *gorm.DB, s storage.Storage, urlExpire time.Duration) ItemExportService {
+
+	return &itemExportServiceImpl{
+		ir:        itemRepo,
+		js:        jobService,
+		ips:       itemPriceService,
+		master:    m,
+		storage:   s,
+		urlExpire: urlExpire,
+	}
+}
+
+func (o ItemExportOptions) ContentType() string {
+	if o.Format == ItemExportFormatNDJSON {
+		return "application/x-ndjson"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+func (o ItemExportOptions) Validate() error {
+	if o.Format != ItemExportFormatCSV && o.Format != ItemExportFormatNDJSON {
+		log.Info("ItemExport unsupported format", zap.String("format", string(o.Format)))
+		return appErr.ServiceStatusBadRequestError
+	}
+	if len(o.Columns) == 0 {
+		log.Info("ItemExport no columns")
+		return appErr.ServiceStatusBadRequestError
+	}
+	for _, c := range o.Columns {
+		if _, ok := itemExportColumns[c]; !ok {
+			log.Info("ItemExport unknown column", zap.String("column", c))
+			return appErr.ServiceStatusBadRequestError
+		}
+	}
+	return nil
+}
+
+// Export writes the items matching opts.Filter to w with the prices effective
+// when it started, flushing after every batch when w supports it so the
+// response streams instead of buffering. Each batch is its own short read
+// rather than one transaction held open for the whole download.
+func (s *itemExportServiceImpl) Export(ctx context.Context, w io.Writer, opts ItemExportOptions) (rows int, err error) {
+	if err = opts.Validate(); err != nil {
+		return
+	}
+	var rw itemRowWriter = newItemCSVWriter(w, opts)
+	if opts.Format == ItemExportFormatNDJSON {
+		rw = &itemNDJSONWriter{enc: json.NewEncoder(w), columns: opts.Columns}
+	}
+	flusher, _ := w.(interface{ Flush() })
+	now := time.Now()
+
+	err = s.ir.FindInBatches(s.master.WithContext(ctx), opts.Filter, itemExportBatchSize, func(items []entities.Item) error {
+		if err := s.ips.ApplyEffective(ctx, items, now); err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := rw.Write(item); err != nil {
+				return err
+			}
+			rows++
+		}
+		if err := rw.Flush(); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		log.Ctx(ctx).Error("occurred error when ItemExport with Export call ItemRepository", zap.Error(err))
+		return rows, appErr.BindServiceErrorWithDBError(err)
+	}
+	err = rw.Flush()
+	return
+}
+
+type (
+	itemRowWriter interface {
+		Write(item entities.Item) error
+		Flush() error
+	}
+
+	itemCSVWriter struct {
+		cw      *csv.Writer
+		columns []string
+	}
+
+	itemNDJSONWriter struct {
+		enc     *json.Encoder
+		columns []string
+	}
+)
+
+// newItemCSVWriter writes the header row up front so an empty export still has one.
+func newItemCSVWriter(w io.Writer, opts ItemExportOptions) *itemCSVWriter {
+	cw := csv.NewWriter(w)
+	if opts.Header {
+		cw.Write(opts.Columns)
+	}
+	return &itemCSVWriter{cw: cw, columns: opts.Columns}
+}
+
+func (w *itemCSVWriter) Write(item entities.Item) error {
+	record := make([]string, len(w.columns))
+	for i, c := range w.columns {
+		switch v := itemExportColumns[c](item).(type) {
+		case string:
+			record[i] = escapeCSVFormula(v)
+		case *string:
+			if v != nil {
+				record[i] = escapeCSVFormula(*v)
+			}
+		case int:
+			record[i] = strconv.Itoa(v)
+		default:
+			record[i] = fmt.Sprint(v)
+		}
+	}
+	return w.cw.Write(record)
+}
+
+// escapeCSVFormula prefixes text that a spreadsheet would run as a formula with
+// a quote so it opens as plain text.
+func escapeCSVFormula(v string) string {
+	if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+		return "'" + v
+	}
+	return v
+}
+
+func (w *itemCSVWriter) Flush() error {
+	w.cw.Flush()
+	return w.cw.Error()
+}
+
+func (w *itemNDJSONWriter) Write(item entities.Item) error {
+	row := make(map[string]interface{}, len(w.columns))
+	for _, c := range w.columns {
+		row[c] = itemExportColumns[c](item)
+	}
+	return w.enc.Encode(row)
+}
+
+func (w *itemNDJSONWriter) Flush() error {
+	return nil
+}
+
+func itemExportObjectKey(jobID uint, format string) string {
+	return fmt.Sprintf("exports/items/%d.%s", jobID, format)
+}
+
+func (s *itemExportServiceImpl) ExportToStorage(ctx context.Context, jobID uint, opts ItemExportOptions) (result *ItemExportResult, err error) {
+	key := itemExportObjectKey(jobID, opts.Format)
+	w, err := s.storage.NewWriter(ctx, key, opts.ContentType())
+	if err != nil {
+		log.Ctx(ctx).Error("occurred error when ItemExport with ExportToStorage call Storage", zap.Error(err))
+		return nil, err
+	}
+	rows, err := s.Export(ctx, w, opts)
+	if err != nil {
+		if a, ok := w.(interface{ Abort() }); ok {
+			a.Abort()
+		}
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		log.Ctx(ctx).Error("occurred error when ItemExport with ExportToStorage close Storage writer", zap.Error(err))
+		return nil, err
+	}
+	return &ItemExportResult{ObjectKey: key, Rows: rows}, nil
+}
+
+func (s *itemExportServiceImpl) Enqueue(ctx context.Context, createdBy string, opts ItemExportOptions) (job *entities.Job, err error) {
+	if err = opts.Validate(); err != nil {
+		return
+	}
+	return s.js.Enqueue(ctx, createdBy, enum.JobTypeItemExport, opts, time.Time{})
+}
+
+// DownloadURL signs a fresh URL for the file written by a finished export job.
+// It returns an empty url when createdBy has no such job or it has not succeeded yet.
+func (s *itemExportServiceImpl) DownloadURL(ctx context.Context, createdBy string, jobID int) (url string, err error) {
+	job, err := s.js.FindByID(ctx, createdBy, jobID)
+	if err != nil || job == nil {
+		return
+	}
+	if job.Type != enum.JobTypeItemExport || job.Status != enum.JobStatusSucceeded {
+		return "", nil
+	}
+	var result ItemExportResult
+	if err = json.Unmarshal([]byte(job.Result), &result); err != nil {
+		log.Ctx(ctx).Error("occurred error when ItemExport with DownloadURL unmarshal result", zap.Error(err))
+		return "", appErr.ServiceClientError
+	}
+	url, err = s.storage.SignedURL(ctx, result.ObjectKey, storage.SignedURLOptions{
+		Method:  http.MethodGet,
+		Expires: time.Now().Add(s.urlExpire),
+	})
+	if err != nil {
+		log.Ctx(ctx).Error("occurred error when ItemExport with DownloadURL call Storage", zap.Error(err))
+		return "", appErr.ServiceClientError
+	}
+	return
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	repositories "github.com/genpsp/go-app/domain/repository"
+	"github.com/genpsp/go-app/domain/repository/memory_repositories"
+	"github.com/genpsp/go-app/domain/repository/mock_repositories"
+	"github.com/genpsp/go-app/pkg/configs"
+	"github.com/genpsp/go-app/pkg/logger"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+	"gorm.io/gorm"
+)
+
+func Test_ItemExportService(t *testing.T) {
+	Convey("ItemExportServiceを初期化", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		configs.TestLoadConfig()
+		cfg := configs.GetConfig()
+		logger.LoadLogger(cfg.System.Env, cfg.Logger.LogLevel, cfg.Logger.LogEncoding)
+
+		db, mock, _ := mock_repositories.GetDBMock()
+		ir := mock_repositories.NewMockItemRepository(ctrl)
+		pr := memory_repositories.NewItemPriceRepository(
+			entities.ItemPrice{ItemID: 2, Price: 150, EffectiveFrom: time.Now().Add(-time.Hour)},
+		)
+		es := NewItemExportService(ir, nil, NewItemPriceService(pr, nil, db), db, nil, 0)
+		So(es, ShouldNotBeNil)
+
+		mockEntities := []entities.Item{
+			{Model: gorm.Model{ID: 1}, Name: "テスト", Price: 100},
+			{Model: gorm.Model{ID: 2}, Name: "a,b", Price: 200},
+		}
+		findInBatches := func(db *gorm.DB, filter repositories.ItemFilter, batchSize int, fn func([]entities.Item) error) error {
+			return fn(mockEntities)
+		}
+
+		Convey("CSVでヘッダー付きで出力できる", func() {
+			ir.EXPECT().FindInBatches(gomock.Any(), repositories.ItemFilter{Name: "テ"}, gomock.Any(), gomock.Any()).DoAndReturn(findInBatches)
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+
+			var buf bytes.Buffer
+			rows, err := es.Export(context.Background(), &buf, ItemExportOptions{
+				Format: ItemExportFormatCSV, Columns: []string{"id", "name", "price"}, Header: true,
+				Filter: repositories.ItemFilter{Name: "テ"},
+			})
+			So(err, ShouldBeNil)
+			So(rows, ShouldEqual, 2)
+			So(buf.String(), ShouldEqual, "id,name,price\n1,テスト,100\n2,\"a,b\",150\n")
+		})
+		Convey("NDJSONで選択したカラムのみ出力できる", func() {
+			ir.EXPECT().FindInBatches(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(findInBatches)
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+
+			var buf bytes.Buffer
+			rows, err := es.Export(context.Background(), &buf, ItemExportOptions{Format: ItemExportFormatNDJSON, Columns: []string{"id", "name"}})
+			So(err, ShouldBeNil)
+			So(rows, ShouldEqual, 2)
+			So(buf.String(), ShouldEqual, "{\"id\":1,\"name\":\"テスト\"}\n{\"id\":2,\"name\":\"a,b\"}\n")
+		})
+		Convey("CSVで数式として解釈される値はエスケープする", func() {
+			ir.EXPECT().FindInBatches(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(db *gorm.DB, filter repositories.ItemFilter, batchSize int, fn func([]entities.Item) error) error {
+					return fn([]entities.Item{
+						{Model: gorm.Model{ID: 1}, Name: "=HYPERLINK(\"http://example.com\")"},
+						{Model: gorm.Model{ID: 3}, Name: "@SUM(A1)"},
+						{Model: gorm.Model{ID: 4}, Name: "-1+1"},
+					})
+				})
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+
+			var buf bytes.Buffer
+			_, err := es.Export(context.Background(), &buf, ItemExportOptions{Format: ItemExportFormatCSV, Columns: []string{"id", "name"}})
+			So(err, ShouldBeNil)
+			So(buf.String(), ShouldEqual, "1,\"'=HYPERLINK(\"\"http://example.com\"\")\"\n3,'@SUM(A1)\n4,'-1+1\n")
+		})
+		Convey("存在しないカラムを指定した場合エラーを返す", func() {
+			var buf bytes.Buffer
+			_, err := es.Export(context.Background(), &buf, ItemExportOptions{Format: ItemExportFormatCSV, Columns: []string{"password"}})
+			So(err, ShouldEqual, appErr.ServiceStatusBadRequestError)
+		})
+	})
+}
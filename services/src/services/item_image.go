@@ -0,0 +1,260 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/domain/enum"
+	repositories "github.com/genpsp/go-app/domain/repository"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"github.com/genpsp/go-app/pkg/storage"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const MaxItemImageSize = 10 << 20
+
+var itemImageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+type (
+	ItemImageService interface {
+		Upload(ctx context.Context, itemID int, file *multipart.FileHeader) (image *entities.ItemImage, err error)
+		// IssueUploadURL registers a pending image. It is not attached to the
+		// item until Confirm finds the uploaded object.
+		IssueUploadURL(ctx context.Context, itemID int, contentType string, size int64) (image *entities.ItemImage, upload *ItemImageUpload, err error)
+		Confirm(ctx context.Context, itemID int, imageID int) (image *entities.ItemImage, err error)
+		SignURLs(ctx context.Context, images []entities.ItemImage) (err error)
+	}
+
+	// ItemImageUpload is the signed request uploading a pending image. The
+	// client must send Headers, which pin the content type and cap the size.
+	ItemImageUpload struct {
+		URL       string
+		Headers   map[string]string
+		ExpiresAt time.Time
+	}
+
+	itemImageServiceImpl struct {
+		iir       repositories.ItemImageRepository
+		ir        repositories.ItemRepository
+		master    *gorm.DB
+		storage   storage.Storage
+		urlExpire time.Duration
+	}
+)
+
+func NewItemImageService(
+	itemImageRepo repositories.ItemImageRepository,
+	itemRepo repositories.ItemRepository,
+	m *gorm.DB, s storage.Storage, urlExpire time.Duration) ItemImageService {
+
+	return &itemImageServiceImpl{
+		iir:       itemImageRepo,
+		ir:        itemRepo,
+		master:    m,
+		storage:   s,
+		urlExpire: urlExpire,
+	}
+}
+
+func validateItemImage(contentType string, size int64) (ext string, err error) {
+	ext, ok := itemImageExtensions[contentType]
+	if !ok {
+		log.Info("ItemImage unsupported content type", zap.String("content_type", contentType))
+		return "", appErr.ServiceStatusBadRequestError
+	}
+	if size <= 0 || size > MaxItemImageSize {
+		log.Info("ItemImage invalid size", zap.Int64("size", size))
+		return "", appErr.ServiceStatusBadRequestError
+	}
+	return ext, nil
+}
+
+func itemImageObjectKey(itemID int, ext string) string {
+	return fmt.Sprintf("items/%d/images/%s%s", itemID, uuid.New().String(), ext)
+}
+
+func (s *itemImageServiceImpl) existsItem(tx *gorm.DB, itemID int) error {
+	item, err := s.ir.FindByID(tx, itemID)
+	if err != nil {
+		log.Error("occurred error when ItemImage call ItemRepository", zap.Error(err))
+		return appErr.BindServiceErrorWithDBError(err)
+	}
+	if item == nil {
+		return appErr.ServiceStatusBadRequestError
+	}
+	return nil
+}
+
+func (s *itemImageServiceImpl) Upload(ctx context.Context, itemID int, file *multipart.FileHeader) (image *entities.ItemImage, err error) {
+	f, err := file.Open()
+	if err != nil {
+		log.Ctx(ctx).Error("occurred error when ItemImage with Upload open file", zap.Error(err))
+		return nil, appErr.ServiceStatusBadRequestError
+	}
+	defer f.Close()
+
+	// sniff the content rather than trusting the multipart header
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		log.Ctx(ctx).Error("occurred error when ItemImage with Upload read file", zap.Error(err))
+		return nil, appErr.ServiceStatusBadRequestError
+	}
+	contentType := http.DetectContentType(head[:n])
+	ext, err := validateItemImage(contentType, file.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.existsItem(tx, itemID); err != nil {
+			return err
+		}
+
+		image = &entities.ItemImage{
+			ItemID:      uint(itemID),
+			ObjectKey:   itemImageObjectKey(itemID, ext),
+			ContentType: contentType,
+			Size:        file.Size,
+			Status:      enum.ItemImageStatusAttached,
+		}
+		body := io.MultiReader(bytes.NewReader(head[:n]), f)
+		if err := s.storage.Put(ctx, image.ObjectKey, body, contentType); err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemImage with Upload call Storage", zap.Error(err))
+			return appErr.ServiceClientError
+		}
+
+		if err := s.iir.Create(tx, image); err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemImage with Upload call ItemImageRepository", zap.Error(err))
+			if deleteErr := s.storage.Delete(ctx, image.ObjectKey); deleteErr != nil {
+				log.Ctx(ctx).Error("occurred error when ItemImage with Upload rollback Storage", zap.Error(deleteErr))
+			}
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	images := []entities.ItemImage{*image}
+	err = s.SignURLs(ctx, images)
+	image.URL = images[0].URL
+	return
+}
+
+func (s *itemImageServiceImpl) IssueUploadURL(ctx context.Context, itemID int, contentType string, size int64) (image *entities.ItemImage, upload *ItemImageUpload, err error) {
+	ext, err := validateItemImage(contentType, size)
+	if err != nil {
+		return
+	}
+
+	opts := storage.SignedURLOptions{
+		Method:      http.MethodPut,
+		ContentType: contentType,
+		Expires:     time.Now().Add(s.urlExpire),
+		MaxSize:     size,
+	}
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.existsItem(tx, itemID); err != nil {
+			return err
+		}
+
+		image = &entities.ItemImage{
+			ItemID:      uint(itemID),
+			ObjectKey:   itemImageObjectKey(itemID, ext),
+			ContentType: contentType,
+			Size:        size,
+			Status:      enum.ItemImageStatusPending,
+		}
+		if err := s.iir.Create(tx, image); err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemImage with IssueUploadURL call ItemImageRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+
+		uploadURL, err := s.storage.SignedURL(ctx, image.ObjectKey, opts)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemImage with IssueUploadURL call Storage", zap.Error(err))
+			return appErr.ServiceClientError
+		}
+		upload = &ItemImageUpload{URL: uploadURL, Headers: storage.UploadHeaders(opts), ExpiresAt: opts.Expires}
+		return nil
+	})
+	return
+}
+
+func (s *itemImageServiceImpl) Confirm(ctx context.Context, itemID int, imageID int) (image *entities.ItemImage, err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		image, err = s.iir.FindByID(tx, itemID, imageID)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemImage with Confirm call ItemImageRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		if image == nil {
+			return appErr.ServiceStatusBadRequestError
+		}
+		if image.Status == enum.ItemImageStatusAttached {
+			return nil
+		}
+
+		attrs, err := s.storage.Attrs(ctx, image.ObjectKey)
+		if errors.Is(err, storage.ErrNotFound) {
+			log.Ctx(ctx).Info("ItemImage not uploaded yet", zap.String("object_key", image.ObjectKey))
+			return appErr.ServiceStatusBadRequestError
+		}
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemImage with Confirm call Storage", zap.Error(err))
+			return appErr.ServiceClientError
+		}
+		// the object may have been uploaded some other way than the signed URL
+		if _, err := validateItemImage(attrs.ContentType, attrs.Size); err != nil {
+			if deleteErr := s.storage.Delete(ctx, image.ObjectKey); deleteErr != nil {
+				log.Ctx(ctx).Error("occurred error when ItemImage with Confirm delete Storage", zap.Error(deleteErr))
+			}
+			return err
+		}
+
+		if err := s.iir.Attach(tx, imageID, attrs.Size); err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemImage with Confirm call ItemImageRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		image.Status = enum.ItemImageStatusAttached
+		image.Size = attrs.Size
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	images := []entities.ItemImage{*image}
+	err = s.SignURLs(ctx, images)
+	image.URL = images[0].URL
+	return
+}
+
+func (s *itemImageServiceImpl) SignURLs(ctx context.Context, images []entities.ItemImage) (err error) {
+	expires := time.Now().Add(s.urlExpire)
+	for i := range images {
+		images[i].URL, err = s.storage.SignedURL(ctx, images[i].ObjectKey, storage.SignedURLOptions{
+			Method:  http.MethodGet,
+			Expires: expires,
+		})
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemImage with SignURLs call Storage", zap.Error(err))
+			return appErr.ServiceClientError
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/domain/enum"
+	"github.com/genpsp/go-app/domain/repository/mock_repositories"
+	"github.com/genpsp/go-app/pkg/configs"
+	"github.com/genpsp/go-app/pkg/logger"
+	"github.com/genpsp/go-app/pkg/storage"
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+	"gorm.io/gorm"
+)
+
+func Test_ItemImageService_Confirm(t *testing.T) {
+	Convey("ItemImageServiceを初期化", t, func() {
+		configs.TestLoadConfig()
+		cfg := configs.GetConfig()
+		logger.LoadLogger(cfg.System.Env, cfg.Logger.LogLevel, cfg.Logger.LogEncoding)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		db, mock, _ := mock_repositories.GetDBMock()
+		iir := mock_repositories.NewMockItemImageRepository(ctrl)
+		ir := mock_repositories.NewMockItemRepository(ctrl)
+		st, _ := storage.NewMemoryStorage("http://localhost/storage", []byte("secret"))
+		is := NewItemImageService(iir, ir, db, st, time.Minute)
+
+		const itemID, imageID = 1, 2
+		pending := &entities.ItemImage{
+			Model:       gorm.Model{ID: imageID},
+			ItemID:      itemID,
+			ObjectKey:   "items/1/images/a.png",
+			ContentType: "image/png",
+			Size:        1024,
+			Status:      enum.ItemImageStatusPending,
+		}
+
+		Convey("アップロード前は添付できない", func() {
+			mock.ExpectBegin()
+			mock.ExpectRollback()
+			iir.EXPECT().FindByID(gomock.Any(), itemID, imageID).Return(pending, nil)
+
+			_, err := is.Confirm(context.Background(), itemID, imageID)
+			So(err, ShouldNotBeNil)
+		})
+		Convey("アップロード済みなら添付する", func() {
+			So(st.Put(context.Background(), pending.ObjectKey, bytes.NewReader([]byte("png")), "image/png"), ShouldBeNil)
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			iir.EXPECT().FindByID(gomock.Any(), itemID, imageID).Return(pending, nil)
+			iir.EXPECT().Attach(gomock.Any(), imageID, int64(3)).Return(nil)
+
+			image, err := is.Confirm(context.Background(), itemID, imageID)
+			So(err, ShouldBeNil)
+			So(image.Status, ShouldEqual, enum.ItemImageStatusAttached)
+			So(image.URL, ShouldNotBeEmpty)
+			So(image.Size, ShouldEqual, 3)
+		})
+		Convey("別の種類のファイルは添付せず削除する", func() {
+			So(st.Put(context.Background(), pending.ObjectKey, bytes.NewReader([]byte("txt")), "text/plain"), ShouldBeNil)
+			mock.ExpectBegin()
+			mock.ExpectRollback()
+			iir.EXPECT().FindByID(gomock.Any(), itemID, imageID).Return(pending, nil)
+
+			_, err := is.Confirm(context.Background(), itemID, imageID)
+			So(err, ShouldNotBeNil)
+			_, err = st.Attrs(context.Background(), pending.ObjectKey)
+			So(err, ShouldEqual, storage.ErrNotFound)
+		})
+	})
+}
@@ -0,0 +1,288 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/domain/enum"
+	repositories "github.com/genpsp/go-app/domain/repository"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"github.com/genpsp/go-app/pkg/storage"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+	"gorm.io/gorm"
+)
+
+const (
+	MaxItemImportRows = 10000
+
+	// the response carries at most this many row errors; the error report has all of them
+	itemImportResponseErrors = 100
+)
+
+type (
+	ItemImportService interface {
+		Import(ctx context.Context, r io.Reader, dryRun bool, v Validator) (result *ItemImportResult, err error)
+	}
+
+	// Validator is satisfied by the echo validator installed on the HTTP server.
+	Validator interface {
+		Validate(i interface{}) error
+	}
+
+	ItemImportRow struct {
+		Code  string `validate:"required,max=64"`
+		Name  string `validate:"required,max=255"`
+		Price int    `validate:"min=0"`
+	}
+
+	ItemImportError struct {
+		Row      int
+		Code     string
+		Messages []string
+	}
+
+	ItemImportResult struct {
+		DryRun         bool
+		TotalRows      int
+		ValidRows      int
+		RejectedRows   int
+		Created        int
+		Updated        int
+		Errors         []ItemImportError
+		ErrorReportURL string
+	}
+
+	itemImportServiceImpl struct {
+		ir        repositories.ItemRepository
+		irr       repositories.ItemRevisionRepository
+		ipr       repositories.ItemPriceRepository
+		master    *gorm.DB
+		storage   storage.Storage
+		urlExpire time.Duration
+	}
+)
+
+func NewItemImportService(
+	itemRepo repositories.ItemRepository,
+	itemRevisionRepo repositories.ItemRevisionRepository,
+	itemPriceRepo repositories.ItemPriceRepository,
+	m *gorm.DB, s storage.Storage, urlExpire time.Duration) ItemImportService {
+
+	return &itemImportServiceImpl{
+		ir:        itemRepo,
+		irr:       itemRevisionRepo,
+		ipr:       itemPriceRepo,
+		master:    m,
+		storage:   s,
+		urlExpire: urlExpire,
+	}
+}
+
+// itemImportColumns maps the accepted CSV header names to ItemImportRow fields.
+var itemImportColumns = map[string]func(row *ItemImportRow, value string) error{
+	"code": func(row *ItemImportRow, value string) error {
+		row.Code = value
+		return nil
+	},
+	"name": func(row *ItemImportRow, value string) error {
+		row.Name = value
+		return nil
+	},
+	"price": func(row *ItemImportRow, value string) (err error) {
+		if value == "" {
+			return nil
+		}
+		row.Price, err = strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("price: must be an integer")
+		}
+		return nil
+	},
+}
+
+var itemImportRequiredColumns = []string{"code", "name"}
+
+// Import validates every row of the CSV and, unless dryRun, upserts the valid ones by code.
+// Rejected rows never abort the import; they are reported in the result and the error report.
+func (s *itemImportServiceImpl) Import(ctx context.Context, r io.Reader, dryRun bool, v Validator) (result *ItemImportResult, err error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		log.Ctx(ctx).Info("ItemImport read header error", zap.Error(err))
+		return nil, appErr.ServiceStatusBadRequestError
+	}
+	columns := make([]string, len(header))
+	found := map[string]bool{}
+	for i, h := range header {
+		columns[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		found[columns[i]] = true
+	}
+	for _, c := range itemImportRequiredColumns {
+		if !found[c] {
+			log.Ctx(ctx).Info("ItemImport missing column", zap.String("column", c))
+			return nil, appErr.ServiceStatusBadRequestError
+		}
+	}
+
+	result = &ItemImportResult{DryRun: dryRun, Errors: []ItemImportError{}}
+	var rejected []ItemImportError
+	var rejectedRecords [][]string
+	var items []entities.Item
+	seen := map[string]int{}
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		result.TotalRows++
+		if result.TotalRows > MaxItemImportRows {
+			log.Ctx(ctx).Info("ItemImport too many rows", zap.Int("max_rows", MaxItemImportRows))
+			return nil, appErr.ServiceStatusBadRequestError
+		}
+		var messages []string
+		row := ItemImportRow{}
+		if err != nil {
+			messages = append(messages, err.Error())
+		} else {
+			messages = parseItemImportRow(&row, columns, record, v)
+		}
+		if prev, ok := seen[row.Code]; ok && row.Code != "" {
+			messages = append(messages, fmt.Sprintf("code: duplicated with row %d", prev))
+		}
+		if len(messages) > 0 {
+			rejected = append(rejected, ItemImportError{Row: line, Code: row.Code, Messages: messages})
+			rejectedRecords = append(rejectedRecords, append(record, strings.Join(messages, "; ")))
+			continue
+		}
+		seen[row.Code] = line
+		items = append(items, entities.Item{Code: itemCode(row.Code), Name: row.Name, Price: row.Price})
+	}
+	result.ValidRows = len(items)
+	result.RejectedRows = len(rejected)
+	if len(rejected) > itemImportResponseErrors {
+		result.Errors = rejected[:itemImportResponseErrors]
+	} else if len(rejected) > 0 {
+		result.Errors = rejected
+	}
+
+	if len(rejected) > 0 {
+		if result.ErrorReportURL, err = s.writeErrorReport(ctx, append(header, "error"), rejectedRecords); err != nil {
+			return nil, err
+		}
+	}
+	if len(items) == 0 {
+		return result, nil
+	}
+
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		codes := make([]string, len(items))
+		for i, item := range items {
+			codes[i] = *item.Code
+		}
+		existing, err := s.ir.FindByCodes(tx, codes)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemImport with Import call ItemRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		result.Updated = len(*existing)
+		result.Created = len(items) - result.Updated
+		if dryRun {
+			return nil
+		}
+
+		if err := s.ir.UpsertByCode(tx, &items); err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemImport with Import call ItemRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		now := time.Now()
+		if err := s.irr.RecordByCodes(tx, codes, enum.ItemRevisionActionImport, now); err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemImport with Import call ItemRevisionRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+
+		// the upsert does not return the IDs of the updated items
+		upserted, err := s.ir.FindByCodes(tx, codes)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemImport with Import call ItemRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		priceByCode := make(map[string]int, len(items))
+		for _, item := range items {
+			priceByCode[*item.Code] = item.Price
+		}
+		prices := make(map[uint]int, len(*upserted))
+		for _, item := range *upserted {
+			prices[item.ID] = priceByCode[*item.Code]
+		}
+		return recordItemPrices(ctx, tx, s.ipr, prices, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func parseItemImportRow(row *ItemImportRow, columns []string, record []string, v Validator) (messages []string) {
+	for i, value := range record {
+		if i >= len(columns) {
+			break
+		}
+		set, ok := itemImportColumns[columns[i]]
+		if !ok {
+			continue
+		}
+		if err := set(row, strings.TrimSpace(value)); err != nil {
+			messages = append(messages, err.Error())
+		}
+	}
+	if err := v.Validate(row); err != nil {
+		var verrs validator.ValidationErrors
+		if !errors.As(err, &verrs) {
+			return append(messages, err.Error())
+		}
+		for _, fe := range verrs {
+			messages = append(messages, fmt.Sprintf("%s: failed on %s", strings.ToLower(fe.Field()), fe.Tag()))
+		}
+	}
+	return
+}
+
+// writeErrorReport stores the rejected rows with an extra error column and returns a signed download URL.
+func (s *itemImportServiceImpl) writeErrorReport(ctx context.Context, header []string, records [][]string) (url string, err error) {
+	var buf bytes.Buffer
+	cw := csv.NewWriter(&buf)
+	cw.Write(header)
+	cw.WriteAll(records)
+	if err = cw.Error(); err != nil {
+		log.Ctx(ctx).Error("occurred error when ItemImport with writeErrorReport", zap.Error(err))
+		return "", appErr.ServiceClientError
+	}
+
+	key := fmt.Sprintf("imports/items/%s-errors.csv", uuid.New().String())
+	if err = s.storage.Put(ctx, key, &buf, "text/csv; charset=utf-8"); err != nil {
+		log.Ctx(ctx).Error("occurred error when ItemImport with writeErrorReport call Storage", zap.Error(err))
+		return "", appErr.ServiceClientError
+	}
+	url, err = s.storage.SignedURL(ctx, key, storage.SignedURLOptions{
+		Method:  http.MethodGet,
+		Expires: time.Now().Add(s.urlExpire),
+	})
+	if err != nil {
+		log.Ctx(ctx).Error("occurred error when ItemImport with writeErrorReport call Storage", zap.Error(err))
+		return "", appErr.ServiceClientError
+	}
+	return
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/domain/enum"
+	"github.com/genpsp/go-app/domain/repository/memory_repositories"
+	"github.com/genpsp/go-app/domain/repository/mock_repositories"
+	"github.com/genpsp/go-app/pkg/configs"
+	"github.com/genpsp/go-app/pkg/logger"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"github.com/genpsp/go-app/pkg/storage"
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/go-playground/validator.v9"
+	"gorm.io/gorm"
+)
+
+type testValidator struct {
+	validator *validator.Validate
+}
+
+func (v *testValidator) Validate(i interface{}) error {
+	return v.validator.Struct(i)
+}
+
+func Test_ItemImportService(t *testing.T) {
+	Convey("ItemImportServiceを初期化", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		configs.TestLoadConfig()
+		cfg := configs.GetConfig()
+		logger.LoadLogger(cfg.System.Env, cfg.Logger.LogLevel, cfg.Logger.LogEncoding)
+
+		db, mock, _ := mock_repositories.GetDBMock()
+		ir := mock_repositories.NewMockItemRepository(ctrl)
+		rr := mock_repositories.NewMockItemRevisionRepository(ctrl)
+		pr := memory_repositories.NewItemPriceRepository()
+		st, _ := storage.NewMemoryStorage("/storage", nil)
+		is := NewItemImportService(ir, rr, pr, db, st, 0)
+		v := &testValidator{validator: validator.New()}
+		So(is, ShouldNotBeNil)
+
+		const csv = "Code,Name,Price\n" +
+			"A-1,テスト,100\n" +
+			"A-2,,200\n" +
+			"A-3,テスト3,abc\n" +
+			"A-1,重複,300\n" +
+			"A-4,テスト4,400\n"
+
+		Convey("dryRunの場合検証のみ行い書き込まない", func() {
+			existing := []entities.Item{{Model: gorm.Model{ID: 1}, Code: itemCode("A-1")}}
+			mock.ExpectBegin()
+			ir.EXPECT().FindByCodes(gomock.Any(), []string{"A-1", "A-4"}).Return(&existing, nil)
+			mock.ExpectCommit()
+
+			result, err := is.Import(context.Background(), strings.NewReader(csv), true, v)
+			So(err, ShouldBeNil)
+			So(result.TotalRows, ShouldEqual, 5)
+			So(result.ValidRows, ShouldEqual, 2)
+			So(result.RejectedRows, ShouldEqual, 3)
+			So(result.Created, ShouldEqual, 1)
+			So(result.Updated, ShouldEqual, 1)
+			So(result.Errors[0].Row, ShouldEqual, 3)
+			So(result.Errors[0].Messages, ShouldResemble, []string{"name: failed on required"})
+			So(result.Errors[1].Messages, ShouldResemble, []string{"price: must be an integer"})
+			So(result.Errors[2].Messages, ShouldResemble, []string{"code: duplicated with row 2"})
+			So(result.ErrorReportURL, ShouldNotBeEmpty)
+
+			objects, _ := st.List(context.Background(), "imports/items/")
+			So(len(objects), ShouldEqual, 1)
+		})
+		Convey("codeで有効な行をupsertする", func() {
+			existing := []entities.Item{}
+			mock.ExpectBegin()
+			ir.EXPECT().FindByCodes(gomock.Any(), gomock.Any()).Return(&existing, nil)
+			ir.EXPECT().UpsertByCode(gomock.Any(), &[]entities.Item{
+				{Code: itemCode("A-1"), Name: "テスト", Price: 100},
+				{Code: itemCode("A-4"), Name: "テスト4", Price: 400},
+			}).Return(nil)
+			rr.EXPECT().RecordByCodes(gomock.Any(), []string{"A-1", "A-4"}, enum.ItemRevisionActionImport, gomock.Any()).Return(nil)
+			ir.EXPECT().FindByCodes(gomock.Any(), gomock.Any()).Return(&[]entities.Item{
+				{Model: gorm.Model{ID: 1}, Code: itemCode("A-1")},
+				{Model: gorm.Model{ID: 4}, Code: itemCode("A-4")},
+			}, nil)
+			mock.ExpectCommit()
+
+			result, err := is.Import(context.Background(), strings.NewReader(csv), false, v)
+			So(err, ShouldBeNil)
+			So(result.Created, ShouldEqual, 2)
+			prices, _ := pr.FindByItemIDsForUpdate(nil, []uint{1, 4})
+			So(len(*prices), ShouldEqual, 2)
+		})
+		Convey("変更した価格は読み戻した時に反映されている", func() {
+			yesterday := time.Now().Add(-24 * time.Hour)
+			nextWeek := time.Now().Add(7 * 24 * time.Hour)
+			pr.Create(nil, &entities.ItemPrice{ItemID: 1, Price: 100, EffectiveFrom: yesterday, EffectiveTo: &nextWeek})
+			pr.Create(nil, &entities.ItemPrice{ItemID: 1, Price: 80, EffectiveFrom: nextWeek})
+			ps := NewItemPriceService(pr, ir, db)
+
+			existing := []entities.Item{{Model: gorm.Model{ID: 1}, Code: itemCode("A-1"), Price: 100}}
+			mock.ExpectBegin()
+			ir.EXPECT().FindByCodes(gomock.Any(), []string{"A-1"}).Return(&existing, nil).Times(2)
+			ir.EXPECT().UpsertByCode(gomock.Any(), gomock.Any()).Return(nil)
+			rr.EXPECT().RecordByCodes(gomock.Any(), []string{"A-1"}, enum.ItemRevisionActionImport, gomock.Any()).Return(nil)
+			mock.ExpectCommit()
+
+			_, err := is.Import(context.Background(), strings.NewReader("code,name,price\nA-1,テスト,150\n"), false, v)
+			So(err, ShouldBeNil)
+
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			items := []entities.Item{{Model: gorm.Model{ID: 1}, Price: 150}}
+			So(ps.ApplyEffective(context.Background(), items, time.Now()), ShouldBeNil)
+			So(items[0].Price, ShouldEqual, 150)
+
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			So(ps.ApplyEffective(context.Background(), items, nextWeek), ShouldBeNil)
+			So(items[0].Price, ShouldEqual, 80)
+
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			So(ps.ApplyEffective(context.Background(), items, yesterday), ShouldBeNil)
+			So(items[0].Price, ShouldEqual, 100)
+		})
+		Convey("必須カラムがない場合エラーを返す", func() {
+			_, err := is.Import(context.Background(), strings.NewReader("name,price\nテスト,100\n"), false, v)
+			So(err, ShouldEqual, appErr.ServiceStatusBadRequestError)
+		})
+	})
+}
@@ -0,0 +1,252 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	repositories "github.com/genpsp/go-app/domain/repository"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// itemPriceScheduleTolerance absorbs the clock skew of clients scheduling a price "now".
+const itemPriceScheduleTolerance = time.Minute
+
+type (
+	ItemPriceService interface {
+		History(ctx context.Context, itemID int) (prices *[]entities.ItemPrice, err error)
+		Schedule(ctx context.Context, itemID int, price int, effectiveFrom time.Time, effectiveTo *time.Time) (priceEntity *entities.ItemPrice, err error)
+		ApplyEffective(ctx context.Context, items []entities.Item, at time.Time) (err error)
+	}
+
+	itemPriceServiceImpl struct {
+		ipr    repositories.ItemPriceRepository
+		ir     repositories.ItemRepository
+		master *gorm.DB
+	}
+
+	// itemPriceSplice lists the changes to apply to the existing ranges of an item
+	// so a new range fits in without overlapping them.
+	itemPriceSplice struct {
+		updates []entities.ItemPrice
+		creates []entities.ItemPrice
+		deletes []uint
+	}
+)
+
+func NewItemPriceService(
+	itemPriceRepo repositories.ItemPriceRepository,
+	itemRepo repositories.ItemRepository,
+	m *gorm.DB) ItemPriceService {
+
+	return &itemPriceServiceImpl{
+		ipr:    itemPriceRepo,
+		ir:     itemRepo,
+		master: m,
+	}
+}
+
+func (s *itemPriceServiceImpl) History(ctx context.Context, itemID int) (prices *[]entities.ItemPrice, err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		prices, err = s.ipr.FindByItemID(tx, itemID)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemPrice with History call ItemPriceRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	return
+}
+
+// spliceItemPrices cuts [from, to) out of prices: ranges starting before from are
+// closed at from, ranges ending after to restart at to, and covered ranges are dropped.
+func spliceItemPrices(prices []entities.ItemPrice, from time.Time, to *time.Time) itemPriceSplice {
+	var splice itemPriceSplice
+	for _, p := range prices {
+		endsAfterFrom := p.EffectiveTo == nil || p.EffectiveTo.After(from)
+		startsBeforeTo := to == nil || p.EffectiveFrom.Before(*to)
+		if !endsAfterFrom || !startsBeforeTo {
+			continue
+		}
+		outlivesTo := to != nil && (p.EffectiveTo == nil || p.EffectiveTo.After(*to))
+
+		if p.EffectiveFrom.Before(from) {
+			if outlivesTo {
+				splice.creates = append(splice.creates, entities.ItemPrice{
+					ItemID:        p.ItemID,
+					Price:         p.Price,
+					EffectiveFrom: *to,
+					EffectiveTo:   p.EffectiveTo,
+				})
+			}
+			end := from
+			p.EffectiveTo = &end
+			splice.updates = append(splice.updates, p)
+			continue
+		}
+		if outlivesTo {
+			p.EffectiveFrom = *to
+			splice.updates = append(splice.updates, p)
+			continue
+		}
+		splice.deletes = append(splice.deletes, p.ID)
+	}
+	return splice
+}
+
+func applyItemPriceSplice(ctx context.Context, tx *gorm.DB, ipr repositories.ItemPriceRepository, splice itemPriceSplice) error {
+	for _, id := range splice.deletes {
+		if err := ipr.Delete(tx, id); err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemPrice call ItemPriceRepository Delete", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+	}
+	for _, p := range splice.updates {
+		if err := ipr.UpdateRange(tx, p.ID, p.EffectiveFrom, p.EffectiveTo); err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemPrice call ItemPriceRepository UpdateRange", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+	}
+	for i := range splice.creates {
+		if err := ipr.Create(tx, &splice.creates[i]); err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemPrice call ItemPriceRepository Create", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+	}
+	return nil
+}
+
+// recordItemPrices makes prices, keyed by item ID, the prices of the items from
+// at on, for writes such as an import that set item.price directly. The range
+// effective at at is closed and the new one runs until the next scheduled range,
+// so ApplyEffective does not hide the new price. Unchanged prices are left alone.
+func recordItemPrices(ctx context.Context, tx *gorm.DB, ipr repositories.ItemPriceRepository, prices map[uint]int, at time.Time) error {
+	if len(prices) == 0 {
+		return nil
+	}
+	itemIDs := make([]uint, 0, len(prices))
+	for id := range prices {
+		itemIDs = append(itemIDs, id)
+	}
+	sort.Slice(itemIDs, func(i, j int) bool { return itemIDs[i] < itemIDs[j] })
+
+	existing, err := ipr.FindByItemIDsForUpdate(tx, itemIDs)
+	if err != nil {
+		log.Ctx(ctx).Error("occurred error when ItemPrice call ItemPriceRepository FindByItemIDsForUpdate", zap.Error(err))
+		return appErr.BindServiceErrorWithDBError(err)
+	}
+	byItem := make(map[uint][]entities.ItemPrice, len(itemIDs))
+	for _, p := range *existing {
+		byItem[p.ItemID] = append(byItem[p.ItemID], p)
+	}
+
+	for _, id := range itemIDs {
+		var current *entities.ItemPrice
+		var next *time.Time
+		for i, p := range byItem[id] {
+			if p.EffectiveAt(at) {
+				current = &byItem[id][i]
+			}
+			if p.EffectiveFrom.After(at) && (next == nil || p.EffectiveFrom.Before(*next)) {
+				from := p.EffectiveFrom
+				next = &from
+			}
+		}
+		if current != nil && current.Price == prices[id] {
+			continue
+		}
+
+		if err := applyItemPriceSplice(ctx, tx, ipr, spliceItemPrices(byItem[id], at, next)); err != nil {
+			return err
+		}
+		price := &entities.ItemPrice{ItemID: id, Price: prices[id], EffectiveFrom: at, EffectiveTo: next}
+		if err := ipr.Create(tx, price); err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemPrice call ItemPriceRepository Create", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+	}
+	return nil
+}
+
+// Schedule sets the price of the item for [effectiveFrom, effectiveTo), replacing
+// whatever was planned for that period. Past periods can not be rewritten.
+func (s *itemPriceServiceImpl) Schedule(ctx context.Context, itemID int, price int, effectiveFrom time.Time, effectiveTo *time.Time) (priceEntity *entities.ItemPrice, err error) {
+	now := time.Now()
+	if effectiveFrom.IsZero() {
+		effectiveFrom = now
+	}
+	if price < 0 || effectiveFrom.Before(now.Add(-itemPriceScheduleTolerance)) {
+		return nil, appErr.ServiceStatusBadRequestError
+	}
+	if effectiveTo != nil && !effectiveTo.After(effectiveFrom) {
+		return nil, appErr.ServiceStatusBadRequestError
+	}
+
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		item, err := s.ir.FindByID(tx, itemID)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemPrice with Schedule call ItemRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		if item == nil {
+			return appErr.ServiceStatusBadRequestError
+		}
+		prices, err := s.ipr.FindByItemIDsForUpdate(tx, []uint{item.ID})
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemPrice with Schedule call ItemPriceRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+
+		splice := spliceItemPrices(*prices, effectiveFrom, effectiveTo)
+		if err := applyItemPriceSplice(ctx, tx, s.ipr, splice); err != nil {
+			return err
+		}
+
+		priceEntity = &entities.ItemPrice{
+			ItemID:        item.ID,
+			Price:         price,
+			EffectiveFrom: effectiveFrom,
+			EffectiveTo:   effectiveTo,
+		}
+		if err := s.ipr.Create(tx, priceEntity); err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemPrice with Schedule call ItemPriceRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	return
+}
+
+// ApplyEffective sets the price of each item to the one effective at the given time.
+// Items without a price range keep their own price.
+func (s *itemPriceServiceImpl) ApplyEffective(ctx context.Context, items []entities.Item, at time.Time) (err error) {
+	if len(items) == 0 {
+		return nil
+	}
+	itemIDs := make([]uint, len(items))
+	for i, item := range items {
+		itemIDs[i] = item.ID
+	}
+
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		prices, err := s.ipr.FindEffective(tx, itemIDs, at)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemPrice with ApplyEffective call ItemPriceRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		byItem := make(map[uint]int, len(*prices))
+		for _, p := range *prices {
+			byItem[p.ItemID] = p.Price
+		}
+		for i := range items {
+			if price, ok := byItem[items[i].ID]; ok {
+				items[i].Price = price
+			}
+		}
+		return nil
+	})
+	return
+}
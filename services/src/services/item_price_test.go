@@ -0,0 +1,53 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	. "github.com/smartystreets/goconvey/convey"
+	"gorm.io/gorm"
+)
+
+func Test_spliceItemPrices(t *testing.T) {
+	Convey("価格期間の差し込み", t, func() {
+		day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+		dayPtr := func(d int) *time.Time { t := day(d); return &t }
+
+		Convey("無期限の価格は開始日で閉じられる", func() {
+			prices := []entities.ItemPrice{
+				{Model: gorm.Model{ID: 1}, ItemID: 1, Price: 1000, EffectiveFrom: day(1)},
+			}
+			splice := spliceItemPrices(prices, day(10), nil)
+			So(len(splice.updates), ShouldEqual, 1)
+			So(*splice.updates[0].EffectiveTo, ShouldEqual, day(10))
+			So(splice.creates, ShouldBeEmpty)
+			So(splice.deletes, ShouldBeEmpty)
+		})
+		Convey("期間限定の価格は既存の価格を分割する", func() {
+			prices := []entities.ItemPrice{
+				{Model: gorm.Model{ID: 1}, ItemID: 1, Price: 1000, EffectiveFrom: day(1)},
+			}
+			splice := spliceItemPrices(prices, day(10), dayPtr(20))
+			So(len(splice.updates), ShouldEqual, 1)
+			So(*splice.updates[0].EffectiveTo, ShouldEqual, day(10))
+			So(len(splice.creates), ShouldEqual, 1)
+			So(splice.creates[0].Price, ShouldEqual, 1000)
+			So(splice.creates[0].EffectiveFrom, ShouldEqual, day(20))
+			So(splice.creates[0].EffectiveTo, ShouldBeNil)
+		})
+		Convey("覆われる予定は削除され、はみ出す予定は後ろにずれる", func() {
+			prices := []entities.ItemPrice{
+				{Model: gorm.Model{ID: 1}, ItemID: 1, Price: 1000, EffectiveFrom: day(1), EffectiveTo: dayPtr(10)},
+				{Model: gorm.Model{ID: 2}, ItemID: 1, Price: 800, EffectiveFrom: day(10), EffectiveTo: dayPtr(15)},
+				{Model: gorm.Model{ID: 3}, ItemID: 1, Price: 1200, EffectiveFrom: day(15)},
+			}
+			splice := spliceItemPrices(prices, day(10), dayPtr(20))
+			So(splice.deletes, ShouldResemble, []uint{2})
+			So(len(splice.updates), ShouldEqual, 1)
+			So(splice.updates[0].ID, ShouldEqual, 3)
+			So(splice.updates[0].EffectiveFrom, ShouldEqual, day(20))
+			So(splice.creates, ShouldBeEmpty)
+		})
+	})
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/domain/enum"
+	"github.com/genpsp/go-app/domain/repository/mock_repositories"
+	"github.com/genpsp/go-app/pkg/configs"
+	"github.com/genpsp/go-app/pkg/logger"
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+	"gorm.io/gorm"
+)
+
+func Test_ItemServiceRevision(t *testing.T) {
+	Convey("ItemServiceを初期化", t, func() {
+		configs.TestLoadConfig()
+		cfg := configs.GetConfig()
+		logger.LoadLogger(cfg.System.Env, cfg.Logger.LogLevel, cfg.Logger.LogEncoding)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		db, mock, _ := mock_repositories.GetDBMock()
+		ar := mock_repositories.NewMockItemRepository(ctrl)
+		rr := mock_repositories.NewMockItemRevisionRepository(ctrl)
+		as := NewItemService(ar, rr, db, nil)
+
+		const itemID = 1
+		at := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+		Convey("指定時刻のリビジョンから商品を復元できる", func() {
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			rr.EXPECT().FindAsOf(gomock.Any(), itemID, at).Return(&entities.ItemRevision{ItemID: itemID, Revision: 2, Code: "A-1", Name: "旧名称", CreatedAt: at.Add(-time.Hour)}, nil)
+			ar.EXPECT().FindByID(gomock.Any(), itemID).Return(&entities.Item{
+				Model:      gorm.Model{ID: itemID},
+				Name:       "新名称",
+				Price:      1200,
+				Images:     []entities.ItemImage{{ObjectKey: "items/1/images/a.png"}},
+				Categories: []entities.Category{{Name: "トップス"}},
+				Tags:       []entities.Tag{{Name: "sale"}},
+			}, nil)
+
+			item, err := as.FindAsOf(context.Background(), itemID, at)
+			So(err, ShouldBeNil)
+			So(item.ID, ShouldEqual, itemID)
+			So(item.Name, ShouldEqual, "旧名称")
+			Convey("バージョン管理されない関連は返さない", func() {
+				So(item.Price, ShouldEqual, 1200)
+				So(item.Images, ShouldBeEmpty)
+				So(item.Categories, ShouldBeEmpty)
+				So(item.Tags, ShouldBeEmpty)
+			})
+		})
+		Convey("現在の商品が削除されていても取得できる", func() {
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			rr.EXPECT().FindAsOf(gomock.Any(), itemID, at).Return(&entities.ItemRevision{ItemID: itemID, Revision: 2, Name: "旧名称"}, nil)
+			ar.EXPECT().FindByID(gomock.Any(), itemID).Return(nil, nil)
+
+			item, err := as.FindAsOf(context.Background(), itemID, at)
+			So(err, ShouldBeNil)
+			So(item.Name, ShouldEqual, "旧名称")
+			So(item.Images, ShouldBeEmpty)
+		})
+		Convey("削除済みのリビジョンはnilを返す", func() {
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			rr.EXPECT().FindAsOf(gomock.Any(), itemID, at).Return(&entities.ItemRevision{ItemID: itemID, Deleted: true}, nil)
+
+			item, err := as.FindAsOf(context.Background(), itemID, at)
+			So(err, ShouldBeNil)
+			So(item, ShouldBeNil)
+		})
+		Convey("リビジョンに戻すと新しいリビジョンが記録される", func() {
+			revision := &entities.ItemRevision{ItemID: itemID, Revision: 2, Code: "A-1", Name: "旧名称"}
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			ar.EXPECT().FindByID(gomock.Any(), itemID).Return(&entities.Item{Model: gorm.Model{ID: itemID}}, nil)
+			rr.EXPECT().FindByRevision(gomock.Any(), itemID, 2).Return(revision, nil)
+			ar.EXPECT().Restore(gomock.Any(), itemID, revision).Return(nil)
+			rr.EXPECT().Record(gomock.Any(), []uint{itemID}, enum.ItemRevisionActionRevert, gomock.Any()).Return(nil)
+
+			err := as.Revert(context.Background(), itemID, 2)
+			So(err, ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"html"
+	"strings"
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	repositories "github.com/genpsp/go-app/domain/repository"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	ItemSearchDefaultLimit = 20
+
+	itemSearchHighlightPre  = "<em>"
+	itemSearchHighlightPost = "</em>"
+)
+
+type (
+	ItemSearchService interface {
+		Search(ctx context.Context, query string, limit int, offset int) (result *ItemSearchResult, err error)
+	}
+
+	ItemSearchResult struct {
+		Total int64
+		Hits  []ItemSearchHit
+	}
+
+	// ItemSearchHit carries the item name HTML escaped with the matched terms wrapped in <em>.
+	ItemSearchHit struct {
+		repositories.ItemSearchHit
+		HighlightedName string
+	}
+
+	itemSearchServiceImpl struct {
+		isr    repositories.ItemSearchRepository
+		iis    ItemImageService
+		ips    ItemPriceService
+		master *gorm.DB
+	}
+)
+
+func NewItemSearchService(
+	itemSearchRepo repositories.ItemSearchRepository, itemImageService ItemImageService,
+	itemPriceService ItemPriceService, m *gorm.DB) ItemSearchService {
+
+	return &itemSearchServiceImpl{
+		isr:    itemSearchRepo,
+		iis:    itemImageService,
+		ips:    itemPriceService,
+		master: m,
+	}
+}
+
+func (s *itemSearchServiceImpl) Search(ctx context.Context, query string, limit int, offset int) (result *ItemSearchResult, err error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, appErr.ServiceStatusBadRequestError
+	}
+	if limit <= 0 {
+		limit = ItemSearchDefaultLimit
+	}
+
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		hits, total, err := s.isr.Search(tx, query, limit, offset)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemSearch with Search call ItemSearchRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		terms := strings.Fields(query)
+		result = &ItemSearchResult{Total: total, Hits: make([]ItemSearchHit, len(*hits))}
+		for i, hit := range *hits {
+			result.Hits[i] = ItemSearchHit{
+				ItemSearchHit:   hit,
+				HighlightedName: highlight(hit.Item.Name, terms),
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]entities.Item, len(result.Hits))
+	for i, hit := range result.Hits {
+		items[i] = hit.Item
+	}
+	if err = s.ips.ApplyEffective(ctx, items, time.Now()); err != nil {
+		return nil, err
+	}
+	for i := range result.Hits {
+		result.Hits[i].Item.Price = items[i].Price
+	}
+	for i := range result.Hits {
+		if err = s.iis.SignURLs(ctx, result.Hits[i].Item.Images); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// highlight escapes text for HTML and wraps every case-insensitive occurrence of terms in <em>.
+// Overlapping occurrences are merged into one highlighted span.
+func highlight(text string, terms []string) string {
+	lower := []rune(strings.ToLower(text))
+	runes := []rune(text)
+	if len(lower) != len(runes) {
+		// lower casing changed the length; fall back to exact matching
+		lower = runes
+	}
+	marked := make([]bool, len(runes))
+	for _, term := range terms {
+		t := []rune(strings.ToLower(term))
+		if len(t) == 0 {
+			continue
+		}
+		for i := 0; i+len(t) <= len(lower); i++ {
+			if string(lower[i:i+len(t)]) == string(t) {
+				for j := i; j < i+len(t); j++ {
+					marked[j] = true
+				}
+			}
+		}
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && marked[j] == marked[i] {
+			j++
+		}
+		segment := html.EscapeString(string(runes[i:j]))
+		if marked[i] {
+			b.WriteString(itemSearchHighlightPre + segment + itemSearchHighlightPost)
+		} else {
+			b.WriteString(segment)
+		}
+		i = j
+	}
+	return b.String()
+}
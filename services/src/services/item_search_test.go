@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/domain/repository/memory_repositories"
+	"github.com/genpsp/go-app/domain/repository/mock_repositories"
+	"github.com/genpsp/go-app/pkg/configs"
+	"github.com/genpsp/go-app/pkg/logger"
+	"github.com/genpsp/go-app/pkg/storage"
+	. "github.com/smartystreets/goconvey/convey"
+	"gorm.io/gorm"
+)
+
+func Test_ItemSearchService(t *testing.T) {
+	Convey("ItemSearchServiceを初期化", t, func() {
+		configs.TestLoadConfig()
+		cfg := configs.GetConfig()
+		logger.LoadLogger(cfg.System.Env, cfg.Logger.LogLevel, cfg.Logger.LogEncoding)
+
+		db, mock, _ := mock_repositories.GetDBMock()
+		isr := memory_repositories.NewItemSearchRepository(
+			entities.Item{Model: gorm.Model{ID: 1}, Name: "赤いTシャツ", Images: []entities.ItemImage{{ObjectKey: "items/1/images/a.png"}}},
+			entities.Item{Model: gorm.Model{ID: 2}, Name: "Tシャツ Tシャツ <限定>"},
+			entities.Item{Model: gorm.Model{ID: 3}, Name: "青いパーカー"},
+		)
+		st, _ := storage.NewMemoryStorage("http://localhost/storage", []byte("secret"))
+		pr := memory_repositories.NewItemPriceRepository(
+			entities.ItemPrice{ItemID: 1, Price: 800, EffectiveFrom: time.Now().Add(-time.Hour)},
+		)
+		ss := NewItemSearchService(isr, NewItemImageService(nil, nil, db, st, time.Minute), NewItemPriceService(pr, nil, db), db)
+
+		Convey("関連度順に取得しハイライトできる", func() {
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+
+			result, err := ss.Search(context.Background(), "tシャツ", 10, 0)
+			So(err, ShouldBeNil)
+			So(result.Total, ShouldEqual, 2)
+			So(result.Hits[0].Item.ID, ShouldEqual, 2)
+			So(result.Hits[0].HighlightedName, ShouldEqual, "<em>Tシャツ</em> <em>Tシャツ</em> &lt;限定&gt;")
+			So(result.Hits[1].HighlightedName, ShouldEqual, "赤い<em>Tシャツ</em>")
+			So(result.Hits[1].Item.Images[0].URL, ShouldNotBeEmpty)
+			So(result.Hits[1].Item.Price, ShouldEqual, 800)
+		})
+		Convey("offsetとlimitでページングできる", func() {
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+
+			result, err := ss.Search(context.Background(), "シャツ", 1, 1)
+			So(err, ShouldBeNil)
+			So(result.Total, ShouldEqual, 2)
+			So(len(result.Hits), ShouldEqual, 1)
+			So(result.Hits[0].Item.ID, ShouldEqual, 1)
+		})
+		Convey("隣接する語はまとめてハイライトする", func() {
+			So(highlight("赤いTシャツ", []string{"赤い", "tシャツ"}), ShouldEqual, "<em>赤いTシャツ</em>")
+		})
+	})
+}
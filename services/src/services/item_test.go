@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"firebase.google.com/go/v4/auth"
 	"github.com/genpsp/go-app/pkg/configs"
 	"github.com/genpsp/go-app/pkg/logger"
@@ -22,8 +23,9 @@ func Test_NewItemService(t *testing.T) {
 		defer ctrl.Finish()
 		db, _, _ := mock_repositories.GetDBMock()
 		ar := mock_repositories.NewMockItemRepository(ctrl)
+		rr := mock_repositories.NewMockItemRevisionRepository(ctrl)
 		fbAuth := mock_pkgs.NewMockAuthAdmin(ctrl)
-		as := NewItemService(ar, db, fbAuth)
+		as := NewItemService(ar, rr, db, fbAuth)
 		So(as, ShouldNotBeNil)
 	})
 }
@@ -47,8 +49,9 @@ func Test_ItemService(t *testing.T) {
 		var password = utils.RandomString(8)
 
 		ar := mock_repositories.NewMockItemRepository(ctrl)
+		rr := mock_repositories.NewMockItemRevisionRepository(ctrl)
 		fbAuth := mock_pkgs.NewMockAuthAdmin(ctrl)
-		as := NewItemService(ar, db, fbAuth)
+		as := NewItemService(ar, rr, db, fbAuth)
 		So(as, ShouldNotBeNil)
 
 		Convey("FindAll", func() {
@@ -59,7 +62,7 @@ func Test_ItemService(t *testing.T) {
 			ar.EXPECT().FindAll(gomock.Any()).Return(&mockEntities, nil)
 			mock.ExpectCommit()
 			Convey("正常に取得できる", func() {
-				result, err := as.FindAll()
+				result, err := as.FindAll(context.Background())
 				So(result, ShouldResemble, &mockEntities)
 				So(err, ShouldBeNil)
 			})
@@ -75,7 +78,7 @@ func Test_ItemService(t *testing.T) {
 			ar.EXPECT().Find(gomock.Any(), emailAddress, name).Return(&mockEntities, nil)
 			mock.ExpectCommit()
 			Convey("正常に取得できる", func() {
-				result, err := as.Find(&mockRequest)
+				result, err := as.Find(context.Background(), &mockRequest)
 				So(result, ShouldResemble, &mockEntities)
 				So(err, ShouldBeNil)
 			})
@@ -88,7 +91,7 @@ func Test_ItemService(t *testing.T) {
 			ar.EXPECT().FindByID(gomock.Any(), itemID).Return(mockEntity, nil)
 			mock.ExpectCommit()
 			Convey("正常に取得できる", func() {
-				result, err := as.FindByID(itemID)
+				result, err := as.FindByID(context.Background(), itemID)
 				So(result, ShouldResemble, mockEntity)
 				So(err, ShouldBeNil)
 			})
@@ -112,7 +115,7 @@ func Test_ItemService(t *testing.T) {
 			fbAuth.EXPECT().SetCustomClaims(externalUserID, gomock.Any()).Return(nil)
 			mock.ExpectCommit()
 			Convey("正常に登録できる", func() {
-				err := as.Create(mockEntity, password)
+				err := as.Create(context.Background(), mockEntity, password)
 				So(err, ShouldBeNil)
 			})
 		})
@@ -124,14 +127,14 @@ func Test_ItemService(t *testing.T) {
 			ar.EXPECT().FindByID(gomock.Any(), itemID).Return(mockEntity, nil)
 			mock.ExpectCommit()
 			Convey("更新対象の管理画面ユーザーが取得できること", func() {
-				result, err := as.FindByID(itemID)
+				result, err := as.FindByID(context.Background(), itemID)
 				So(result, ShouldResemble, mockEntity)
 				So(err, ShouldBeNil)
 				mock.ExpectBegin()
 				ar.EXPECT().Update(gomock.Any(), itemID, mockEntity).Return(nil)
 				mock.ExpectCommit()
 				Convey("正常に更新できる", func() {
-					err := as.Update(itemID, mockEntity)
+					err := as.Update(context.Background(), itemID, mockEntity)
 					So(err, ShouldBeEmpty)
 				})
 			})
@@ -147,7 +150,7 @@ func Test_ItemService(t *testing.T) {
 				ar.EXPECT().Delete(gomock.Any(), itemID).Return(nil)
 				mock.ExpectCommit()
 				Convey("正常に削除できる", func() {
-					err := as.Delete(itemID)
+					err := as.Delete(context.Background(), itemID)
 					So(err, ShouldBeEmpty)
 				})
 			})
@@ -157,7 +160,7 @@ func Test_ItemService(t *testing.T) {
 			ar.EXPECT().FindAll(gomock.Any()).Return(nil, appErr.DBClientError)
 			mock.ExpectCommit()
 
-			result, err := as.FindAll()
+			result, err := as.FindAll(context.Background())
 			So(result, ShouldBeNil)
 			So(err, ShouldEqual, appErr.ServiceClientError)
 		})
@@ -170,7 +173,7 @@ func Test_ItemService(t *testing.T) {
 			ar.EXPECT().Find(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, appErr.DBClientError)
 			mock.ExpectCommit()
 
-			result, err := as.Find(&mockRequest)
+			result, err := as.Find(context.Background(), &mockRequest)
 			So(result, ShouldBeNil)
 			So(err, ShouldEqual, appErr.ServiceClientError)
 		})
@@ -179,7 +182,7 @@ func Test_ItemService(t *testing.T) {
 			ar.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, appErr.DBClientError)
 			mock.ExpectCommit()
 
-			result, err := as.FindByID(itemID)
+			result, err := as.FindByID(context.Background(), itemID)
 			So(result, ShouldBeNil)
 			So(err, ShouldEqual, appErr.ServiceClientError)
 		})
@@ -201,7 +204,7 @@ func Test_ItemService(t *testing.T) {
 				fbAuth.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(nil, appErr.FirebaseCreateUserError)
 				mock.ExpectCommit()
 
-				err := as.Create(mockEntity, password)
+				err := as.Create(context.Background(), mockEntity, password)
 				So(err, ShouldEqual, appErr.ServiceClientError)
 			})
 			Convey("Createでエラーが発生", func() {
@@ -211,7 +214,7 @@ func Test_ItemService(t *testing.T) {
 				fbAuth.EXPECT().DeleteUser(gomock.Any()).Return(nil)
 				mock.ExpectCommit()
 
-				err := as.Create(mockEntity, password)
+				err := as.Create(context.Background(), mockEntity, password)
 				So(err, ShouldEqual, appErr.ServiceClientError)
 			})
 			Convey("SetCustomClaimsでエラーが発生", func() {
@@ -222,7 +225,7 @@ func Test_ItemService(t *testing.T) {
 				fbAuth.EXPECT().DeleteUser(gomock.Any()).Return(nil)
 				mock.ExpectCommit()
 
-				err := as.Create(mockEntity, password)
+				err := as.Create(context.Background(), mockEntity, password)
 				So(err, ShouldEqual, appErr.ServiceClientError)
 			})
 		})
@@ -235,7 +238,7 @@ func Test_ItemService(t *testing.T) {
 			ar.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(appErr.DBClientError)
 			mock.ExpectCommit()
 
-			err := as.Update(itemID, mockEntity)
+			err := as.Update(context.Background(), itemID, mockEntity)
 			So(err, ShouldEqual, appErr.ServiceClientError)
 		})
 		Convey("DeleteでFirebaseから削除出来なかった場合にエラーを返す", func() {
@@ -246,7 +249,7 @@ func Test_ItemService(t *testing.T) {
 			ar.EXPECT().FindByID(gomock.Any(), itemID).Return(mockEntity, nil)
 			fbAuth.EXPECT().DeleteUser(externalUserID).Return(appErr.FirebaseDeleteUserError)
 			mock.ExpectCommit()
-			err := as.Delete(itemID)
+			err := as.Delete(context.Background(), itemID)
 			So(err, ShouldEqual, appErr.ServiceStatusBadRequestError)
 		})
 		Convey("DeleteでDBから削除出来なかった場合にエラーを返す", func() {
@@ -258,7 +261,7 @@ func Test_ItemService(t *testing.T) {
 			fbAuth.EXPECT().DeleteUser(externalUserID).Return(nil)
 			ar.EXPECT().Delete(gomock.Any(), itemID).Return(appErr.DBClientError)
 			mock.ExpectCommit()
-			err := as.Delete(itemID)
+			err := as.Delete(context.Background(), itemID)
 			So(err, ShouldEqual, appErr.ServiceClientError)
 		})
 	})
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	repositories "github.com/genpsp/go-app/domain/repository"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type (
+	ItemVariantService interface {
+		FindByItemID(ctx context.Context, itemID int) (variants *[]entities.ItemVariant, err error)
+		FindByID(ctx context.Context, itemID int, variantID int) (variant *entities.ItemVariant, err error)
+		Create(ctx context.Context, itemID int, variantEntity *entities.ItemVariant) (err error)
+		Update(ctx context.Context, itemID int, variantID int, variantEntity *entities.ItemVariant) (err error)
+		Delete(ctx context.Context, itemID int, variantID int) (err error)
+		Expand(ctx context.Context, items []entities.Item) (err error)
+	}
+
+	itemVariantServiceImpl struct {
+		ivr    repositories.ItemVariantRepository
+		ir     repositories.ItemRepository
+		master *gorm.DB
+	}
+)
+
+func NewItemVariantService(
+	itemVariantRepo repositories.ItemVariantRepository,
+	itemRepo repositories.ItemRepository,
+	m *gorm.DB) ItemVariantService {
+
+	return &itemVariantServiceImpl{
+		ivr:    itemVariantRepo,
+		ir:     itemRepo,
+		master: m,
+	}
+}
+
+func (s *itemVariantServiceImpl) existsItem(tx *gorm.DB, itemID int) error {
+	item, err := s.ir.FindByID(tx, itemID)
+	if err != nil {
+		log.Error("occurred error when ItemVariant call ItemRepository", zap.Error(err))
+		return appErr.BindServiceErrorWithDBError(err)
+	}
+	if item == nil {
+		return appErr.ServiceStatusBadRequestError
+	}
+	return nil
+}
+
+// ensureUniqueSKU rejects a SKU already used by another variant than variantID.
+// A soft deleted variant gives its SKU up and is removed for good.
+func (s *itemVariantServiceImpl) ensureUniqueSKU(tx *gorm.DB, sku string, variantID uint) error {
+	variant, err := s.ivr.FindBySKU(tx, sku)
+	if err != nil {
+		log.Error("occurred error when ItemVariant call ItemVariantRepository", zap.Error(err))
+		return appErr.BindServiceErrorWithDBError(err)
+	}
+	if variant != nil && variant.DeletedAt.Valid {
+		if err := s.ivr.Delete(tx, int(variant.ID)); err != nil {
+			log.Error("occurred error when ItemVariant call ItemVariantRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	}
+	if variant != nil && variant.ID != variantID {
+		log.Info("ItemVariant duplicate sku", zap.String("sku", sku))
+		return appErr.ServiceStatusBadRequestError
+	}
+	return nil
+}
+
+func (s *itemVariantServiceImpl) FindByItemID(ctx context.Context, itemID int) (variants *[]entities.ItemVariant, err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.existsItem(tx, itemID); err != nil {
+			return err
+		}
+		variants, err = s.ivr.FindByItemIDs(tx, []uint{uint(itemID)})
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemVariant with FindByItemID call ItemVariantRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	return
+}
+
+func (s *itemVariantServiceImpl) FindByID(ctx context.Context, itemID int, variantID int) (variant *entities.ItemVariant, err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		variant, err = s.ivr.FindByID(tx, itemID, variantID)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemVariant with FindByID call ItemVariantRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	return
+}
+
+func (s *itemVariantServiceImpl) Create(ctx context.Context, itemID int, variantEntity *entities.ItemVariant) (err error) {
+	variantEntity.SKU = strings.TrimSpace(variantEntity.SKU)
+	if variantEntity.SKU == "" {
+		return appErr.ServiceStatusBadRequestError
+	}
+	variantEntity.ItemID = uint(itemID)
+
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.existsItem(tx, itemID); err != nil {
+			return err
+		}
+		if err := s.ensureUniqueSKU(tx, variantEntity.SKU, 0); err != nil {
+			return err
+		}
+		err := s.ivr.Create(tx, variantEntity)
+		if errors.Is(err, repositories.ErrDuplicateKey) {
+			// another request took the SKU after ensureUniqueSKU
+			return appErr.ServiceStatusBadRequestError
+		}
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemVariant with Create call ItemVariantRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	return
+}
+
+func (s *itemVariantServiceImpl) Update(ctx context.Context, itemID int, variantID int, variantEntity *entities.ItemVariant) (err error) {
+	variantEntity.SKU = strings.TrimSpace(variantEntity.SKU)
+	if variantEntity.SKU == "" {
+		return appErr.ServiceStatusBadRequestError
+	}
+
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		variant, err := s.ivr.FindByID(tx, itemID, variantID)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemVariant with Update call ItemVariantRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		if variant == nil {
+			return appErr.ServiceStatusBadRequestError
+		}
+		if err := s.ensureUniqueSKU(tx, variantEntity.SKU, variant.ID); err != nil {
+			return err
+		}
+		err = s.ivr.Update(tx, variantID, variantEntity)
+		if errors.Is(err, repositories.ErrDuplicateKey) {
+			return appErr.ServiceStatusBadRequestError
+		}
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemVariant with Update call ItemVariantRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	return
+}
+
+func (s *itemVariantServiceImpl) Delete(ctx context.Context, itemID int, variantID int) (err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		variant, err := s.ivr.FindByID(tx, itemID, variantID)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemVariant with Delete call ItemVariantRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		if variant == nil {
+			return appErr.ServiceStatusBadRequestError
+		}
+		err = s.ivr.Delete(tx, variantID)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemVariant with Delete call ItemVariantRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	return
+}
+
+// Expand loads the variants of items in a single query and sets them on each item.
+func (s *itemVariantServiceImpl) Expand(ctx context.Context, items []entities.Item) (err error) {
+	if len(items) == 0 {
+		return nil
+	}
+	itemIDs := make([]uint, len(items))
+	for i, item := range items {
+		itemIDs[i] = item.ID
+	}
+
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		variants, err := s.ivr.FindByItemIDs(tx, itemIDs)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when ItemVariant with Expand call ItemVariantRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		byItem := make(map[uint][]entities.ItemVariant, len(items))
+		for _, variant := range *variants {
+			byItem[variant.ItemID] = append(byItem[variant.ItemID], variant)
+		}
+		for i := range items {
+			items[i].Variants = byItem[items[i].ID]
+			if items[i].Variants == nil {
+				items[i].Variants = []entities.ItemVariant{}
+			}
+		}
+		return nil
+	})
+	return
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	repositories "github.com/genpsp/go-app/domain/repository"
+	"github.com/genpsp/go-app/domain/repository/mock_repositories"
+	"github.com/genpsp/go-app/pkg/configs"
+	"github.com/genpsp/go-app/pkg/logger"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+	"gorm.io/gorm"
+)
+
+func Test_ItemVariantService(t *testing.T) {
+	Convey("ItemVariantServiceを初期化", t, func() {
+		configs.TestLoadConfig()
+		cfg := configs.GetConfig()
+		logger.LoadLogger(cfg.System.Env, cfg.Logger.LogLevel, cfg.Logger.LogEncoding)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		db, mock, _ := mock_repositories.GetDBMock()
+		ivr := mock_repositories.NewMockItemVariantRepository(ctrl)
+		ir := mock_repositories.NewMockItemRepository(ctrl)
+		vs := NewItemVariantService(ivr, ir, db)
+
+		const itemID = 1
+
+		Convey("SKUが重複する場合は登録できない", func() {
+			mock.ExpectBegin()
+			mock.ExpectRollback()
+			ir.EXPECT().FindByID(gomock.Any(), itemID).Return(&entities.Item{Model: gorm.Model{ID: itemID}}, nil)
+			ivr.EXPECT().FindBySKU(gomock.Any(), "TS-RED-M").Return(&entities.ItemVariant{Model: gorm.Model{ID: 5}, SKU: "TS-RED-M"}, nil)
+
+			err := vs.Create(context.Background(), itemID, &entities.ItemVariant{SKU: " TS-RED-M "})
+			So(err, ShouldNotBeNil)
+		})
+		Convey("同時に登録されたSKUの重複は400として扱う", func() {
+			mock.ExpectBegin()
+			mock.ExpectRollback()
+			ir.EXPECT().FindByID(gomock.Any(), itemID).Return(&entities.Item{Model: gorm.Model{ID: itemID}}, nil)
+			ivr.EXPECT().FindBySKU(gomock.Any(), "TS-RED-L").Return(nil, nil)
+			ivr.EXPECT().Create(gomock.Any(), gomock.Any()).Return(repositories.ErrDuplicateKey)
+
+			err := vs.Create(context.Background(), itemID, &entities.ItemVariant{SKU: "TS-RED-L"})
+			So(err, ShouldEqual, appErr.ServiceStatusBadRequestError)
+		})
+		Convey("削除済みのバリエーションのSKUは再利用できる", func() {
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			deleted := &entities.ItemVariant{Model: gorm.Model{ID: 6, DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}}, SKU: "TS-RED-S"}
+			ir.EXPECT().FindByID(gomock.Any(), itemID).Return(&entities.Item{Model: gorm.Model{ID: itemID}}, nil)
+			ivr.EXPECT().FindBySKU(gomock.Any(), "TS-RED-S").Return(deleted, nil)
+			ivr.EXPECT().Delete(gomock.Any(), 6).Return(nil)
+			ivr.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+			err := vs.Create(context.Background(), itemID, &entities.ItemVariant{SKU: "TS-RED-S"})
+			So(err, ShouldBeNil)
+		})
+		Convey("自身のSKUのままなら更新できる", func() {
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			variant := &entities.ItemVariant{Model: gorm.Model{ID: 5}, ItemID: itemID, SKU: "TS-RED-M"}
+			ivr.EXPECT().FindByID(gomock.Any(), itemID, 5).Return(variant, nil)
+			ivr.EXPECT().FindBySKU(gomock.Any(), "TS-RED-M").Return(variant, nil)
+			ivr.EXPECT().Update(gomock.Any(), 5, gomock.Any()).Return(nil)
+
+			err := vs.Update(context.Background(), itemID, 5, &entities.ItemVariant{SKU: "TS-RED-M", Barcode: "4900000000000"})
+			So(err, ShouldBeNil)
+		})
+		Convey("複数商品のバリエーションをまとめて展開できる", func() {
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			ivr.EXPECT().FindByItemIDs(gomock.Any(), []uint{1, 2}).Return(&[]entities.ItemVariant{
+				{ItemID: 1, SKU: "A-S"},
+				{ItemID: 1, SKU: "A-M"},
+			}, nil)
+
+			items := []entities.Item{{Model: gorm.Model{ID: 1}}, {Model: gorm.Model{ID: 2}}}
+			err := vs.Expand(context.Background(), items)
+			So(err, ShouldBeNil)
+			So(len(items[0].Variants), ShouldEqual, 2)
+			So(items[1].Variants, ShouldNotBeNil)
+			So(len(items[1].Variants), ShouldEqual, 0)
+		})
+	})
+}
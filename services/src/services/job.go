@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/domain/enum"
+	repositories "github.com/genpsp/go-app/domain/repository"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type (
+	JobService interface {
+		Enqueue(ctx context.Context, createdBy string, jobType string, payload interface{}, runAt time.Time) (job *entities.Job, err error)
+		FindByID(ctx context.Context, createdBy string, jobID int) (job *entities.Job, err error)
+	}
+
+	jobServiceImpl struct {
+		jr          repositories.JobRepository
+		master      *gorm.DB
+		maxAttempts int
+	}
+)
+
+func NewJobService(jobRepo repositories.JobRepository, m *gorm.DB, maxAttempts int) JobService {
+	return &jobServiceImpl{
+		jr:          jobRepo,
+		master:      m,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// Enqueue stores a job to be run at runAt. A zero runAt runs it as soon as a worker is free.
+// createdBy is the UID of the user the job belongs to; only they can look it up.
+func (s *jobServiceImpl) Enqueue(ctx context.Context, createdBy string, jobType string, payload interface{}, runAt time.Time) (job *entities.Job, err error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		log.Ctx(ctx).Error("occurred error when Job with Enqueue marshal payload", zap.Error(err))
+		return nil, appErr.ServiceStatusBadRequestError
+	}
+	if runAt.IsZero() {
+		runAt = time.Now()
+	}
+	job = &entities.Job{
+		Type:        jobType,
+		Payload:     string(b),
+		CreatedBy:   createdBy,
+		Status:      enum.JobStatusQueued,
+		MaxAttempts: s.maxAttempts,
+		RunAt:       runAt,
+	}
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.jr.Create(tx, job); err != nil {
+			log.Ctx(ctx).Error("occurred error when Job with Enqueue call JobRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (s *jobServiceImpl) FindByID(ctx context.Context, createdBy string, jobID int) (job *entities.Job, err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		job, err = s.jr.FindByID(tx, createdBy, jobID)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Job with FindByID call JobRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	return
+}
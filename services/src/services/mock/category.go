@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/src/services/category.go
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gormmodel "github.com/genpsp/go-app/domain/entities"
+	services "github.com/genpsp/go-app/services/src/services"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCategoryService is a mock of CategoryService interface.
+type MockCategoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryServiceMockRecorder
+}
+
+// MockCategoryServiceMockRecorder is the mock recorder for MockCategoryService.
+type MockCategoryServiceMockRecorder struct {
+	mock *MockCategoryService
+}
+
+// NewMockCategoryService creates a new mock instance.
+func NewMockCategoryService(ctrl *gomock.Controller) *MockCategoryService {
+	mock := &MockCategoryService{ctrl: ctrl}
+	mock.recorder = &MockCategoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryService) EXPECT() *MockCategoryServiceMockRecorder {
+	return m.recorder
+}
+
+// AssignItem mocks base method.
+func (m *MockCategoryService) AssignItem(ctx context.Context, itemID int, categoryIDs []uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignItem", ctx, itemID, categoryIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignItem indicates an expected call of AssignItem.
+func (mr *MockCategoryServiceMockRecorder) AssignItem(ctx, itemID, categoryIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignItem", reflect.TypeOf((*MockCategoryService)(nil).AssignItem), ctx, itemID, categoryIDs)
+}
+
+// Create mocks base method.
+func (m *MockCategoryService) Create(ctx context.Context, categoryEntity *gormmodel.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, categoryEntity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCategoryServiceMockRecorder) Create(ctx, categoryEntity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCategoryService)(nil).Create), ctx, categoryEntity)
+}
+
+// Delete mocks base method.
+func (m *MockCategoryService) Delete(ctx context.Context, categoryID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, categoryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCategoryServiceMockRecorder) Delete(ctx, categoryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategoryService)(nil).Delete), ctx, categoryID)
+}
+
+// FindTree mocks base method.
+func (m *MockCategoryService) FindTree(ctx context.Context) ([]*services.CategoryNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTree", ctx)
+	ret0, _ := ret[0].([]*services.CategoryNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTree indicates an expected call of FindTree.
+func (mr *MockCategoryServiceMockRecorder) FindTree(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTree", reflect.TypeOf((*MockCategoryService)(nil).FindTree), ctx)
+}
+
+// Update mocks base method.
+func (m *MockCategoryService) Update(ctx context.Context, categoryID int, categoryEntity *gormmodel.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, categoryID, categoryEntity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCategoryServiceMockRecorder) Update(ctx, categoryID, categoryEntity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCategoryService)(nil).Update), ctx, categoryID, categoryEntity)
+}
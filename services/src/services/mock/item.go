@@ -5,9 +5,12 @@
 package mock_services
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	gormmodel "github.com/genpsp/go-app/domain/entities"
+	repositories "github.com/genpsp/go-app/domain/repository"
 	request "github.com/genpsp/go-app/services/src/handler/request"
 	gomock "github.com/golang/mock/gomock"
 )
@@ -36,88 +39,147 @@ func (m *MockItemService) EXPECT() *MockItemServiceMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockItemService) Create(itemEntity *gormmodel.Item, password string) error {
+func (m *MockItemService) Create(ctx context.Context, itemEntity *gormmodel.Item, password string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", itemEntity, password)
+	ret := m.ctrl.Call(m, "Create", ctx, itemEntity, password)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockItemServiceMockRecorder) Create(itemEntity, password interface{}) *gomock.Call {
+func (mr *MockItemServiceMockRecorder) Create(ctx, itemEntity, password interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockItemService)(nil).Create), itemEntity, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockItemService)(nil).Create), ctx, itemEntity, password)
 }
 
 // Delete mocks base method.
-func (m *MockItemService) Delete(itemID int) error {
+func (m *MockItemService) Delete(ctx context.Context, itemID int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", itemID)
+	ret := m.ctrl.Call(m, "Delete", ctx, itemID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockItemServiceMockRecorder) Delete(itemID interface{}) *gomock.Call {
+func (mr *MockItemServiceMockRecorder) Delete(ctx, itemID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockItemService)(nil).Delete), itemID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockItemService)(nil).Delete), ctx, itemID)
 }
 
 // Find mocks base method.
-func (m *MockItemService) Find(gar *request.GetItemRequest) (*[]gormmodel.Item, error) {
+func (m *MockItemService) Find(ctx context.Context, gar *request.GetItemRequest) (*[]gormmodel.Item, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Find", gar)
+	ret := m.ctrl.Call(m, "Find", ctx, gar)
 	ret0, _ := ret[0].(*[]gormmodel.Item)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Find indicates an expected call of Find.
-func (mr *MockItemServiceMockRecorder) Find(gar interface{}) *gomock.Call {
+func (mr *MockItemServiceMockRecorder) Find(ctx, gar interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockItemService)(nil).Find), gar)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockItemService)(nil).Find), ctx, gar)
 }
 
 // FindAll mocks base method.
-func (m *MockItemService) FindAll() (*[]gormmodel.Item, error) {
+func (m *MockItemService) FindAll(ctx context.Context) (*[]gormmodel.Item, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll")
+	ret := m.ctrl.Call(m, "FindAll", ctx)
 	ret0, _ := ret[0].(*[]gormmodel.Item)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockItemServiceMockRecorder) FindAll() *gomock.Call {
+func (mr *MockItemServiceMockRecorder) FindAll(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockItemService)(nil).FindAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockItemService)(nil).FindAll), ctx)
+}
+
+// FindAsOf mocks base method.
+func (m *MockItemService) FindAsOf(ctx context.Context, itemID int, at time.Time) (*gormmodel.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAsOf", ctx, itemID, at)
+	ret0, _ := ret[0].(*gormmodel.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAsOf indicates an expected call of FindAsOf.
+func (mr *MockItemServiceMockRecorder) FindAsOf(ctx, itemID, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAsOf", reflect.TypeOf((*MockItemService)(nil).FindAsOf), ctx, itemID, at)
+}
+
+// FindByFilter mocks base method.
+func (m *MockItemService) FindByFilter(ctx context.Context, filter repositories.ItemFilter) (*[]gormmodel.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByFilter", ctx, filter)
+	ret0, _ := ret[0].(*[]gormmodel.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByFilter indicates an expected call of FindByFilter.
+func (mr *MockItemServiceMockRecorder) FindByFilter(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByFilter", reflect.TypeOf((*MockItemService)(nil).FindByFilter), ctx, filter)
 }
 
 // FindByID mocks base method.
-func (m *MockItemService) FindByID(itemID int) (*gormmodel.Item, error) {
+func (m *MockItemService) FindByID(ctx context.Context, itemID int) (*gormmodel.Item, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByID", itemID)
+	ret := m.ctrl.Call(m, "FindByID", ctx, itemID)
 	ret0, _ := ret[0].(*gormmodel.Item)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByID indicates an expected call of FindByID.
-func (mr *MockItemServiceMockRecorder) FindByID(itemID interface{}) *gomock.Call {
+func (mr *MockItemServiceMockRecorder) FindByID(ctx, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockItemService)(nil).FindByID), ctx, itemID)
+}
+
+// Revert mocks base method.
+func (m *MockItemService) Revert(ctx context.Context, itemID, revision int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revert", ctx, itemID, revision)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revert indicates an expected call of Revert.
+func (mr *MockItemServiceMockRecorder) Revert(ctx, itemID, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revert", reflect.TypeOf((*MockItemService)(nil).Revert), ctx, itemID, revision)
+}
+
+// Revisions mocks base method.
+func (m *MockItemService) Revisions(ctx context.Context, itemID int) (*[]gormmodel.ItemRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revisions", ctx, itemID)
+	ret0, _ := ret[0].(*[]gormmodel.ItemRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revisions indicates an expected call of Revisions.
+func (mr *MockItemServiceMockRecorder) Revisions(ctx, itemID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockItemService)(nil).FindByID), itemID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revisions", reflect.TypeOf((*MockItemService)(nil).Revisions), ctx, itemID)
 }
 
 // Update mocks base method.
-func (m *MockItemService) Update(itemID int, itemEntity *gormmodel.Item) error {
+func (m *MockItemService) Update(ctx context.Context, itemID int, itemEntity *gormmodel.Item) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", itemID, itemEntity)
+	ret := m.ctrl.Call(m, "Update", ctx, itemID, itemEntity)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockItemServiceMockRecorder) Update(itemID, itemEntity interface{}) *gomock.Call {
+func (mr *MockItemServiceMockRecorder) Update(ctx, itemID, itemEntity interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockItemService)(nil).Update), itemID, itemEntity)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockItemService)(nil).Update), ctx, itemID, itemEntity)
 }
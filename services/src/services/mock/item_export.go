@@ -0,0 +1,149 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/src/services/item_export.go
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gormmodel "github.com/genpsp/go-app/domain/entities"
+	services "github.com/genpsp/go-app/services/src/services"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockItemExportService is a mock of ItemExportService interface.
+type MockItemExportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemExportServiceMockRecorder
+}
+
+// MockItemExportServiceMockRecorder is the mock recorder for MockItemExportService.
+type MockItemExportServiceMockRecorder struct {
+	mock *MockItemExportService
+}
+
+// NewMockItemExportService creates a new mock instance.
+func NewMockItemExportService(ctrl *gomock.Controller) *MockItemExportService {
+	mock := &MockItemExportService{ctrl: ctrl}
+	mock.recorder = &MockItemExportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemExportService) EXPECT() *MockItemExportServiceMockRecorder {
+	return m.recorder
+}
+
+// DownloadURL mocks base method.
+func (m *MockItemExportService) DownloadURL(ctx context.Context, createdBy string, jobID int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadURL", ctx, createdBy, jobID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadURL indicates an expected call of DownloadURL.
+func (mr *MockItemExportServiceMockRecorder) DownloadURL(ctx, createdBy, jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadURL", reflect.TypeOf((*MockItemExportService)(nil).DownloadURL), ctx, createdBy, jobID)
+}
+
+// Enqueue mocks base method.
+func (m *MockItemExportService) Enqueue(ctx context.Context, createdBy string, opts services.ItemExportOptions) (*gormmodel.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", ctx, createdBy, opts)
+	ret0, _ := ret[0].(*gormmodel.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockItemExportServiceMockRecorder) Enqueue(ctx, createdBy, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockItemExportService)(nil).Enqueue), ctx, createdBy, opts)
+}
+
+// Export mocks base method.
+func (m *MockItemExportService) Export(ctx context.Context, w io.Writer, opts services.ItemExportOptions) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, w, opts)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockItemExportServiceMockRecorder) Export(ctx, w, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockItemExportService)(nil).Export), ctx, w, opts)
+}
+
+// ExportToStorage mocks base method.
+func (m *MockItemExportService) ExportToStorage(ctx context.Context, jobID uint, opts services.ItemExportOptions) (*services.ItemExportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportToStorage", ctx, jobID, opts)
+	ret0, _ := ret[0].(*services.ItemExportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportToStorage indicates an expected call of ExportToStorage.
+func (mr *MockItemExportServiceMockRecorder) ExportToStorage(ctx, jobID, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportToStorage", reflect.TypeOf((*MockItemExportService)(nil).ExportToStorage), ctx, jobID, opts)
+}
+
+// MockitemRowWriter is a mock of itemRowWriter interface.
+type MockitemRowWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockitemRowWriterMockRecorder
+}
+
+// MockitemRowWriterMockRecorder is the mock recorder for MockitemRowWriter.
+type MockitemRowWriterMockRecorder struct {
+	mock *MockitemRowWriter
+}
+
+// NewMockitemRowWriter creates a new mock instance.
+func NewMockitemRowWriter(ctrl *gomock.Controller) *MockitemRowWriter {
+	mock := &MockitemRowWriter{ctrl: ctrl}
+	mock.recorder = &MockitemRowWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockitemRowWriter) EXPECT() *MockitemRowWriterMockRecorder {
+	return m.recorder
+}
+
+// Flush mocks base method.
+func (m *MockitemRowWriter) Flush() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flush")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockitemRowWriterMockRecorder) Flush() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockitemRowWriter)(nil).Flush))
+}
+
+// Write mocks base method.
+func (m *MockitemRowWriter) Write(item gormmodel.Item) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Write", item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Write indicates an expected call of Write.
+func (mr *MockitemRowWriterMockRecorder) Write(item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockitemRowWriter)(nil).Write), item)
+}
@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/src/services/item_image.go
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	context "context"
+	multipart "mime/multipart"
+	reflect "reflect"
+
+	gormmodel "github.com/genpsp/go-app/domain/entities"
+	services "github.com/genpsp/go-app/services/src/services"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockItemImageService is a mock of ItemImageService interface.
+type MockItemImageService struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemImageServiceMockRecorder
+}
+
+// MockItemImageServiceMockRecorder is the mock recorder for MockItemImageService.
+type MockItemImageServiceMockRecorder struct {
+	mock *MockItemImageService
+}
+
+// NewMockItemImageService creates a new mock instance.
+func NewMockItemImageService(ctrl *gomock.Controller) *MockItemImageService {
+	mock := &MockItemImageService{ctrl: ctrl}
+	mock.recorder = &MockItemImageServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemImageService) EXPECT() *MockItemImageServiceMockRecorder {
+	return m.recorder
+}
+
+// Confirm mocks base method.
+func (m *MockItemImageService) Confirm(ctx context.Context, itemID, imageID int) (*gormmodel.ItemImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", ctx, itemID, imageID)
+	ret0, _ := ret[0].(*gormmodel.ItemImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockItemImageServiceMockRecorder) Confirm(ctx, itemID, imageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockItemImageService)(nil).Confirm), ctx, itemID, imageID)
+}
+
+// IssueUploadURL mocks base method.
+func (m *MockItemImageService) IssueUploadURL(ctx context.Context, itemID int, contentType string, size int64) (*gormmodel.ItemImage, *services.ItemImageUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueUploadURL", ctx, itemID, contentType, size)
+	ret0, _ := ret[0].(*gormmodel.ItemImage)
+	ret1, _ := ret[1].(*services.ItemImageUpload)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// IssueUploadURL indicates an expected call of IssueUploadURL.
+func (mr *MockItemImageServiceMockRecorder) IssueUploadURL(ctx, itemID, contentType, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueUploadURL", reflect.TypeOf((*MockItemImageService)(nil).IssueUploadURL), ctx, itemID, contentType, size)
+}
+
+// SignURLs mocks base method.
+func (m *MockItemImageService) SignURLs(ctx context.Context, images []gormmodel.ItemImage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignURLs", ctx, images)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SignURLs indicates an expected call of SignURLs.
+func (mr *MockItemImageServiceMockRecorder) SignURLs(ctx, images interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignURLs", reflect.TypeOf((*MockItemImageService)(nil).SignURLs), ctx, images)
+}
+
+// Upload mocks base method.
+func (m *MockItemImageService) Upload(ctx context.Context, itemID int, file *multipart.FileHeader) (*gormmodel.ItemImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, itemID, file)
+	ret0, _ := ret[0].(*gormmodel.ItemImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockItemImageServiceMockRecorder) Upload(ctx, itemID, file interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockItemImageService)(nil).Upload), ctx, itemID, file)
+}
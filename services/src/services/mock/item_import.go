@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/src/services/item_import.go
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	services "github.com/genpsp/go-app/services/src/services"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockItemImportService is a mock of ItemImportService interface.
+type MockItemImportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemImportServiceMockRecorder
+}
+
+// MockItemImportServiceMockRecorder is the mock recorder for MockItemImportService.
+type MockItemImportServiceMockRecorder struct {
+	mock *MockItemImportService
+}
+
+// NewMockItemImportService creates a new mock instance.
+func NewMockItemImportService(ctrl *gomock.Controller) *MockItemImportService {
+	mock := &MockItemImportService{ctrl: ctrl}
+	mock.recorder = &MockItemImportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemImportService) EXPECT() *MockItemImportServiceMockRecorder {
+	return m.recorder
+}
+
+// Import mocks base method.
+func (m *MockItemImportService) Import(ctx context.Context, r io.Reader, dryRun bool, v services.Validator) (*services.ItemImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, r, dryRun, v)
+	ret0, _ := ret[0].(*services.ItemImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockItemImportServiceMockRecorder) Import(ctx, r, dryRun, v interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockItemImportService)(nil).Import), ctx, r, dryRun, v)
+}
+
+// MockValidator is a mock of Validator interface.
+type MockValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockValidatorMockRecorder
+}
+
+// MockValidatorMockRecorder is the mock recorder for MockValidator.
+type MockValidatorMockRecorder struct {
+	mock *MockValidator
+}
+
+// NewMockValidator creates a new mock instance.
+func NewMockValidator(ctrl *gomock.Controller) *MockValidator {
+	mock := &MockValidator{ctrl: ctrl}
+	mock.recorder = &MockValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockValidator) EXPECT() *MockValidatorMockRecorder {
+	return m.recorder
+}
+
+// Validate mocks base method.
+func (m *MockValidator) Validate(i interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", i)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockValidatorMockRecorder) Validate(i interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockValidator)(nil).Validate), i)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/src/services/item_price.go
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gormmodel "github.com/genpsp/go-app/domain/entities"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockItemPriceService is a mock of ItemPriceService interface.
+type MockItemPriceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemPriceServiceMockRecorder
+}
+
+// MockItemPriceServiceMockRecorder is the mock recorder for MockItemPriceService.
+type MockItemPriceServiceMockRecorder struct {
+	mock *MockItemPriceService
+}
+
+// NewMockItemPriceService creates a new mock instance.
+func NewMockItemPriceService(ctrl *gomock.Controller) *MockItemPriceService {
+	mock := &MockItemPriceService{ctrl: ctrl}
+	mock.recorder = &MockItemPriceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemPriceService) EXPECT() *MockItemPriceServiceMockRecorder {
+	return m.recorder
+}
+
+// ApplyEffective mocks base method.
+func (m *MockItemPriceService) ApplyEffective(ctx context.Context, items []gormmodel.Item, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyEffective", ctx, items, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyEffective indicates an expected call of ApplyEffective.
+func (mr *MockItemPriceServiceMockRecorder) ApplyEffective(ctx, items, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyEffective", reflect.TypeOf((*MockItemPriceService)(nil).ApplyEffective), ctx, items, at)
+}
+
+// History mocks base method.
+func (m *MockItemPriceService) History(ctx context.Context, itemID int) (*[]gormmodel.ItemPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", ctx, itemID)
+	ret0, _ := ret[0].(*[]gormmodel.ItemPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History.
+func (mr *MockItemPriceServiceMockRecorder) History(ctx, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockItemPriceService)(nil).History), ctx, itemID)
+}
+
+// Schedule mocks base method.
+func (m *MockItemPriceService) Schedule(ctx context.Context, itemID, price int, effectiveFrom time.Time, effectiveTo *time.Time) (*gormmodel.ItemPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Schedule", ctx, itemID, price, effectiveFrom, effectiveTo)
+	ret0, _ := ret[0].(*gormmodel.ItemPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Schedule indicates an expected call of Schedule.
+func (mr *MockItemPriceServiceMockRecorder) Schedule(ctx, itemID, price, effectiveFrom, effectiveTo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schedule", reflect.TypeOf((*MockItemPriceService)(nil).Schedule), ctx, itemID, price, effectiveFrom, effectiveTo)
+}
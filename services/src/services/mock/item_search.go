@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/src/services/item_search.go
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	services "github.com/genpsp/go-app/services/src/services"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockItemSearchService is a mock of ItemSearchService interface.
+type MockItemSearchService struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemSearchServiceMockRecorder
+}
+
+// MockItemSearchServiceMockRecorder is the mock recorder for MockItemSearchService.
+type MockItemSearchServiceMockRecorder struct {
+	mock *MockItemSearchService
+}
+
+// NewMockItemSearchService creates a new mock instance.
+func NewMockItemSearchService(ctrl *gomock.Controller) *MockItemSearchService {
+	mock := &MockItemSearchService{ctrl: ctrl}
+	mock.recorder = &MockItemSearchServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemSearchService) EXPECT() *MockItemSearchServiceMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockItemSearchService) Search(ctx context.Context, query string, limit, offset int) (*services.ItemSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, limit, offset)
+	ret0, _ := ret[0].(*services.ItemSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockItemSearchServiceMockRecorder) Search(ctx, query, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockItemSearchService)(nil).Search), ctx, query, limit, offset)
+}
@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/src/services/item_variant.go
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gormmodel "github.com/genpsp/go-app/domain/entities"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockItemVariantService is a mock of ItemVariantService interface.
+type MockItemVariantService struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemVariantServiceMockRecorder
+}
+
+// MockItemVariantServiceMockRecorder is the mock recorder for MockItemVariantService.
+type MockItemVariantServiceMockRecorder struct {
+	mock *MockItemVariantService
+}
+
+// NewMockItemVariantService creates a new mock instance.
+func NewMockItemVariantService(ctrl *gomock.Controller) *MockItemVariantService {
+	mock := &MockItemVariantService{ctrl: ctrl}
+	mock.recorder = &MockItemVariantServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemVariantService) EXPECT() *MockItemVariantServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockItemVariantService) Create(ctx context.Context, itemID int, variantEntity *gormmodel.ItemVariant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, itemID, variantEntity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockItemVariantServiceMockRecorder) Create(ctx, itemID, variantEntity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockItemVariantService)(nil).Create), ctx, itemID, variantEntity)
+}
+
+// Delete mocks base method.
+func (m *MockItemVariantService) Delete(ctx context.Context, itemID, variantID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, itemID, variantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockItemVariantServiceMockRecorder) Delete(ctx, itemID, variantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockItemVariantService)(nil).Delete), ctx, itemID, variantID)
+}
+
+// Expand mocks base method.
+func (m *MockItemVariantService) Expand(ctx context.Context, items []gormmodel.Item) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Expand", ctx, items)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Expand indicates an expected call of Expand.
+func (mr *MockItemVariantServiceMockRecorder) Expand(ctx, items interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expand", reflect.TypeOf((*MockItemVariantService)(nil).Expand), ctx, items)
+}
+
+// FindByID mocks base method.
+func (m *MockItemVariantService) FindByID(ctx context.Context, itemID, variantID int) (*gormmodel.ItemVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, itemID, variantID)
+	ret0, _ := ret[0].(*gormmodel.ItemVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockItemVariantServiceMockRecorder) FindByID(ctx, itemID, variantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockItemVariantService)(nil).FindByID), ctx, itemID, variantID)
+}
+
+// FindByItemID mocks base method.
+func (m *MockItemVariantService) FindByItemID(ctx context.Context, itemID int) (*[]gormmodel.ItemVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByItemID", ctx, itemID)
+	ret0, _ := ret[0].(*[]gormmodel.ItemVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByItemID indicates an expected call of FindByItemID.
+func (mr *MockItemVariantServiceMockRecorder) FindByItemID(ctx, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByItemID", reflect.TypeOf((*MockItemVariantService)(nil).FindByItemID), ctx, itemID)
+}
+
+// Update mocks base method.
+func (m *MockItemVariantService) Update(ctx context.Context, itemID, variantID int, variantEntity *gormmodel.ItemVariant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, itemID, variantID, variantEntity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockItemVariantServiceMockRecorder) Update(ctx, itemID, variantID, variantEntity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockItemVariantService)(nil).Update), ctx, itemID, variantID, variantEntity)
+}
@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/src/services/stock.go
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gormmodel "github.com/genpsp/go-app/domain/entities"
+	repositories "github.com/genpsp/go-app/domain/repository"
+	services "github.com/genpsp/go-app/services/src/services"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStockService is a mock of StockService interface.
+type MockStockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockServiceMockRecorder
+}
+
+// MockStockServiceMockRecorder is the mock recorder for MockStockService.
+type MockStockServiceMockRecorder struct {
+	mock *MockStockService
+}
+
+// NewMockStockService creates a new mock instance.
+func NewMockStockService(ctrl *gomock.Controller) *MockStockService {
+	mock := &MockStockService{ctrl: ctrl}
+	mock.recorder = &MockStockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockService) EXPECT() *MockStockServiceMockRecorder {
+	return m.recorder
+}
+
+// Commit mocks base method.
+func (m *MockStockService) Commit(ctx context.Context, reservationID int) (*gormmodel.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Commit", ctx, reservationID)
+	ret0, _ := ret[0].(*gormmodel.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Commit indicates an expected call of Commit.
+func (mr *MockStockServiceMockRecorder) Commit(ctx, reservationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockStockService)(nil).Commit), ctx, reservationID)
+}
+
+// Level mocks base method.
+func (m *MockStockService) Level(ctx context.Context, key repositories.StockKey) (*services.StockLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Level", ctx, key)
+	ret0, _ := ret[0].(*services.StockLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Level indicates an expected call of Level.
+func (mr *MockStockServiceMockRecorder) Level(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Level", reflect.TypeOf((*MockStockService)(nil).Level), ctx, key)
+}
+
+// Movements mocks base method.
+func (m *MockStockService) Movements(ctx context.Context, key repositories.StockKey, limit, offset int) (*[]gormmodel.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Movements", ctx, key, limit, offset)
+	ret0, _ := ret[0].(*[]gormmodel.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Movements indicates an expected call of Movements.
+func (mr *MockStockServiceMockRecorder) Movements(ctx, key, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Movements", reflect.TypeOf((*MockStockService)(nil).Movements), ctx, key, limit, offset)
+}
+
+// Record mocks base method.
+func (m *MockStockService) Record(ctx context.Context, movementEntity *gormmodel.StockMovement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, movementEntity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockStockServiceMockRecorder) Record(ctx, movementEntity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockStockService)(nil).Record), ctx, movementEntity)
+}
+
+// Release mocks base method.
+func (m *MockStockService) Release(ctx context.Context, reservationID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, reservationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockStockServiceMockRecorder) Release(ctx, reservationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockStockService)(nil).Release), ctx, reservationID)
+}
+
+// Reserve mocks base method.
+func (m *MockStockService) Reserve(ctx context.Context, key repositories.StockKey, quantity int, ttl time.Duration) (*gormmodel.StockReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, key, quantity, ttl)
+	ret0, _ := ret[0].(*gormmodel.StockReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockStockServiceMockRecorder) Reserve(ctx, key, quantity, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockStockService)(nil).Reserve), ctx, key, quantity, ttl)
+}
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/domain/enum"
+	repositories "github.com/genpsp/go-app/domain/repository"
+	"github.com/genpsp/go-app/pkg/logger"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	StockReservationDefaultTTL = 15 * time.Minute
+	StockReservationMaxTTL     = 24 * time.Hour
+	StockMovementsDefaultLimit = 50
+)
+
+type (
+	StockService interface {
+		Level(ctx context.Context, key repositories.StockKey) (level *StockLevel, err error)
+		Movements(ctx context.Context, key repositories.StockKey, limit int, offset int) (movements *[]entities.StockMovement, err error)
+		Record(ctx context.Context, movementEntity *entities.StockMovement) (err error)
+		Reserve(ctx context.Context, key repositories.StockKey, quantity int, ttl time.Duration) (reservation *entities.StockReservation, err error)
+		Commit(ctx context.Context, reservationID int) (movement *entities.StockMovement, err error)
+		Release(ctx context.Context, reservationID int) (err error)
+	}
+
+	StockLevel struct {
+		OnHand    int64
+		Reserved  int64
+		Available int64
+	}
+
+	stockServiceImpl struct {
+		sr     repositories.StockRepository
+		ivr    repositories.ItemVariantRepository
+		master *gorm.DB
+	}
+)
+
+func NewStockService(
+	stockRepo repositories.StockRepository,
+	itemVariantRepo repositories.ItemVariantRepository,
+	m *gorm.DB) StockService {
+
+	return &stockServiceImpl{
+		sr:     stockRepo,
+		ivr:    itemVariantRepo,
+		master: m,
+	}
+}
+
+// lock takes the row lock of the item and checks that the variant of key belongs to it.
+func (s *stockServiceImpl) lock(tx *gorm.DB, key repositories.StockKey) error {
+	ok, err := s.sr.LockItem(tx, key.ItemID)
+	if err != nil {
+		log.Error("occurred error when Stock call StockRepository", zap.Error(err))
+		return appErr.BindServiceErrorWithDBError(err)
+	}
+	if !ok {
+		return appErr.ServiceStatusBadRequestError
+	}
+	if key.ItemVariantID == nil {
+		return nil
+	}
+	variant, err := s.ivr.FindByID(tx, int(key.ItemID), int(*key.ItemVariantID))
+	if err != nil {
+		log.Error("occurred error when Stock call ItemVariantRepository", zap.Error(err))
+		return appErr.BindServiceErrorWithDBError(err)
+	}
+	if variant == nil {
+		return appErr.ServiceStatusBadRequestError
+	}
+	return nil
+}
+
+func (s *stockServiceImpl) level(tx *gorm.DB, key repositories.StockKey, now time.Time) (*StockLevel, error) {
+	onHand, err := s.sr.OnHand(tx, key)
+	if err != nil {
+		log.Error("occurred error when Stock call StockRepository", zap.Error(err))
+		return nil, appErr.BindServiceErrorWithDBError(err)
+	}
+	reserved, err := s.sr.Reserved(tx, key, now)
+	if err != nil {
+		log.Error("occurred error when Stock call StockRepository", zap.Error(err))
+		return nil, appErr.BindServiceErrorWithDBError(err)
+	}
+	return &StockLevel{
+		OnHand:    onHand,
+		Reserved:  reserved,
+		Available: onHand - reserved,
+	}, nil
+}
+
+func (s *stockServiceImpl) Level(ctx context.Context, key repositories.StockKey) (level *StockLevel, err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		level, err = s.level(tx, key, time.Now())
+		return err
+	})
+	return
+}
+
+func (s *stockServiceImpl) Movements(ctx context.Context, key repositories.StockKey, limit int, offset int) (movements *[]entities.StockMovement, err error) {
+	if limit <= 0 {
+		limit = StockMovementsDefaultLimit
+	}
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		movements, err = s.sr.FindMovements(tx, key, limit, offset)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Stock with Movements call StockRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	return
+}
+
+// signedStockQuantity validates the quantity of a movement and returns it
+// with the sign applied to the on-hand quantity.
+func signedStockQuantity(movementType enum.StockMovementType, quantity int) (int, error) {
+	switch movementType {
+	case enum.StockMovementTypeReceipt:
+		if quantity > 0 {
+			return quantity, nil
+		}
+	case enum.StockMovementTypeSale:
+		if quantity > 0 {
+			return -quantity, nil
+		}
+	case enum.StockMovementTypeAdjustment:
+		if quantity != 0 {
+			return quantity, nil
+		}
+	}
+	log.Info("Stock invalid movement", zap.String("movement_type", string(movementType)), zap.Int("quantity", quantity))
+	return 0, appErr.ServiceStatusBadRequestError
+}
+
+// Record appends a movement to the ledger. Movements taking stock out are
+// rejected when they would eat into the reserved or missing quantity.
+func (s *stockServiceImpl) Record(ctx context.Context, movementEntity *entities.StockMovement) (err error) {
+	quantity, err := signedStockQuantity(movementEntity.Type, movementEntity.Quantity)
+	if err != nil {
+		return
+	}
+	movementEntity.Quantity = quantity
+	movementEntity.StockReservationID = nil
+	key := repositories.StockKey{ItemID: movementEntity.ItemID, ItemVariantID: movementEntity.ItemVariantID}
+
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.lock(tx, key); err != nil {
+			return err
+		}
+		if quantity < 0 {
+			level, err := s.level(tx, key, time.Now())
+			if err != nil {
+				return err
+			}
+			if level.Available+int64(quantity) < 0 {
+				log.Ctx(ctx).Info("Stock insufficient", logger.ItemID(key.ItemID), zap.Int64("available", level.Available), zap.Int("requested", -quantity))
+				return appErr.ServiceStatusBadRequestError
+			}
+		}
+		err := s.sr.CreateMovement(tx, movementEntity)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Stock with Record call StockRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	return
+}
+
+// Reserve holds quantity aside for ttl. The available quantity is checked
+// under the item row lock, so concurrent reservations can not oversell.
+func (s *stockServiceImpl) Reserve(ctx context.Context, key repositories.StockKey, quantity int, ttl time.Duration) (reservation *entities.StockReservation, err error) {
+	if quantity <= 0 || ttl > StockReservationMaxTTL {
+		return nil, appErr.ServiceStatusBadRequestError
+	}
+	if ttl <= 0 {
+		ttl = StockReservationDefaultTTL
+	}
+
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.lock(tx, key); err != nil {
+			return err
+		}
+		now := time.Now()
+		if err := s.sr.ExpireReservations(tx, key, now); err != nil {
+			log.Ctx(ctx).Error("occurred error when Stock with Reserve call StockRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		level, err := s.level(tx, key, now)
+		if err != nil {
+			return err
+		}
+		if level.Available < int64(quantity) {
+			log.Ctx(ctx).Info("Stock insufficient", logger.ItemID(key.ItemID), zap.Int64("available", level.Available), zap.Int("requested", quantity))
+			return appErr.ServiceStatusBadRequestError
+		}
+		reservation = &entities.StockReservation{
+			ItemID:        key.ItemID,
+			ItemVariantID: key.ItemVariantID,
+			Quantity:      quantity,
+			Status:        enum.StockReservationStatusActive,
+			ExpiresAt:     now.Add(ttl),
+		}
+		err = s.sr.CreateReservation(tx, reservation)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Stock with Reserve call StockRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	return
+}
+
+// Commit turns an active reservation into a sale movement.
+func (s *stockServiceImpl) Commit(ctx context.Context, reservationID int) (movement *entities.StockMovement, err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		reservation, err := s.sr.FindReservationByID(tx, reservationID)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Stock with Commit call StockRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		if reservation == nil {
+			return appErr.ServiceStatusBadRequestError
+		}
+		key := repositories.StockKey{ItemID: reservation.ItemID, ItemVariantID: reservation.ItemVariantID}
+		if err := s.lock(tx, key); err != nil {
+			return err
+		}
+		ok, err := s.sr.TransitReservation(tx, reservation.ID, enum.StockReservationStatusCommitted, time.Now())
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Stock with Commit call StockRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		if !ok {
+			log.Ctx(ctx).Info("Stock reservation is not active", zap.Uint("reservation_id", reservation.ID))
+			return appErr.ServiceStatusBadRequestError
+		}
+		movement = &entities.StockMovement{
+			ItemID:             reservation.ItemID,
+			ItemVariantID:      reservation.ItemVariantID,
+			Type:               enum.StockMovementTypeSale,
+			Quantity:           -reservation.Quantity,
+			StockReservationID: &reservation.ID,
+		}
+		err = s.sr.CreateMovement(tx, movement)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Stock with Commit call StockRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	return
+}
+
+func (s *stockServiceImpl) Release(ctx context.Context, reservationID int) (err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ok, err := s.sr.TransitReservation(tx, uint(reservationID), enum.StockReservationStatusReleased, time.Now())
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Stock with Release call StockRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		if !ok {
+			return appErr.ServiceStatusBadRequestError
+		}
+		return nil
+	})
+	return
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	"github.com/genpsp/go-app/domain/enum"
+	repositories "github.com/genpsp/go-app/domain/repository"
+	"github.com/genpsp/go-app/domain/repository/mock_repositories"
+	"github.com/genpsp/go-app/pkg/configs"
+	"github.com/genpsp/go-app/pkg/logger"
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_StockService(t *testing.T) {
+	Convey("StockServiceを初期化", t, func() {
+		configs.TestLoadConfig()
+		cfg := configs.GetConfig()
+		logger.LoadLogger(cfg.System.Env, cfg.Logger.LogLevel, cfg.Logger.LogEncoding)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		db, mock, _ := mock_repositories.GetDBMock()
+		sr := mock_repositories.NewMockStockRepository(ctrl)
+		ivr := mock_repositories.NewMockItemVariantRepository(ctrl)
+		ss := NewStockService(sr, ivr, db)
+
+		key := repositories.StockKey{ItemID: 1}
+
+		Convey("行ロック後の引当可能数の範囲で予約できる", func() {
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			gomock.InOrder(
+				sr.EXPECT().LockItem(gomock.Any(), uint(1)).Return(true, nil),
+				sr.EXPECT().ExpireReservations(gomock.Any(), key, gomock.Any()).Return(nil),
+				sr.EXPECT().OnHand(gomock.Any(), key).Return(int64(10), nil),
+				sr.EXPECT().Reserved(gomock.Any(), key, gomock.Any()).Return(int64(7), nil),
+				sr.EXPECT().CreateReservation(gomock.Any(), gomock.Any()).Return(nil),
+			)
+
+			reservation, err := ss.Reserve(context.Background(), key, 3, 0)
+			So(err, ShouldBeNil)
+			So(reservation.Status, ShouldEqual, enum.StockReservationStatusActive)
+			So(reservation.ExpiresAt, ShouldHappenWithin, time.Minute, time.Now().Add(StockReservationDefaultTTL))
+		})
+		Convey("引当可能数を超える予約はできない", func() {
+			mock.ExpectBegin()
+			mock.ExpectRollback()
+			sr.EXPECT().LockItem(gomock.Any(), uint(1)).Return(true, nil)
+			sr.EXPECT().ExpireReservations(gomock.Any(), key, gomock.Any()).Return(nil)
+			sr.EXPECT().OnHand(gomock.Any(), key).Return(int64(10), nil)
+			sr.EXPECT().Reserved(gomock.Any(), key, gomock.Any()).Return(int64(8), nil)
+
+			_, err := ss.Reserve(context.Background(), key, 3, 0)
+			So(err, ShouldNotBeNil)
+		})
+		Convey("出荷は符号を反転して記録する", func() {
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			sr.EXPECT().LockItem(gomock.Any(), uint(1)).Return(true, nil)
+			sr.EXPECT().OnHand(gomock.Any(), key).Return(int64(5), nil)
+			sr.EXPECT().Reserved(gomock.Any(), key, gomock.Any()).Return(int64(0), nil)
+			sr.EXPECT().CreateMovement(gomock.Any(), gomock.Any()).Return(nil)
+
+			movement := &entities.StockMovement{ItemID: 1, Type: enum.StockMovementTypeSale, Quantity: 5}
+			err := ss.Record(context.Background(), movement)
+			So(err, ShouldBeNil)
+			So(movement.Quantity, ShouldEqual, -5)
+		})
+		Convey("数量0の調整は記録できない", func() {
+			err := ss.Record(context.Background(), &entities.StockMovement{ItemID: 1, Type: enum.StockMovementTypeAdjustment})
+			So(err, ShouldNotBeNil)
+		})
+		Convey("期限切れの予約は確定できない", func() {
+			mock.ExpectBegin()
+			mock.ExpectRollback()
+			sr.EXPECT().FindReservationByID(gomock.Any(), 9).Return(&entities.StockReservation{ItemID: 1, Quantity: 2}, nil)
+			sr.EXPECT().LockItem(gomock.Any(), uint(1)).Return(true, nil)
+			sr.EXPECT().TransitReservation(gomock.Any(), gomock.Any(), enum.StockReservationStatusCommitted, gomock.Any()).Return(false, nil)
+
+			_, err := ss.Commit(context.Background(), 9)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	repositories "github.com/genpsp/go-app/domain/repository"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const MaxTagNameLength = 64
+
+type (
+	TagService interface {
+		FindAll(ctx context.Context) (tags *[]entities.Tag, err error)
+		Create(ctx context.Context, tagEntity *entities.Tag) (err error)
+		Delete(ctx context.Context, tagID int) (err error)
+		AssignItem(ctx context.Context, itemID int, names []string) (err error)
+	}
+
+	tagServiceImpl struct {
+		tr     repositories.TagRepository
+		ir     repositories.ItemRepository
+		master *gorm.DB
+	}
+)
+
+func NewTagService(
+	tagRepo repositories.TagRepository,
+	itemRepo repositories.ItemRepository,
+	m *gorm.DB) TagService {
+
+	return &tagServiceImpl{
+		tr:     tagRepo,
+		ir:     itemRepo,
+		master: m,
+	}
+}
+
+// NormalizeTagNames trims and lower cases the names, dropping blanks and duplicates.
+func NormalizeTagNames(names []string) []string {
+	seen := make(map[string]struct{}, len(names))
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		normalized = append(normalized, name)
+	}
+	return normalized
+}
+
+func validateTagNames(names []string) error {
+	for _, name := range names {
+		if len([]rune(name)) > MaxTagNameLength {
+			log.Info("Tag name too long", zap.String("name", name))
+			return appErr.ServiceStatusBadRequestError
+		}
+	}
+	return nil
+}
+
+func (s *tagServiceImpl) FindAll(ctx context.Context) (tags *[]entities.Tag, err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tags, err = s.tr.FindAll(tx)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Tag with FindAll call TagRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	return
+}
+
+func (s *tagServiceImpl) Create(ctx context.Context, tagEntity *entities.Tag) (err error) {
+	names := NormalizeTagNames([]string{tagEntity.Name})
+	if len(names) == 0 {
+		return appErr.ServiceStatusBadRequestError
+	}
+	if err = validateTagNames(names); err != nil {
+		return
+	}
+	tagEntity.Name = names[0]
+
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := s.tr.Create(tx, tagEntity)
+		if errors.Is(err, repositories.ErrDuplicateKey) {
+			return appErr.ServiceStatusBadRequestError
+		}
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Tag with Create call TagRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	return
+}
+
+func (s *tagServiceImpl) Delete(ctx context.Context, tagID int) (err error) {
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := s.tr.Delete(tx, tagID)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Tag with Delete call TagRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	return
+}
+
+// AssignItem replaces the tags of the item with names, creating unknown tags on the fly.
+func (s *tagServiceImpl) AssignItem(ctx context.Context, itemID int, names []string) (err error) {
+	names = NormalizeTagNames(names)
+	if err = validateTagNames(names); err != nil {
+		return
+	}
+
+	err = s.master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		item, err := s.ir.FindByID(tx, itemID)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Tag with AssignItem call ItemRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		if item == nil {
+			return appErr.ServiceStatusBadRequestError
+		}
+		tags := []entities.Tag{}
+		if len(names) > 0 {
+			found, err := s.tr.FindOrCreateByNames(tx, names)
+			if err != nil {
+				log.Ctx(ctx).Error("occurred error when Tag with AssignItem call TagRepository", zap.Error(err))
+				return appErr.BindServiceErrorWithDBError(err)
+			}
+			tags = *found
+		}
+		err = s.ir.ReplaceTags(tx, item, tags)
+		if err != nil {
+			log.Ctx(ctx).Error("occurred error when Tag with AssignItem call ItemRepository", zap.Error(err))
+			return appErr.BindServiceErrorWithDBError(err)
+		}
+		return nil
+	})
+	return
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	entities "github.com/genpsp/go-app/domain/entities"
+	repositories "github.com/genpsp/go-app/domain/repository"
+	"github.com/genpsp/go-app/domain/repository/mock_repositories"
+	"github.com/genpsp/go-app/pkg/configs"
+	"github.com/genpsp/go-app/pkg/logger"
+	appErr "github.com/genpsp/go-app/pkg/server/error"
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_TagService(t *testing.T) {
+	Convey("TagServiceを初期化", t, func() {
+		configs.TestLoadConfig()
+		cfg := configs.GetConfig()
+		logger.LoadLogger(cfg.System.Env, cfg.Logger.LogLevel, cfg.Logger.LogEncoding)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		db, mock, _ := mock_repositories.GetDBMock()
+		tr := mock_repositories.NewMockTagRepository(ctrl)
+		ir := mock_repositories.NewMockItemRepository(ctrl)
+		ts := NewTagService(tr, ir, db)
+
+		Convey("正規化した名前でタグを作成できる", func() {
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			tr.EXPECT().Create(gomock.Any(), &entities.Tag{Name: "sale"}).Return(nil)
+
+			err := ts.Create(context.Background(), &entities.Tag{Name: " Sale "})
+			So(err, ShouldBeNil)
+		})
+		Convey("既に存在する名前の場合エラーを返す", func() {
+			mock.ExpectBegin()
+			mock.ExpectRollback()
+			tr.EXPECT().Create(gomock.Any(), gomock.Any()).Return(repositories.ErrDuplicateKey)
+
+			err := ts.Create(context.Background(), &entities.Tag{Name: "sale"})
+			So(err, ShouldEqual, appErr.ServiceStatusBadRequestError)
+		})
+	})
+}